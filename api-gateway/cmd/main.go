@@ -7,9 +7,12 @@ import (
 )
 
 func main() {
-	server := app.NewApp()
+	server, err := app.NewApp()
+	if err != nil {
+		log.Fatalf("failed to initialize gateway: %v", err)
+	}
 
-	log.Println("API Gateway started on :8080")
+	log.Println("API Gateway started")
 	if err := server.Start(); err != nil {
 		log.Fatalf("failed to start server: %v", err)
 	}
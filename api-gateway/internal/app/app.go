@@ -1,44 +1,34 @@
 package app
 
 import (
-	"api-gateway/internal/controller"
+	"api-gateway/internal/config"
 	"api-gateway/internal/middleware"
 	"api-gateway/internal/server"
-	"google.golang.org/grpc"
 )
 
 type App struct {
 	server *server.Server
 }
 
-func NewApp() *App {
-	s := server.NewServer()
+func NewApp() (*App, error) {
+	cfg := config.Load()
 
-	// Initialize controllers with gRPC connections
-	inventoryController := controller.NewInventoryController(s.InventoryConn)
-	orderController := controller.NewOrderController(s.OrderConn)
-
-	// Setup routes with middleware
-	router := s.GinEngine
-	router.Use(middleware.AuthMiddleware())
+	verifier, err := middleware.NewTokenVerifier(cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
 
-	// Inventory routes
-	inventory := router.Group("/inventory")
-	{
-		inventory.GET("/products", inventoryController.ListProducts)
-		inventory.GET("/products/:id", inventoryController.GetProduct)
-		// Add other inventory routes
+	signer, err := middleware.NewIdentitySigner(cfg.ServiceAuthSecret)
+	if err != nil {
+		return nil, err
 	}
 
-	// Order routes
-	orders := router.Group("/orders")
-	{
-		orders.POST("/", orderController.CreateOrder)
-		orders.GET("/:id", orderController.GetOrder)
-		// Add other order routes
+	policy, err := middleware.LoadRoutePolicy(cfg.RBACPolicy)
+	if err != nil {
+		return nil, err
 	}
 
-	return &App{server: s}
+	return &App{server: server.NewServer(cfg, verifier, signer, policy)}, nil
 }
 
 func (a *App) Start() error {
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+
+	"api-gateway/internal/middleware"
+)
+
+type Config struct {
+	HTTPAddr      string
+	InventoryAddr string
+	OrderAddr     string
+	Auth          middleware.AuthConfig
+	RBACPolicy    string
+	// ServiceAuthSecret signs the caller identities forwarded to the
+	// backend services, which share it
+	ServiceAuthSecret string
+}
+
+// Load reads the gateway configuration from the environment.
+func Load() Config {
+	return Config{
+		HTTPAddr:      getEnv("HTTP_ADDR", ":8080"),
+		InventoryAddr: getEnv("INVENTORY_SERVICE_ADDR", "inventory-service:50051"),
+		OrderAddr:     getEnv("ORDER_SERVICE_ADDR", "order-service:50052"),
+		Auth: middleware.AuthConfig{
+			HMACSecret: os.Getenv("JWT_HS256_SECRET"),
+			JWKSFile:   os.Getenv("JWT_JWKS_FILE"),
+			Issuer:     os.Getenv("JWT_ISSUER"),
+			Audience:   os.Getenv("JWT_AUDIENCE"),
+		},
+		RBACPolicy:        getEnv("RBAC_POLICY_FILE", "config/rbac.yaml"),
+		ServiceAuthSecret: os.Getenv("SERVICE_AUTH_SECRET"),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// writeError translates a gRPC error from a backend service into an HTTP
+// response. A conflict on a request made conditional with If-Match means
+// the precondition failed.
+func writeError(ctx *gin.Context, err error) {
+	st := status.Convert(err)
+	code := httpStatus(st.Code())
+	if st.Code() == codes.Aborted && ctx.GetHeader("If-Match") != "" {
+		code = http.StatusPreconditionFailed
+	}
+	ctx.JSON(code, gin.H{"error": st.Message()})
+}
+
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted:
+		return http.StatusConflict
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
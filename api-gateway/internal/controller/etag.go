@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag exposes an entity version as a strong ETag.
+func setETag(ctx *gin.Context, version int64) {
+	if version > 0 {
+		ctx.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+	}
+}
+
+// ifMatch reads the version a conditional update expects from the If-Match
+// header. A missing header or "*" puts no condition on the update.
+func ifMatch(ctx *gin.Context) (int64, bool) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	tag, err := strconv.Unquote(strings.TrimPrefix(header, "W/"))
+	if err != nil {
+		tag = header
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
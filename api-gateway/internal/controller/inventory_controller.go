@@ -3,9 +3,10 @@ package controller
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/yourusername/ecommerce/protos/inventory"
+	"github.com/abaika-abay/ecommerce/protos/inventory"
 	"google.golang.org/grpc"
 )
 
@@ -19,20 +20,82 @@ func NewInventoryController(conn *grpc.ClientConn) *InventoryController {
 	}
 }
 
-// ListProducts handles GET /products?page=X&limit=Y
+// ListProducts handles GET /products?limit=X&category_id=Y&include_descendants=true&include_archived=true&page_token=Z
 // Corresponds to: rpc ListProducts(ListProductsRequest) returns (ListProductsResponse)
 func (c *InventoryController) ListProducts(ctx *gin.Context) {
-	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	includeDescendants, _ := strconv.ParseBool(ctx.Query("include_descendants"))
+	includeArchived, _ := strconv.ParseBool(ctx.Query("include_archived"))
 
 	req := &inventory.ListProductsRequest{
-		Page:  int32(page),
-		Limit: int32(limit),
+		Limit:              int32(limit),
+		CategoryId:         ctx.Query("category_id"),
+		IncludeDescendants: includeDescendants,
+		IncludeArchived:    includeArchived,
+		PageToken:          ctx.Query("page_token"),
 	}
 
 	res, err := c.client.ListProducts(ctx.Request.Context(), req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// SearchProducts handles GET /products/search?q=X&category_id=Y&min_price=Z&max_price=W&in_stock=true&include_archived=true&sort=price&order=asc&page=X&limit=Y
+// category_id may be repeated or comma-separated.
+// Corresponds to: rpc SearchProducts(SearchProductsRequest) returns (ListProductsResponse)
+func (c *InventoryController) SearchProducts(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	req := &inventory.SearchProductsRequest{
+		Query:     ctx.Query("q"),
+		SortBy:    ctx.Query("sort"),
+		SortOrder: ctx.Query("order"),
+		Page:      int32(page),
+		Limit:     int32(limit),
+	}
+
+	for _, value := range ctx.QueryArray("category_id") {
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				req.CategoryIds = append(req.CategoryIds, id)
+			}
+		}
+	}
+
+	var err error
+	if value := ctx.Query("min_price"); value != "" {
+		if req.MinPrice, err = strconv.ParseFloat(value, 64); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid min_price"})
+			return
+		}
+	}
+	if value := ctx.Query("max_price"); value != "" {
+		if req.MaxPrice, err = strconv.ParseFloat(value, 64); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid max_price"})
+			return
+		}
+	}
+	if value := ctx.Query("in_stock"); value != "" {
+		if req.InStockOnly, err = strconv.ParseBool(value); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid in_stock"})
+			return
+		}
+	}
+	if value := ctx.Query("include_archived"); value != "" {
+		if req.IncludeArchived, err = strconv.ParseBool(value); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_archived"})
+			return
+		}
+	}
+
+	res, err := c.client.SearchProducts(ctx.Request.Context(), req)
+	if err != nil {
+		writeError(ctx, err)
 		return
 	}
 
@@ -46,10 +109,11 @@ func (c *InventoryController) GetProduct(ctx *gin.Context) {
 
 	res, err := c.client.GetProductByID(ctx.Request.Context(), &inventory.GetProductRequest{Id: id})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(ctx, err)
 		return
 	}
 
+	setETag(ctx, res.GetProduct().GetVersion())
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -64,33 +128,561 @@ func (c *InventoryController) CreateProduct(ctx *gin.Context) {
 
 	res, err := c.client.CreateProduct(ctx.Request.Context(), &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, res)
 }
 
-// UpdateProductStock handles PUT /products/:id/stock
-// Corresponds to: rpc UpdateProductStock(UpdateProductStockRequest) returns (ProductResponse)
-func (c *InventoryController) UpdateProductStock(ctx *gin.Context) {
+// UpdateProduct handles PUT /products/:id
+// An If-Match header makes the update conditional on the product's ETag.
+// Corresponds to: rpc UpdateProduct(UpdateProductRequest) returns (ProductResponse)
+func (c *InventoryController) UpdateProduct(ctx *gin.Context) {
+	var req inventory.UpdateProductRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Id = ctx.Param("id")
+
+	version, ok := ifMatch(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid If-Match header"})
+		return
+	}
+	if version != 0 {
+		req.ExpectedVersion = version
+	}
+
+	res, err := c.client.UpdateProduct(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	setETag(ctx, res.GetProduct().GetVersion())
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteProduct handles DELETE /products/:id
+// The product is archived and can be restored until it is purged.
+// Corresponds to: rpc DeleteProduct(DeleteProductRequest) returns (Empty)
+func (c *InventoryController) DeleteProduct(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	_, err := c.client.DeleteProduct(ctx.Request.Context(), &inventory.DeleteProductRequest{Id: id})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RestoreProduct handles POST /products/:id/restore
+// Corresponds to: rpc RestoreProduct(RestoreProductRequest) returns (ProductResponse)
+func (c *InventoryController) RestoreProduct(ctx *gin.Context) {
 	id := ctx.Param("id")
-	var reqBody struct {
-		Stock int32 `json:"stock"`
+
+	res, err := c.client.RestoreProduct(ctx.Request.Context(), &inventory.RestoreProductRequest{Id: id})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	setETag(ctx, res.GetProduct().GetVersion())
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListCategories handles GET /categories?parent_id=X&include_descendants=true
+// Corresponds to: rpc ListCategories(ListCategoriesRequest) returns (ListCategoriesResponse)
+func (c *InventoryController) ListCategories(ctx *gin.Context) {
+	includeDescendants, _ := strconv.ParseBool(ctx.Query("include_descendants"))
+
+	res, err := c.client.ListCategories(ctx.Request.Context(), &inventory.ListCategoriesRequest{
+		ParentId:           ctx.Query("parent_id"),
+		IncludeDescendants: includeDescendants,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetCategory handles GET /categories/:id
+// Corresponds to: rpc GetCategory(GetCategoryRequest) returns (CategoryResponse)
+func (c *InventoryController) GetCategory(ctx *gin.Context) {
+	res, err := c.client.GetCategory(ctx.Request.Context(), &inventory.GetCategoryRequest{Id: ctx.Param("id")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateCategory handles POST /categories
+// Corresponds to: rpc CreateCategory(CreateCategoryRequest) returns (CategoryResponse)
+func (c *InventoryController) CreateCategory(ctx *gin.Context) {
+	var req inventory.CreateCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := c.client.CreateCategory(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// UpdateCategory handles PUT /categories/:id
+// Corresponds to: rpc UpdateCategory(UpdateCategoryRequest) returns (CategoryResponse)
+func (c *InventoryController) UpdateCategory(ctx *gin.Context) {
+	var req inventory.UpdateCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
-	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+	req.Id = ctx.Param("id")
+
+	res, err := c.client.UpdateCategory(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteCategory handles DELETE /categories/:id
+// Corresponds to: rpc DeleteCategory(DeleteCategoryRequest) returns (Empty)
+func (c *InventoryController) DeleteCategory(ctx *gin.Context) {
+	_, err := c.client.DeleteCategory(ctx.Request.Context(), &inventory.DeleteCategoryRequest{Id: ctx.Param("id")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListVariants handles GET /products/:id/variants
+// Corresponds to: rpc ListVariants(ListVariantsRequest) returns (ListVariantsResponse)
+func (c *InventoryController) ListVariants(ctx *gin.Context) {
+	res, err := c.client.ListVariants(ctx.Request.Context(), &inventory.ListVariantsRequest{ProductId: ctx.Param("id")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateVariant handles POST /products/:id/variants
+// Corresponds to: rpc CreateVariant(CreateVariantRequest) returns (VariantResponse)
+func (c *InventoryController) CreateVariant(ctx *gin.Context) {
+	var req inventory.CreateVariantRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ProductId = ctx.Param("id")
+
+	res, err := c.client.CreateVariant(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// GetVariant handles GET /variants/:sku
+// Corresponds to: rpc GetVariant(GetVariantRequest) returns (VariantResponse)
+func (c *InventoryController) GetVariant(ctx *gin.Context) {
+	res, err := c.client.GetVariant(ctx.Request.Context(), &inventory.GetVariantRequest{Sku: ctx.Param("sku")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UpdateVariant handles PUT /variants/:sku
+// Corresponds to: rpc UpdateVariant(UpdateVariantRequest) returns (VariantResponse)
+func (c *InventoryController) UpdateVariant(ctx *gin.Context) {
+	var req inventory.UpdateVariantRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	req.Sku = ctx.Param("sku")
 
-	req := &inventory.UpdateProductStockRequest{
-		Id:    id,
-		Stock: reqBody.Stock,
+	res, err := c.client.UpdateVariant(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
 	}
 
-	res, err := c.client.UpdateProductStock(ctx.Request.Context(), req)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteVariant handles DELETE /variants/:sku
+// Corresponds to: rpc DeleteVariant(DeleteVariantRequest) returns (Empty)
+func (c *InventoryController) DeleteVariant(ctx *gin.Context) {
+	_, err := c.client.DeleteVariant(ctx.Request.Context(), &inventory.DeleteVariantRequest{Sku: ctx.Param("sku")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListWarehouses handles GET /warehouses
+// Corresponds to: rpc ListWarehouses(ListWarehousesRequest) returns (ListWarehousesResponse)
+func (c *InventoryController) ListWarehouses(ctx *gin.Context) {
+	res, err := c.client.ListWarehouses(ctx.Request.Context(), &inventory.ListWarehousesRequest{})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetWarehouse handles GET /warehouses/:id
+// Corresponds to: rpc GetWarehouse(GetWarehouseRequest) returns (WarehouseResponse)
+func (c *InventoryController) GetWarehouse(ctx *gin.Context) {
+	res, err := c.client.GetWarehouse(ctx.Request.Context(), &inventory.GetWarehouseRequest{Id: ctx.Param("id")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateWarehouse handles POST /warehouses
+// Corresponds to: rpc CreateWarehouse(CreateWarehouseRequest) returns (WarehouseResponse)
+func (c *InventoryController) CreateWarehouse(ctx *gin.Context) {
+	var req inventory.CreateWarehouseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := c.client.CreateWarehouse(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// UpdateWarehouse handles PUT /warehouses/:id
+// Corresponds to: rpc UpdateWarehouse(UpdateWarehouseRequest) returns (WarehouseResponse)
+func (c *InventoryController) UpdateWarehouse(ctx *gin.Context) {
+	var req inventory.UpdateWarehouseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Id = ctx.Param("id")
+
+	res, err := c.client.UpdateWarehouse(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteWarehouse handles DELETE /warehouses/:id
+// Corresponds to: rpc DeleteWarehouse(DeleteWarehouseRequest) returns (Empty)
+func (c *InventoryController) DeleteWarehouse(ctx *gin.Context) {
+	_, err := c.client.DeleteWarehouse(ctx.Request.Context(), &inventory.DeleteWarehouseRequest{Id: ctx.Param("id")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListWarehouseStock handles GET /warehouses/:id/stock
+// Corresponds to: rpc ListWarehouseStock(ListWarehouseStockRequest) returns (ListWarehouseStockResponse)
+func (c *InventoryController) ListWarehouseStock(ctx *gin.Context) {
+	res, err := c.client.ListWarehouseStock(ctx.Request.Context(), &inventory.ListWarehouseStockRequest{WarehouseId: ctx.Param("id")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// SetStockLevel handles PUT /warehouses/:id/stock
+// Corresponds to: rpc SetStockLevel(SetStockLevelRequest) returns (ProductStockResponse)
+func (c *InventoryController) SetStockLevel(ctx *gin.Context) {
+	var req inventory.SetStockLevelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.WarehouseId = ctx.Param("id")
+
+	res, err := c.client.SetStockLevel(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// TransferStock handles POST /stock/transfers
+// Corresponds to: rpc TransferStock(TransferStockRequest) returns (ProductStockResponse)
+func (c *InventoryController) TransferStock(ctx *gin.Context) {
+	var req inventory.TransferStockRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := c.client.TransferStock(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListStockMovements handles GET /stock/movements?product_id=X&variant_sku=Y&warehouse_id=Z&order_id=W&reason=R&limit=N&page_token=T
+// Corresponds to: rpc ListStockMovements(ListStockMovementsRequest) returns (ListStockMovementsResponse)
+func (c *InventoryController) ListStockMovements(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "50"))
+
+	req := &inventory.ListStockMovementsRequest{
+		ProductId:   ctx.Query("product_id"),
+		VariantSku:  ctx.Query("variant_sku"),
+		WarehouseId: ctx.Query("warehouse_id"),
+		OrderId:     ctx.Query("order_id"),
+		Reason:      ctx.Query("reason"),
+		Limit:       int32(limit),
+		PageToken:   ctx.Query("page_token"),
+	}
+
+	res, err := c.client.ListStockMovements(ctx.Request.Context(), req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ReconcileStock handles POST /products/:id/stock/reconcile?apply=true
+// Without apply the discrepancies are only reported.
+// Corresponds to: rpc ReconcileStock(ReconcileStockRequest) returns (ReconcileStockResponse)
+func (c *InventoryController) ReconcileStock(ctx *gin.Context) {
+	apply, _ := strconv.ParseBool(ctx.Query("apply"))
+
+	req := &inventory.ReconcileStockRequest{
+		ProductId: ctx.Param("id"),
+		Apply:     apply,
+	}
+
+	res, err := c.client.ReconcileStock(ctx.Request.Context(), req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListLowStock handles GET /stock/low
+// Corresponds to: rpc ListLowStock(ListLowStockRequest) returns (ListLowStockResponse)
+func (c *InventoryController) ListLowStock(ctx *gin.Context) {
+	res, err := c.client.ListLowStock(ctx.Request.Context(), &inventory.ListLowStockRequest{})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// AdjustStock handles POST /products/:id/stock/adjustments
+// The body carries a signed delta, e.g. {"delta": -2, "reason": "damage"}.
+// Corresponds to: rpc AdjustStock(AdjustStockRequest) returns (AdjustStockResponse)
+func (c *InventoryController) AdjustStock(ctx *gin.Context) {
+	var req inventory.AdjustStockRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ProductId = ctx.Param("id")
+
+	res, err := c.client.AdjustStock(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListSuppliers handles GET /suppliers
+// Corresponds to: rpc ListSuppliers(ListSuppliersRequest) returns (ListSuppliersResponse)
+func (c *InventoryController) ListSuppliers(ctx *gin.Context) {
+	res, err := c.client.ListSuppliers(ctx.Request.Context(), &inventory.ListSuppliersRequest{})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetSupplier handles GET /suppliers/:id
+// Corresponds to: rpc GetSupplier(GetSupplierRequest) returns (SupplierResponse)
+func (c *InventoryController) GetSupplier(ctx *gin.Context) {
+	res, err := c.client.GetSupplier(ctx.Request.Context(), &inventory.GetSupplierRequest{Id: ctx.Param("id")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateSupplier handles POST /suppliers
+// Corresponds to: rpc CreateSupplier(CreateSupplierRequest) returns (SupplierResponse)
+func (c *InventoryController) CreateSupplier(ctx *gin.Context) {
+	var req inventory.CreateSupplierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := c.client.CreateSupplier(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// UpdateSupplier handles PUT /suppliers/:id
+// Corresponds to: rpc UpdateSupplier(UpdateSupplierRequest) returns (SupplierResponse)
+func (c *InventoryController) UpdateSupplier(ctx *gin.Context) {
+	var req inventory.UpdateSupplierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Id = ctx.Param("id")
+
+	res, err := c.client.UpdateSupplier(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListPurchaseOrders handles GET /purchase-orders?supplier_id=X&status=Y&limit=N&page_token=T
+// Corresponds to: rpc ListPurchaseOrders(ListPurchaseOrdersRequest) returns (ListPurchaseOrdersResponse)
+func (c *InventoryController) ListPurchaseOrders(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	req := &inventory.ListPurchaseOrdersRequest{
+		SupplierId: ctx.Query("supplier_id"),
+		Status:     ctx.Query("status"),
+		Limit:      int32(limit),
+		PageToken:  ctx.Query("page_token"),
+	}
+
+	res, err := c.client.ListPurchaseOrders(ctx.Request.Context(), req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetPurchaseOrder handles GET /purchase-orders/:id
+// Corresponds to: rpc GetPurchaseOrder(GetPurchaseOrderRequest) returns (PurchaseOrderResponse)
+func (c *InventoryController) GetPurchaseOrder(ctx *gin.Context) {
+	res, err := c.client.GetPurchaseOrder(ctx.Request.Context(), &inventory.GetPurchaseOrderRequest{Id: ctx.Param("id")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreatePurchaseOrder handles POST /purchase-orders
+// Corresponds to: rpc CreatePurchaseOrder(CreatePurchaseOrderRequest) returns (PurchaseOrderResponse)
+func (c *InventoryController) CreatePurchaseOrder(ctx *gin.Context) {
+	var req inventory.CreatePurchaseOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := c.client.CreatePurchaseOrder(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// ReceivePurchaseOrder handles POST /purchase-orders/:id/receipts
+// The body lists the delivered units, e.g. {"lines": [{"product_id": "X", "quantity": 5}]}.
+// Corresponds to: rpc ReceivePurchaseOrder(ReceivePurchaseOrderRequest) returns (PurchaseOrderResponse)
+func (c *InventoryController) ReceivePurchaseOrder(ctx *gin.Context) {
+	var req inventory.ReceivePurchaseOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Id = ctx.Param("id")
+
+	res, err := c.client.ReceivePurchaseOrder(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CancelPurchaseOrder handles POST /purchase-orders/:id/cancel
+// Corresponds to: rpc CancelPurchaseOrder(CancelPurchaseOrderRequest) returns (PurchaseOrderResponse)
+func (c *InventoryController) CancelPurchaseOrder(ctx *gin.Context) {
+	res, err := c.client.CancelPurchaseOrder(ctx.Request.Context(), &inventory.CancelPurchaseOrderRequest{Id: ctx.Param("id")})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(ctx, err)
 		return
 	}
 
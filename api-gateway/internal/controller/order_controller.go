@@ -30,7 +30,7 @@ func (c *OrderController) CreateOrder(ctx *gin.Context) {
 
 	res, err := c.client.CreateOrder(ctx.Request.Context(), &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(ctx, err)
 		return
 	}
 
@@ -44,52 +44,142 @@ func (c *OrderController) GetOrder(ctx *gin.Context) {
 
 	res, err := c.client.GetOrderByID(ctx.Request.Context(), &order.GetOrderRequest{Id: id})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(ctx, err)
 		return
 	}
 
+	setETag(ctx, res.GetOrder().GetVersion())
 	ctx.JSON(http.StatusOK, res)
 }
 
 // UpdateOrderStatus handles HTTP PUT /orders/:id/status
+// An If-Match header makes the update conditional on the order's ETag.
 // Corresponds to: rpc UpdateOrderStatus(UpdateOrderStatusRequest) returns (OrderResponse)
 func (c *OrderController) UpdateOrderStatus(ctx *gin.Context) {
 	id := ctx.Param("id")
 	var reqBody struct {
 		Status string `json:"status"`
+		Reason string `json:"reason"`
 	}
 
 	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	version, ok := ifMatch(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid If-Match header"})
+		return
+	}
 
 	res, err := c.client.UpdateOrderStatus(ctx.Request.Context(), &order.UpdateOrderStatusRequest{
+		Id:              id,
+		Status:          reqBody.Status,
+		Reason:          reqBody.Reason,
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	setETag(ctx, res.GetOrder().GetVersion())
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CancelOrder handles HTTP POST /orders/:id/cancel
+// Corresponds to: rpc CancelOrder(CancelOrderRequest) returns (OrderResponse)
+func (c *OrderController) CancelOrder(ctx *gin.Context) {
+	id := ctx.Param("id")
+	var reqBody struct {
+		Reason string `json:"reason"`
+	}
+
+	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := c.client.CancelOrder(ctx.Request.Context(), &order.CancelOrderRequest{
 		Id:     id,
-		Status: reqBody.Status,
+		Reason: reqBody.Reason,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	setETag(ctx, res.GetOrder().GetVersion())
+	ctx.JSON(http.StatusOK, res)
+}
+
+// PayOrder handles HTTP POST /orders/:id/payments
+// Corresponds to: rpc PayOrder(PayOrderRequest) returns (OrderResponse)
+func (c *OrderController) PayOrder(ctx *gin.Context) {
+	id := ctx.Param("id")
+	var reqBody struct {
+		PaymentMethod string `json:"payment_method"`
+	}
+
+	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := c.client.PayOrder(ctx.Request.Context(), &order.PayOrderRequest{
+		OrderId:       id,
+		PaymentMethod: reqBody.PaymentMethod,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(ctx, err)
+		return
+	}
+
+	setETag(ctx, res.GetOrder().GetVersion())
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListOrderPayments handles HTTP GET /orders/:id/payments
+// Corresponds to: rpc ListOrderPayments(ListPaymentsRequest) returns (ListPaymentsResponse)
+func (c *OrderController) ListOrderPayments(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	res, err := c.client.ListOrderPayments(ctx.Request.Context(), &order.ListPaymentsRequest{OrderId: id})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetOrderSaga handles HTTP GET /orders/:id/saga
+// Corresponds to: rpc GetOrderSaga(GetOrderSagaRequest) returns (SagaResponse)
+func (c *OrderController) GetOrderSaga(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	res, err := c.client.GetOrderSaga(ctx.Request.Context(), &order.GetOrderSagaRequest{OrderId: id})
+	if err != nil {
+		writeError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, res)
 }
 
-// ListUserOrders handles HTTP GET /users/:user_id/orders?page=X&limit=Y
+// ListUserOrders handles HTTP GET /users/:user_id/orders?limit=X&page_token=Y
 // Corresponds to: rpc ListUserOrders(ListOrdersRequest) returns (ListOrdersResponse)
 func (c *OrderController) ListUserOrders(ctx *gin.Context) {
 	userID := ctx.Param("user_id")
-	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 
 	res, err := c.client.ListUserOrders(ctx.Request.Context(), &order.ListOrdersRequest{
-		UserId: userID,
-		Page:   int32(page),
-		Limit:  int32(limit),
+		UserId:    userID,
+		Limit:     int32(limit),
+		PageToken: ctx.Query("page_token"),
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(ctx, err)
 		return
 	}
 
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/ecommerce/protos/order"
+)
+
+// RequestReturn handles HTTP POST /orders/:id/returns
+// Corresponds to: rpc RequestReturn(RequestReturnRequest) returns (ReturnResponse)
+func (c *OrderController) RequestReturn(ctx *gin.Context) {
+	var req order.RequestReturnRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.OrderId = ctx.Param("id")
+
+	res, err := c.client.RequestReturn(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// ListOrderReturns handles HTTP GET /orders/:id/returns
+// Corresponds to: rpc ListOrderReturns(ListReturnsRequest) returns (ListReturnsResponse)
+func (c *OrderController) ListOrderReturns(ctx *gin.Context) {
+	res, err := c.client.ListOrderReturns(ctx.Request.Context(), &order.ListReturnsRequest{OrderId: ctx.Param("id")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetReturn handles HTTP GET /returns/:id
+// Corresponds to: rpc GetReturn(GetReturnRequest) returns (ReturnResponse)
+func (c *OrderController) GetReturn(ctx *gin.Context) {
+	res, err := c.client.GetReturn(ctx.Request.Context(), &order.GetReturnRequest{Id: ctx.Param("id")})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ApproveReturn handles HTTP POST /returns/:id/approve
+// Corresponds to: rpc ApproveReturn(ReviewReturnRequest) returns (ReturnResponse)
+func (c *OrderController) ApproveReturn(ctx *gin.Context) {
+	req, ok := bindReview(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := c.client.ApproveReturn(ctx.Request.Context(), req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RejectReturn handles HTTP POST /returns/:id/reject
+// Corresponds to: rpc RejectReturn(ReviewReturnRequest) returns (ReturnResponse)
+func (c *OrderController) RejectReturn(ctx *gin.Context) {
+	req, ok := bindReview(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := c.client.RejectReturn(ctx.Request.Context(), req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ReceiveReturn handles HTTP POST /returns/:id/receive
+// Corresponds to: rpc ReceiveReturn(ReceiveReturnRequest) returns (ReturnResponse)
+func (c *OrderController) ReceiveReturn(ctx *gin.Context) {
+	var req order.ReceiveReturnRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Id = ctx.Param("id")
+
+	res, err := c.client.ReceiveReturn(ctx.Request.Context(), &req)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// bindReview reads the optional note of an approval or rejection. It
+// writes the error response itself and reports false when the body is
+// malformed.
+func bindReview(ctx *gin.Context) (*order.ReviewReturnRequest, bool) {
+	var reqBody struct {
+		Note string `json:"note"`
+	}
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil, false
+		}
+	}
+
+	return &order.ReviewReturnRequest{Id: ctx.Param("id"), Note: reqBody.Note}, true
+}
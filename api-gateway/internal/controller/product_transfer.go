@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"github.com/gin-gonic/gin"
+)
+
+// transferChunkSize is how many bytes of an upload go into each
+// ImportProducts message.
+const transferChunkSize = 32 * 1024
+
+// Content types of the bulk product file formats
+var transferContentTypes = map[string]string{
+	"csv":   "text/csv",
+	"jsonl": "application/x-ndjson",
+}
+
+// ImportProducts handles POST /products/import?format=csv|jsonl
+// The request body is the file. Without a format parameter it is taken
+// from the Content-Type header.
+// Corresponds to: rpc ImportProducts(stream ImportProductsRequest) returns (ImportProductsResponse)
+func (c *InventoryController) ImportProducts(ctx *gin.Context) {
+	format := ctx.Query("format")
+	if format == "" {
+		format = formatOfContentType(ctx.ContentType())
+	}
+
+	// Cancelling abandons the import when the upload breaks off
+	rpcCtx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+
+	stream, err := c.client.ImportProducts(rpcCtx)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	// The first message names the format; the file follows in chunks
+	err = stream.Send(&inventory.ImportProductsRequest{Format: format})
+	buf := make([]byte, transferChunkSize)
+	for err == nil {
+		n, readErr := ctx.Request.Body.Read(buf)
+		if n > 0 {
+			// A failed send means the service has already answered; the
+			// answer comes from CloseAndRecv
+			err = stream.Send(&inventory.ImportProductsRequest{Chunk: append([]byte(nil), buf[:n]...)})
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to read upload: " + readErr.Error()})
+			return
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ExportProducts handles GET /products/export?format=csv|jsonl
+// The format defaults to csv.
+// Corresponds to: rpc ExportProducts(ExportProductsRequest) returns (stream ExportProductsChunk)
+func (c *InventoryController) ExportProducts(ctx *gin.Context) {
+	format := ctx.DefaultQuery("format", "csv")
+
+	stream, err := c.client.ExportProducts(ctx.Request.Context(), &inventory.ExportProductsRequest{Format: format})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	// Errors such as an unknown format only arrive with the first message,
+	// while the status code can still be set
+	chunk, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Type", transferContentTypes[format])
+	ctx.Header("Content-Disposition", `attachment; filename="products.`+format+`"`)
+	ctx.Status(http.StatusOK)
+	for err == nil {
+		if _, err = ctx.Writer.Write(chunk.Chunk); err != nil {
+			return
+		}
+		ctx.Writer.Flush()
+		chunk, err = stream.Recv()
+	}
+	// The response is already under way; all that can be done is cut it
+	// short
+	if err != io.EOF {
+		log.Printf("product export ended early: %v", err)
+	}
+}
+
+// formatOfContentType maps a Content-Type onto a bulk file format, or
+// returns "" when it names none.
+func formatOfContentType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return "csv"
+	case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return "jsonl"
+	}
+	return ""
+}
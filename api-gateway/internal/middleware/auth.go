@@ -1,19 +1,199 @@
 package middleware
 
 import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// ContextUserID and ContextRoles are the gin context keys holding the
+	// authenticated caller.
+	ContextUserID = "user_id"
+	ContextRoles  = "roles"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// AuthConfig configures how bearer tokens are verified. At least one of
+// HMACSecret or JWKSFile must be set.
+type AuthConfig struct {
+	HMACSecret string
+	JWKSFile   string
+	Issuer     string
+	Audience   string
+}
+
+// Identity is the caller extracted from a validated token.
+type Identity struct {
+	UserID string
+	Roles  []string
+}
+
+type identityKey struct{}
+
+// IdentityFromContext returns the identity stored by AuthMiddleware.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+type claims struct {
+	Roles []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// TokenVerifier validates HS256 and RS256 signed JWTs.
+type TokenVerifier struct {
+	hmacSecret []byte
+	rsaKeys    map[string]*rsa.PublicKey
+	parser     *jwt.Parser
+}
+
+func NewTokenVerifier(cfg AuthConfig) (*TokenVerifier, error) {
+	v := &TokenVerifier{
+		rsaKeys: map[string]*rsa.PublicKey{},
+	}
+	if cfg.HMACSecret != "" {
+		v.hmacSecret = []byte(cfg.HMACSecret)
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		v.rsaKeys = keys
+	}
+	if v.hmacSecret == nil && len(v.rsaKeys) == 0 {
+		return nil, errors.New("no JWT verification key configured")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"HS256", "RS256"}),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	v.parser = jwt.NewParser(opts...)
+
+	return v, nil
+}
+
+// Verify parses and validates a raw token and returns its identity.
+func (v *TokenVerifier) Verify(raw string) (Identity, error) {
+	var c claims
+	if _, err := v.parser.ParseWithClaims(raw, &c, v.key); err != nil {
+		return Identity{}, err
+	}
+	if c.Subject == "" {
+		return Identity{}, errors.New("token has no subject")
+	}
+
+	return Identity{UserID: c.Subject, Roles: c.Roles}, nil
+}
+
+func (v *TokenVerifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case "HS256":
+		if v.hmacSecret == nil {
+			return nil, errors.New("HS256 tokens are not accepted")
+		}
+		return v.hmacSecret, nil
+	case "RS256":
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := v.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		// Tokens without a kid are accepted only when there is a single key
+		if kid == "" && len(v.rsaKeys) == 1 {
+			for _, key := range v.rsaKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// AuthMiddleware rejects requests without a valid bearer token and stores
+// the caller's identity in both the gin and the request context.
+func AuthMiddleware(verifier *TokenVerifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Implement JWT validation here
-		token := c.GetHeader("Authorization")
-		if token == "" {
+		header := c.GetHeader("Authorization")
+		raw, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || raw == "" {
 			c.AbortWithStatusJSON(401, gin.H{"error": "unauthorized"})
 			return
 		}
 
-		// Validate token and set user context
+		identity, err := verifier.Verify(raw)
+		if err != nil {
+			c.AbortWithStatusJSON(401, gin.H{"error": "invalid token"})
+			return
+		}
+
+		c.Set(ContextUserID, identity.UserID)
+		c.Set(ContextRoles, identity.Roles)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), identityKey{}, identity))
+
 		c.Next()
 	}
 }
+
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// loadJWKS reads the RSA public keys of a JSON Web Key Set file, keyed by kid.
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read JWKS file: %w", err)
+	}
+
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parse JWKS file: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: invalid modulus: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: invalid exponent: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS file contains no RSA keys")
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+// writeJWKS writes a JSON Web Key Set holding key under kid and returns
+// its path.
+func writeJWKS(t *testing.T, kid string, key *rsa.PublicKey) string {
+	t.Helper()
+
+	set := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("marshal JWKS: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write JWKS: %v", err)
+	}
+	return path
+}
+
+// sign returns a token for user u1 with the customer role, expiring at
+// expires, signed by method with key and carrying kid when one is given.
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, expires time.Time) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims{
+		Roles: []string{"customer"},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "u1",
+			ExpiresAt: jwt.NewNumericDate(expires),
+		},
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	raw, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return raw
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA key: %v", err)
+	}
+
+	verifier, err := NewTokenVerifier(AuthConfig{
+		HMACSecret: testSecret,
+		JWKSFile:   writeJWKS(t, "k1", &rsaKey.PublicKey),
+	})
+	if err != nil {
+		t.Fatalf("NewTokenVerifier: %v", err)
+	}
+
+	inAMinute := time.Now().Add(time.Minute)
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{
+			name:   "HS256",
+			header: "Bearer " + sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", inAMinute),
+			want:   http.StatusOK,
+		},
+		{
+			name:   "RS256 from the JWKS",
+			header: "Bearer " + sign(t, jwt.SigningMethodRS256, rsaKey, "k1", inAMinute),
+			want:   http.StatusOK,
+		},
+		{
+			name:   "RS256 without kid and a single key",
+			header: "Bearer " + sign(t, jwt.SigningMethodRS256, rsaKey, "", inAMinute),
+			want:   http.StatusOK,
+		},
+		{
+			name:   "HS256 with another secret",
+			header: "Bearer " + sign(t, jwt.SigningMethodHS256, []byte("guessed"), "", inAMinute),
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "expired",
+			header: "Bearer " + sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", time.Now().Add(-time.Minute)),
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "wrong algorithm",
+			header: "Bearer " + sign(t, jwt.SigningMethodHS384, []byte(testSecret), "", inAMinute),
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "unsigned",
+			header: "Bearer " + sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", inAMinute),
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "unknown kid",
+			header: "Bearer " + sign(t, jwt.SigningMethodRS256, rsaKey, "k2", inAMinute),
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "RS256 with a key not in the JWKS",
+			header: "Bearer " + sign(t, jwt.SigningMethodRS256, otherKey, "k1", inAMinute),
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name: "missing bearer token",
+			want: http.StatusUnauthorized,
+		},
+		{
+			name:   "empty bearer token",
+			header: "Bearer ",
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "not a bearer token",
+			header: "Basic dTE6c2VjcmV0",
+			want:   http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var identity Identity
+			router := gin.New()
+			router.GET("/", AuthMiddleware(verifier), func(c *gin.Context) {
+				identity, _ = IdentityFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusOK && (identity.UserID != "u1" || len(identity.Roles) != 1 || identity.Roles[0] != "customer") {
+				t.Fatalf("handler got identity %+v", identity)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+// RouteRule lists the roles allowed to call one route.
+type RouteRule struct {
+	Method string   `yaml:"method"`
+	Path   string   `yaml:"path"`
+	Roles  []string `yaml:"roles"`
+}
+
+// RoutePolicy restricts routes to roles. Routes without a rule are open to
+// any authenticated caller.
+type RoutePolicy struct {
+	Routes []RouteRule `yaml:"routes"`
+
+	rules map[string][]string
+}
+
+// LoadRoutePolicy reads a YAML route policy file.
+func LoadRoutePolicy(path string) (*RoutePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read RBAC policy: %w", err)
+	}
+
+	var policy RoutePolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("parse RBAC policy: %w", err)
+	}
+
+	policy.rules = make(map[string][]string, len(policy.Routes))
+	for _, rule := range policy.Routes {
+		if rule.Method == "" || rule.Path == "" || len(rule.Roles) == 0 {
+			return nil, fmt.Errorf("RBAC rule %+v needs a method, a path and at least one role", rule)
+		}
+		policy.rules[rule.Method+" "+rule.Path] = rule.Roles
+	}
+
+	return &policy, nil
+}
+
+// Allowed reports whether a caller holding roles may call the route.
+func (p *RoutePolicy) Allowed(method, path string, roles []string) bool {
+	allowed, restricted := p.rules[method+" "+path]
+	if !restricted {
+		return true
+	}
+
+	for _, want := range allowed {
+		for _, have := range roles {
+			if want == have {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RBACMiddleware enforces the route policy. It must run after
+// AuthMiddleware.
+func RBACMiddleware(policy *RoutePolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roles := c.GetStringSlice(ContextRoles)
+		if !policy.Allowed(c.Request.Method, c.FullPath(), roles) {
+			c.AbortWithStatusJSON(403, gin.H{"error": "forbidden"})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -4,17 +4,29 @@ import (
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"api-gateway/internal/config"
+	"api-gateway/internal/controller"
+	"api-gateway/internal/middleware"
 )
 
 type Server struct {
+	cfg           config.Config
 	ginEngine     *gin.Engine
+	verifier      *middleware.TokenVerifier
+	signer        *middleware.IdentitySigner
+	policy        *middleware.RoutePolicy
 	inventoryConn *grpc.ClientConn
 	orderConn     *grpc.ClientConn
 }
 
-func NewServer() *Server {
+func NewServer(cfg config.Config, verifier *middleware.TokenVerifier, signer *middleware.IdentitySigner, policy *middleware.RoutePolicy) *Server {
 	return &Server{
+		cfg:       cfg,
 		ginEngine: gin.Default(),
+		verifier:  verifier,
+		signer:    signer,
+		policy:    policy,
 	}
 }
 
@@ -25,49 +37,107 @@ func (s *Server) Start() error {
 
 	s.setupRoutes()
 
-	return s.ginEngine.Run(":8080")
+	return s.ginEngine.Run(s.cfg.HTTPAddr)
 }
 
 func (s *Server) initGRPCClients() error {
 	var err error
 
-	// Initialize inventory service connection
-	s.inventoryConn, err = grpc.Dial(
-		"inventory-service:50051",
+	// Every call carries the authenticated caller as signed metadata
+	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+		grpc.WithUnaryInterceptor(middleware.UnaryClientInterceptor(s.signer)),
+		grpc.WithStreamInterceptor(middleware.StreamClientInterceptor(s.signer)),
+	}
+
+	// Initialize inventory service connection
+	s.inventoryConn, err = grpc.Dial(s.cfg.InventoryAddr, opts...)
 	if err != nil {
 		return err
 	}
 
 	// Initialize order service connection
-	s.orderConn, err = grpc.Dial(
-		"order-service:50052",
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	s.orderConn, err = grpc.Dial(s.cfg.OrderAddr, opts...)
 
 	return err
 }
 
 func (s *Server) setupRoutes() {
+	inventoryController := controller.NewInventoryController(s.inventoryConn)
+	orderController := controller.NewOrderController(s.orderConn)
+
 	api := s.ginEngine.Group("/api")
+	api.Use(middleware.AuthMiddleware(s.verifier), middleware.RBACMiddleware(s.policy))
 
 	// Inventory routes
 	inventory := api.Group("/inventory")
 	{
-		inventory.GET("/products", s.listProducts)
-		inventory.GET("/products/:id", s.getProduct)
-		inventory.POST("/products", s.createProduct)
-		inventory.PUT("/products/:id", s.updateProduct)
-		inventory.DELETE("/products/:id", s.deleteProduct)
+		inventory.GET("/products", inventoryController.ListProducts)
+		inventory.GET("/products/search", inventoryController.SearchProducts)
+		inventory.GET("/products/export", inventoryController.ExportProducts)
+		inventory.POST("/products/import", inventoryController.ImportProducts)
+		inventory.GET("/products/:id", inventoryController.GetProduct)
+		inventory.POST("/products", inventoryController.CreateProduct)
+		inventory.PUT("/products/:id", inventoryController.UpdateProduct)
+		inventory.DELETE("/products/:id", inventoryController.DeleteProduct)
+		inventory.POST("/products/:id/restore", inventoryController.RestoreProduct)
+
+		inventory.GET("/categories", inventoryController.ListCategories)
+		inventory.GET("/categories/:id", inventoryController.GetCategory)
+		inventory.POST("/categories", inventoryController.CreateCategory)
+		inventory.PUT("/categories/:id", inventoryController.UpdateCategory)
+		inventory.DELETE("/categories/:id", inventoryController.DeleteCategory)
+
+		inventory.GET("/products/:id/variants", inventoryController.ListVariants)
+		inventory.POST("/products/:id/variants", inventoryController.CreateVariant)
+		inventory.GET("/variants/:sku", inventoryController.GetVariant)
+		inventory.PUT("/variants/:sku", inventoryController.UpdateVariant)
+		inventory.DELETE("/variants/:sku", inventoryController.DeleteVariant)
+
+		inventory.GET("/warehouses", inventoryController.ListWarehouses)
+		inventory.GET("/warehouses/:id", inventoryController.GetWarehouse)
+		inventory.POST("/warehouses", inventoryController.CreateWarehouse)
+		inventory.PUT("/warehouses/:id", inventoryController.UpdateWarehouse)
+		inventory.DELETE("/warehouses/:id", inventoryController.DeleteWarehouse)
+		inventory.GET("/warehouses/:id/stock", inventoryController.ListWarehouseStock)
+		inventory.PUT("/warehouses/:id/stock", inventoryController.SetStockLevel)
+		inventory.POST("/stock/transfers", inventoryController.TransferStock)
+		inventory.GET("/stock/movements", inventoryController.ListStockMovements)
+		inventory.POST("/products/:id/stock/adjustments", inventoryController.AdjustStock)
+		inventory.POST("/products/:id/stock/reconcile", inventoryController.ReconcileStock)
+		inventory.GET("/stock/low", inventoryController.ListLowStock)
+		inventory.GET("/suppliers", inventoryController.ListSuppliers)
+		inventory.GET("/suppliers/:id", inventoryController.GetSupplier)
+		inventory.POST("/suppliers", inventoryController.CreateSupplier)
+		inventory.PUT("/suppliers/:id", inventoryController.UpdateSupplier)
+		inventory.GET("/purchase-orders", inventoryController.ListPurchaseOrders)
+		inventory.GET("/purchase-orders/:id", inventoryController.GetPurchaseOrder)
+		inventory.POST("/purchase-orders", inventoryController.CreatePurchaseOrder)
+		inventory.POST("/purchase-orders/:id/receipts", inventoryController.ReceivePurchaseOrder)
+		inventory.POST("/purchase-orders/:id/cancel", inventoryController.CancelPurchaseOrder)
 	}
 
 	// Order routes
 	orders := api.Group("/orders")
 	{
-		orders.POST("/", s.createOrder)
-		orders.GET("/:id", s.getOrder)
-		orders.PUT("/:id/status", s.updateOrderStatus)
-		orders.GET("/user/:user_id", s.listUserOrders)
+		orders.POST("/", orderController.CreateOrder)
+		orders.GET("/:id", orderController.GetOrder)
+		orders.PUT("/:id/status", orderController.UpdateOrderStatus)
+		orders.POST("/:id/cancel", orderController.CancelOrder)
+		orders.POST("/:id/payments", orderController.PayOrder)
+		orders.GET("/:id/payments", orderController.ListOrderPayments)
+		orders.POST("/:id/returns", orderController.RequestReturn)
+		orders.GET("/:id/returns", orderController.ListOrderReturns)
+		orders.GET("/:id/saga", orderController.GetOrderSaga)
+		orders.GET("/user/:user_id", orderController.ListUserOrders)
+	}
+
+	// Return routes
+	returns := api.Group("/returns")
+	{
+		returns.GET("/:id", orderController.GetReturn)
+		returns.POST("/:id/approve", orderController.ApproveReturn)
+		returns.POST("/:id/reject", orderController.RejectReturn)
+		returns.POST("/:id/receive", orderController.ReceiveReturn)
 	}
 }
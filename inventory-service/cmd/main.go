@@ -1,46 +1,169 @@
 package main
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
 	"log"
 	"net"
-	"os"
+	"time"
 
 	"github.com/abaika-abay/ecommerce/protos/inventory"
+	_ "github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
+	"inventory-service/internal/auth"
+	"inventory-service/internal/config"
+	"inventory-service/internal/rbac"
 	"inventory-service/internal/repository"
 	"inventory-service/internal/service"
 	"inventory-service/internal/usecase"
+	"inventory-service/migrations"
 )
 
 func main() {
-	// Initialize database connection
-	db, err := sql.Open("postgres", os.Getenv("DB_DSN"))
+	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("failed to connect to database: %v", err)
+		log.Fatalf("invalid configuration: %v", err)
 	}
-	defer db.Close()
 
-	// Initialize repository
-	productRepo := repository.NewProductRepository(db)
+	// Initialize repositories for the configured storage backend
+	repos, closeStorage, err := openStorage(cfg)
+	if err != nil {
+		log.Fatalf("failed to initialize %s storage: %v", cfg.StorageDriver, err)
+	}
+	defer closeStorage()
+
+	// Report products falling to their reorder point; the usecases get
+	// repositories that tell the checker about every stock change
+	lowStockChecker := usecase.NewLowStockChecker(repos.Products, repos.Variants, usecase.NewLogLowStockNotifier())
+	repos = lowStockChecker.Watch(repos)
+	go lowStockChecker.Run(context.Background(), cfg.LowStockSweepInterval)
+
+	// Hand arriving stock to backordered reservations
+	backorderFiller := usecase.NewBackorderFiller()
+	repos = backorderFiller.Watch(repos)
+
+	// Publish the domain events the repositories write to the outbox; the
+	// in-process broker logs them until other subscribers are added
+	broker := usecase.NewInProcessBroker()
+	broker.Subscribe(usecase.LogEvent)
+	go usecase.NewOutboxRelay(repos.Outbox, broker).Run(context.Background(), cfg.OutboxRelayInterval)
 
 	// Initialize usecase
-	productUsecase := usecase.NewProductUsecase(productRepo)
+	productUsecase := usecase.NewProductUsecase(repos.Products, repos.Categories, repos.Variants, repos.StockLevels, repos.Movements)
+	categoryUsecase := usecase.NewCategoryUsecase(repos.Categories, repos.Products)
+	variantUsecase := usecase.NewVariantUsecase(repos.Variants, repos.Products, repos.StockLevels, repos.Movements)
+	warehouseUsecase := usecase.NewWarehouseUsecase(repos.Warehouses, repos.StockLevels, repos.Movements, repos.Products, repos.Variants)
+	reservationUsecase := usecase.NewReservationUsecase(repos.Products, repos.Variants, repos.Warehouses, repos.StockLevels, repos.Movements, repos.Reservations, cfg.AllocationStrategy, cfg.ReservationTTL)
+	purchasingUsecase := usecase.NewPurchasingUsecase(repos.Suppliers, repos.PurchaseOrders, repos.Products, repos.Variants, repos.Warehouses, repos.StockLevels, repos.Movements)
+
+	// Return stock held by reservations that were never committed
+	go runReservationExpiry(reservationUsecase, cfg.ReservationExpiryInterval)
+	go backorderFiller.Run(context.Background(), reservationUsecase, cfg.BackorderFillInterval)
+	// Remove products archived past the retention window
+	go runArchivePurge(productUsecase, cfg.ArchiveRetention, cfg.ArchivePurgeInterval)
+
+	// Load the role policy enforced on every RPC
+	policy, err := rbac.LoadPolicy(cfg.RBACPolicyFile)
+	if err != nil {
+		log.Fatalf("failed to load RBAC policy: %v", err)
+	}
+
+	// Callers are only trusted with the identity they sign
+	verifier, err := auth.NewVerifier(cfg.ServiceAuthSecret)
+	if err != nil {
+		log.Fatalf("failed to set up caller authentication: %v", err)
+	}
 
 	// Initialize gRPC server
-	grpcServer := grpc.NewServer()
-	inventoryServer := service.NewInventoryServer(productUsecase)
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(rbac.UnaryServerInterceptor(policy, verifier)),
+		grpc.StreamInterceptor(rbac.StreamServerInterceptor(policy, verifier)),
+	)
+	inventoryServer := service.NewInventoryServer(productUsecase, categoryUsecase, variantUsecase, warehouseUsecase, reservationUsecase, purchasingUsecase)
 	inventory.RegisterInventoryServiceServer(grpcServer, inventoryServer)
 
 	// Start server
-	lis, err := net.Listen("tcp", ":50051")
+	lis, err := net.Listen("tcp", cfg.GRPCAddr)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	log.Println("Inventory service started on :50051")
+	log.Printf("Inventory service started on %s using %s storage", cfg.GRPCAddr, cfg.StorageDriver)
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
+
+// openStorage connects to the configured backend and returns its
+// repositories together with a function releasing the connection.
+func openStorage(cfg config.Config) (repository.Repositories, func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	switch cfg.StorageDriver {
+	case config.StorageMemory:
+		log.Println("using in-memory storage; all data is lost on restart")
+		return repository.NewMemoryRepositories(), func() {}, nil
+
+	case config.StoragePostgres:
+		db, err := sql.Open("postgres", cfg.PostgresDSN)
+		if err != nil {
+			return repository.Repositories{}, nil, err
+		}
+		if err := migrations.Apply(ctx, db); err != nil {
+			db.Close()
+			return repository.Repositories{}, nil, err
+		}
+		return repository.NewPostgresRepositories(db), func() { db.Close() }, nil
+
+	default:
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURI))
+		if err != nil {
+			return repository.Repositories{}, nil, err
+		}
+		closeFn := func() { client.Disconnect(context.Background()) }
+		db := client.Database(cfg.MongoDatabase)
+		if err := repository.CheckMongoTransactions(ctx, db); err != nil {
+			closeFn()
+			return repository.Repositories{}, nil, err
+		}
+		if err := repository.EnsureMongoIndexes(ctx, db); err != nil {
+			closeFn()
+			return repository.Repositories{}, nil, err
+		}
+		return repository.NewMongoRepositories(db), closeFn, nil
+	}
+}
+
+func runReservationExpiry(reservationUsecase usecase.ReservationUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		expired, err := reservationUsecase.ExpireReservations(context.Background())
+		if err != nil {
+			log.Printf("failed to expire reservations: %v", err)
+			continue
+		}
+		if expired > 0 {
+			log.Printf("expired %d stock reservations", expired)
+		}
+	}
+}
+
+func runArchivePurge(productUsecase usecase.ProductUsecase, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		purged, err := productUsecase.PurgeArchivedProducts(context.Background(), retention)
+		if err != nil {
+			log.Printf("failed to purge archived products: %v", err)
+		}
+		if purged > 0 {
+			log.Printf("purged %d archived products", purged)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys carrying the authenticated caller, set by the API gateway
+// or by the calling service. The caller signs the user ID, roles and
+// expiry with the secret it shares with the backends, so that the roles
+// cannot be made up by whoever reaches the service port.
+const (
+	MetadataUserID    = "x-user-id"
+	MetadataRoles     = "x-user-roles"
+	MetadataExpires   = "x-user-expires"
+	MetadataSignature = "x-user-signature"
+)
+
+// Roles known to the catalog. RoleService is held by other backend services
+// calling inventory-service on their own behalf.
+const (
+	RoleCustomer       = "customer"
+	RoleCatalogManager = "catalog-manager"
+	RoleWarehouse      = "warehouse"
+	RoleAdmin          = "admin"
+	RoleService        = "service"
+)
+
+// Identity is the caller on whose behalf an RPC runs.
+type Identity struct {
+	UserID string
+	Roles  []string
+}
+
+// HasRole reports whether the identity holds role.
+func (i Identity) HasRole(role string) bool {
+	for _, r := range i.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin reports whether the identity holds the admin role.
+func (i Identity) IsAdmin() bool {
+	return i.HasRole(RoleAdmin)
+}
+
+type identityKey struct{}
+
+// FromContext returns the identity attached by the RBAC interceptor.
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// NewContext returns a copy of ctx carrying identity.
+func NewContext(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// Verifier checks the signature on the caller identity carried in
+// metadata.
+type Verifier struct {
+	secret []byte
+}
+
+// NewVerifier returns a Verifier for identities signed with secret.
+func NewVerifier(secret string) (*Verifier, error) {
+	if secret == "" {
+		return nil, errors.New("no service auth secret configured")
+	}
+	return &Verifier{secret: []byte(secret)}, nil
+}
+
+// FromMetadata reads the caller from incoming gRPC metadata. It reports
+// false unless the identity is signed with the shared secret and has not
+// expired.
+func (v *Verifier) FromMetadata(ctx context.Context) (Identity, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Identity{}, false
+	}
+
+	userID, rawRoles := first(md, MetadataUserID), first(md, MetadataRoles)
+	if userID == "" {
+		return Identity{}, false
+	}
+	expires, err := strconv.ParseInt(first(md, MetadataExpires), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return Identity{}, false
+	}
+	signature, err := hex.DecodeString(first(md, MetadataSignature))
+	if err != nil || !hmac.Equal(signature, sign(v.secret, userID, rawRoles, expires)) {
+		return Identity{}, false
+	}
+
+	var roles []string
+	for _, role := range strings.Split(rawRoles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+
+	return Identity{UserID: userID, Roles: roles}, true
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// sign computes the signature the gateway and the services put on an
+// identity: an HMAC-SHA256 of its user ID, comma-separated roles and
+// expiry in Unix seconds.
+func sign(secret []byte, userID, roles string, expires int64) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(userID + "\n" + roles + "\n" + strconv.FormatInt(expires, 10)))
+	return mac.Sum(nil)
+}
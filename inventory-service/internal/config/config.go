@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"inventory-service/internal/domain"
+)
+
+// Storage drivers accepted in STORAGE_DRIVER.
+const (
+	StorageMongo    = "mongo"
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+type Config struct {
+	GRPCAddr       string
+	StorageDriver  string
+	MongoURI       string
+	MongoDatabase  string
+	PostgresDSN    string
+	RBACPolicyFile string
+	// ServiceAuthSecret is shared with the gateway and the other services,
+	// which sign the caller identities they send with it
+	ServiceAuthSecret string
+
+	ReservationTTL            time.Duration
+	ReservationExpiryInterval time.Duration
+	// AllocationStrategy picks the warehouses reservations draw from
+	AllocationStrategy domain.AllocationStrategy
+	// LowStockSweepInterval is how often every product is checked against
+	// its reorder point, on top of the checks after each stock change
+	LowStockSweepInterval time.Duration
+	// BackorderFillInterval is how often backordered reservations are
+	// offered stock, on top of the passes after each stock increase
+	BackorderFillInterval time.Duration
+	// ArchiveRetention is how long deleted products are kept archived
+	// before ArchivePurgeInterval's sweeps remove them for good
+	ArchiveRetention     time.Duration
+	ArchivePurgeInterval time.Duration
+	// OutboxRelayInterval is how often domain events written to the outbox
+	// are published
+	OutboxRelayInterval time.Duration
+}
+
+// Load reads the service configuration from the environment.
+func Load() (Config, error) {
+	cfg := Config{
+		GRPCAddr:       getEnv("GRPC_ADDR", ":50051"),
+		StorageDriver:  getEnv("STORAGE_DRIVER", StorageMongo),
+		MongoURI:       os.Getenv("MONGO_URI"),
+		MongoDatabase:  getEnv("MONGO_DATABASE", "ecommerce"),
+		PostgresDSN:    os.Getenv("DB_DSN"),
+		RBACPolicyFile: getEnv("RBAC_POLICY_FILE", "config/rbac.yaml"),
+
+		ServiceAuthSecret: os.Getenv("SERVICE_AUTH_SECRET"),
+
+		ReservationTTL:            getDuration("RESERVATION_TTL", 15*time.Minute),
+		ReservationExpiryInterval: getDuration("RESERVATION_EXPIRY_INTERVAL", time.Minute),
+		AllocationStrategy:        domain.AllocationStrategy(getEnv("ALLOCATION_STRATEGY", string(domain.AllocationPriority))),
+		LowStockSweepInterval:     getDuration("LOW_STOCK_SWEEP_INTERVAL", 10*time.Minute),
+		BackorderFillInterval:     getDuration("BACKORDER_FILL_INTERVAL", time.Minute),
+		ArchiveRetention:          getDuration("ARCHIVE_RETENTION", 90*24*time.Hour),
+		ArchivePurgeInterval:      getDuration("ARCHIVE_PURGE_INTERVAL", time.Hour),
+		OutboxRelayInterval:       getDuration("OUTBOX_RELAY_INTERVAL", time.Second),
+	}
+
+	if !cfg.AllocationStrategy.IsValid() {
+		return cfg, fmt.Errorf("unknown ALLOCATION_STRATEGY %q", cfg.AllocationStrategy)
+	}
+
+	if cfg.ServiceAuthSecret == "" {
+		return cfg, fmt.Errorf("SERVICE_AUTH_SECRET is required")
+	}
+
+	switch cfg.StorageDriver {
+	case StorageMongo:
+		if cfg.MongoURI == "" {
+			return cfg, fmt.Errorf("MONGO_URI is required for the %s storage driver", StorageMongo)
+		}
+	case StoragePostgres:
+		if cfg.PostgresDSN == "" {
+			return cfg, fmt.Errorf("DB_DSN is required for the %s storage driver", StoragePostgres)
+		}
+	case StorageMemory:
+	default:
+		return cfg, fmt.Errorf("unknown STORAGE_DRIVER %q", cfg.StorageDriver)
+	}
+
+	return cfg, nil
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("invalid %s %q, using %s", key, value, fallback)
+		return fallback
+	}
+	return d
+}
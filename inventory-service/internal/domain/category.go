@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Category groups products. Categories form a forest: a category without
+// a ParentID is a root.
+type Category struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ParentID    string    `json:"parent_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DescendantIDs returns rootID followed by the IDs of every category below
+// it in categories.
+func DescendantIDs(categories []*Category, rootID string) []string {
+	children := make(map[string][]string, len(categories))
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category.ID)
+	}
+
+	ids := []string{rootID}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[ids[i]]...)
+	}
+
+	return ids
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "errors"
+
+var (
+	ErrProductNotFound       = errors.New("product not found")
+	ErrProductExists         = errors.New("product already exists")
+	ErrProductArchived       = errors.New("product is archived")
+	ErrProductNotArchived    = errors.New("product is not archived")
+	ErrInsufficientStock     = errors.New("insufficient stock")
+	ErrInvalidSearch         = errors.New("invalid search")
+	ErrCategoryNotFound      = errors.New("category not found")
+	ErrCategoryExists        = errors.New("category already exists")
+	ErrInvalidCategory       = errors.New("invalid category")
+	ErrCategoryInUse         = errors.New("category still has subcategories or products")
+	ErrVariantNotFound       = errors.New("variant not found")
+	ErrVariantExists         = errors.New("variant already exists")
+	ErrInvalidVariant        = errors.New("invalid variant")
+	ErrWarehouseNotFound     = errors.New("warehouse not found")
+	ErrWarehouseExists       = errors.New("warehouse already exists")
+	ErrInvalidWarehouse      = errors.New("invalid warehouse")
+	ErrWarehouseInUse        = errors.New("warehouse still holds stock")
+	ErrInvalidStockChange    = errors.New("invalid stock change")
+	ErrVersionConflict       = errors.New("version conflict: modified concurrently")
+	ErrReservationNotFound   = errors.New("reservation not found")
+	ErrReservationExists     = errors.New("reservation already exists")
+	ErrReservationClosed     = errors.New("reservation is no longer active")
+	ErrInvalidReturn         = errors.New("invalid return")
+	ErrReturnExists          = errors.New("return already recorded")
+	ErrSupplierNotFound      = errors.New("supplier not found")
+	ErrSupplierExists        = errors.New("supplier already exists")
+	ErrInvalidSupplier       = errors.New("invalid supplier")
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrPurchaseOrderExists   = errors.New("purchase order already exists")
+	ErrInvalidPurchaseOrder  = errors.New("invalid purchase order")
+	ErrPurchaseOrderClosed   = errors.New("purchase order is closed")
+	ErrInvalidImport         = errors.New("invalid import")
+)
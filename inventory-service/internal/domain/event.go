@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType names a kind of domain event.
+type EventType string
+
+const (
+	EventProductCreated  EventType = "ProductCreated"
+	EventProductUpdated  EventType = "ProductUpdated"
+	EventProductArchived EventType = "ProductArchived"
+	EventProductRestored EventType = "ProductRestored"
+	EventProductDeleted  EventType = "ProductDeleted"
+	// EventStockAdjusted is written for every change to the stock of a
+	// product, variant or warehouse level.
+	EventStockAdjusted EventType = "StockAdjusted"
+)
+
+// Event is a change other systems may want to hear about. Repositories
+// write it to the outbox in the same write as the change itself and the
+// outbox relay publishes it from there, so every committed change is
+// published at least once; consumers tell repeats apart by ID.
+// AggregateID is the product the event is about and Payload its data as
+// JSON: the product after the change, the removed product's ID, or the
+// stock change.
+type Event struct {
+	ID          string          `json:"id"`
+	Type        EventType       `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+// ProductDeletion is the payload of EventProductDeleted.
+type ProductDeletion struct {
+	ID string `json:"id"`
+}
+
+// StockChange is the payload of EventStockAdjusted. Stock is the count
+// after the change: the warehouse level's quantity when WarehouseID is set,
+// otherwise the total of the product or variant.
+type StockChange struct {
+	ProductID   string `json:"product_id"`
+	VariantSKU  string `json:"variant_sku,omitempty"`
+	WarehouseID string `json:"warehouse_id,omitempty"`
+	Delta       int    `json:"delta"`
+	Stock       int    `json:"stock"`
+}
@@ -0,0 +1,97 @@
+package domain
+
+import "time"
+
+// MovementReason explains why stock changed.
+type MovementReason string
+
+const (
+	// MovementOpening records stock that existed before the ledger did.
+	MovementOpening MovementReason = "opening"
+	// MovementSale takes stock for an order.
+	MovementSale MovementReason = "sale"
+	// MovementRelease returns stock held for an order that was released
+	// or whose reservation expired.
+	MovementRelease  MovementReason = "release"
+	MovementRestock  MovementReason = "restock"
+	MovementReturn   MovementReason = "return"
+	MovementDamage   MovementReason = "damage"
+	MovementCount    MovementReason = "count"
+	MovementTransfer MovementReason = "transfer"
+	MovementManual   MovementReason = "manual"
+	// MovementPurchase adds stock delivered against a purchase order.
+	MovementPurchase MovementReason = "purchase"
+)
+
+// IsAdjustment reports whether r may be given for a direct stock change
+// made by staff, as opposed to one the service records on its own.
+func (r MovementReason) IsAdjustment() bool {
+	switch r {
+	case MovementRestock, MovementReturn, MovementDamage, MovementCount, MovementManual:
+		return true
+	}
+	return false
+}
+
+// StockMovement is one entry of the append-only stock ledger. Delta is the
+// change to the stock of a product, or of one of its variants when
+// VariantSKU is set, held at WarehouseID, which is empty for unassigned
+// stock. Summing the deltas of an item therefore gives its stock, and
+// summing them per warehouse gives its stock levels.
+type StockMovement struct {
+	ID          string         `json:"id"`
+	ProductID   string         `json:"product_id"`
+	VariantSKU  string         `json:"variant_sku,omitempty"`
+	WarehouseID string         `json:"warehouse_id,omitempty"`
+	Delta       int            `json:"delta"`
+	Reason      MovementReason `json:"reason"`
+	// Actor is the user who caused the change, or "system" for changes
+	// made by background jobs
+	Actor     string    `json:"actor"`
+	OrderID   string    `json:"order_id,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// StockAdjustment is a signed change to the unassigned stock of a product
+// or variant, made by staff for one of the adjustment reasons.
+type StockAdjustment struct {
+	ProductID  string         `json:"product_id"`
+	VariantSKU string         `json:"variant_sku,omitempty"`
+	Delta      int            `json:"delta"`
+	Reason     MovementReason `json:"reason"`
+	Note       string         `json:"note,omitempty"`
+}
+
+// MovementCursor returns the cursor positioned just after movement.
+func MovementCursor(movement *StockMovement) *PageCursor {
+	return &PageCursor{CreatedAt: movement.CreatedAt, ID: movement.ID}
+}
+
+// MovementFilter narrows a ledger listing; empty fields match everything.
+type MovementFilter struct {
+	ProductID   string
+	VariantSKU  string
+	WarehouseID string
+	OrderID     string
+	Reason      MovementReason
+}
+
+// StockBalance is the sum of the ledger for one product or variant at one
+// warehouse.
+type StockBalance struct {
+	VariantSKU  string `json:"variant_sku,omitempty"`
+	WarehouseID string `json:"warehouse_id,omitempty"`
+	Quantity    int    `json:"quantity"`
+}
+
+// StockDiscrepancy is a place where stored stock and the ledger disagree.
+// Recorded is the stored quantity and Ledger the sum of the ledger; an
+// empty WarehouseID stands for unassigned stock.
+type StockDiscrepancy struct {
+	ProductID   string `json:"product_id"`
+	VariantSKU  string `json:"variant_sku,omitempty"`
+	WarehouseID string `json:"warehouse_id,omitempty"`
+	Recorded    int    `json:"recorded"`
+	Ledger      int    `json:"ledger"`
+}
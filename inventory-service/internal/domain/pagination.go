@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// PageCursor marks where a listing resumes: the sort key of the last item
+// already returned. Listings run newest first, so the next page holds
+// everything strictly before it.
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// ProductCursor returns the cursor positioned just after product.
+func ProductCursor(product *Product) *PageCursor {
+	return &PageCursor{CreatedAt: product.CreatedAt, ID: product.ID}
+}
@@ -2,13 +2,84 @@ package domain
 
 import "time"
 
+// Product is a catalog entry. SKU is optional but unique when set; bulk
+// imports match rows to products by it. Version starts at 1 and grows
+// with every update, for optimistic concurrency control; stock changes do
+// not count as updates.
+//
+// A positive ReorderPoint turns on low-stock alerts: once the product's
+// stock falls to it, ReorderQuantity units should be ordered.
+//
+// BackorderMode decides whether the product can be ordered without stock;
+// AvailableAt, when set, is when stock is expected.
+//
+// Deleting a product archives it: ArchivedAt is set, the product leaves
+// listings and cannot be ordered, but it can still be fetched by ID for
+// the orders that refer to it, and restored.
 type Product struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Stock       int       `json:"stock"`
-	CategoryID  string    `json:"category_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID              string        `json:"id"`
+	SKU             string        `json:"sku,omitempty"`
+	Name            string        `json:"name"`
+	Description     string        `json:"description"`
+	Price           float64       `json:"price"`
+	Stock           int           `json:"stock"`
+	CategoryID      string        `json:"category_id"`
+	ReorderPoint    int           `json:"reorder_point"`
+	ReorderQuantity int           `json:"reorder_quantity"`
+	BackorderMode   BackorderMode `json:"backorder_mode"`
+	AvailableAt     *time.Time    `json:"available_at,omitempty"`
+	ArchivedAt      *time.Time    `json:"archived_at,omitempty"`
+	Version         int64         `json:"version"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
+// BackorderMode says whether a product can be ordered while it is out of
+// stock. Such orders wait for stock and are served first come, first
+// served.
+type BackorderMode string
+
+const (
+	// BackorderNone refuses orders the stock cannot cover
+	BackorderNone BackorderMode = "none"
+	// BackorderAllowed accepts orders for a product that is temporarily
+	// out of stock
+	BackorderAllowed BackorderMode = "backorder"
+	// BackorderPreorder accepts orders for a product not released yet
+	BackorderPreorder BackorderMode = "preorder"
+)
+
+func (m BackorderMode) IsValid() bool {
+	switch m {
+	case BackorderNone, BackorderAllowed, BackorderPreorder:
+		return true
+	}
+	return false
+}
+
+// IsArchived reports whether the product has been deleted.
+func (p *Product) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// AcceptsBackorders reports whether the product can be ordered without
+// stock.
+func (p *Product) AcceptsBackorders() bool {
+	return p.BackorderMode == BackorderAllowed || p.BackorderMode == BackorderPreorder
+}
+
+// NeedsReorder reports whether stock, the product's total across its
+// variants, has fallen to the reorder point.
+func (p *Product) NeedsReorder(stock int) bool {
+	return p.ReorderPoint > 0 && stock <= p.ReorderPoint
+}
+
+// LowStock describes a product whose stock has fallen to its reorder
+// point.
+type LowStock struct {
+	ProductID       string `json:"product_id"`
+	Name            string `json:"name"`
+	Stock           int    `json:"stock"`
+	ReorderPoint    int    `json:"reorder_point"`
+	ReorderQuantity int    `json:"reorder_quantity"`
 }
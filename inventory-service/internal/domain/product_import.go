@@ -0,0 +1,34 @@
+package domain
+
+// FileFormat is the encoding of a bulk product import or export.
+type FileFormat string
+
+const (
+	// FileFormatCSV is comma-separated values with a header row naming
+	// the columns.
+	FileFormatCSV FileFormat = "csv"
+	// FileFormatJSONL is one JSON object per line.
+	FileFormatJSONL FileFormat = "jsonl"
+)
+
+func (f FileFormat) IsValid() bool {
+	return f == FileFormatCSV || f == FileFormatJSONL
+}
+
+// ImportReport sums up a bulk product import. Rows that fail do not stop
+// the import; each is reported in Errors, which is capped, while Failed
+// counts them all.
+type ImportReport struct {
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Failed  int              `json:"failed"`
+	Errors  []ImportRowError `json:"errors"`
+}
+
+// ImportRowError explains why a row was not imported. Row is 1-based and
+// does not count a CSV header.
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	SKU   string `json:"sku,omitempty"`
+	Error string `json:"error"`
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Supplier is a vendor stock is bought from.
+type Supplier struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	Phone     string    `json:"phone,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SupplierUpdate lists the supplier fields to change; nil fields are left
+// as they are.
+type SupplierUpdate struct {
+	Name  *string
+	Email *string
+	Phone *string
+}
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderOpen              PurchaseOrderStatus = "open"
+	PurchaseOrderPartiallyReceived PurchaseOrderStatus = "partially_received"
+	PurchaseOrderReceived          PurchaseOrderStatus = "received"
+	PurchaseOrderCancelled         PurchaseOrderStatus = "cancelled"
+)
+
+func (s PurchaseOrderStatus) IsValid() bool {
+	switch s {
+	case PurchaseOrderOpen, PurchaseOrderPartiallyReceived, PurchaseOrderReceived, PurchaseOrderCancelled:
+		return true
+	}
+	return false
+}
+
+// IsClosed reports whether no more units can be received against an order
+// in status s.
+func (s PurchaseOrderStatus) IsClosed() bool {
+	return s == PurchaseOrderReceived || s == PurchaseOrderCancelled
+}
+
+// PurchaseOrderLine orders Quantity units of a product, or of one of its
+// variants when VariantSKU is set. Received counts the units delivered so
+// far.
+type PurchaseOrderLine struct {
+	ProductID  string  `json:"product_id"`
+	VariantSKU string  `json:"variant_sku,omitempty"`
+	Quantity   int     `json:"quantity"`
+	Received   int     `json:"received"`
+	UnitCost   float64 `json:"unit_cost"`
+}
+
+// Outstanding returns how many units of the line are still to come.
+func (l PurchaseOrderLine) Outstanding() int {
+	return l.Quantity - l.Received
+}
+
+// PurchaseOrder is an order for stock placed with a supplier. Received
+// units go to WarehouseID, or to unassigned stock when it is empty.
+// Version starts at 1 and grows with every change, so concurrent receipts
+// cannot both apply.
+type PurchaseOrder struct {
+	ID          string              `json:"id"`
+	SupplierID  string              `json:"supplier_id"`
+	WarehouseID string              `json:"warehouse_id,omitempty"`
+	Lines       []PurchaseOrderLine `json:"lines"`
+	Status      PurchaseOrderStatus `json:"status"`
+	Note        string              `json:"note,omitempty"`
+	Version     int64               `json:"version"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// ReceiptLine is a delivery of Quantity units against the purchase order
+// line of the same product and variant.
+type ReceiptLine struct {
+	ProductID  string `json:"product_id"`
+	VariantSKU string `json:"variant_sku,omitempty"`
+	Quantity   int    `json:"quantity"`
+}
+
+// Receive books a delivery against the order's lines and moves the order to
+// partially or fully received. Receiving more than a line has outstanding
+// is rejected.
+func (po *PurchaseOrder) Receive(receipt []ReceiptLine) error {
+	if po.Status.IsClosed() {
+		return fmt.Errorf("%w: purchase order is %s", ErrPurchaseOrderClosed, po.Status)
+	}
+	if len(receipt) == 0 {
+		return fmt.Errorf("%w: receipt has no lines", ErrInvalidPurchaseOrder)
+	}
+
+	for _, received := range receipt {
+		if received.Quantity <= 0 {
+			return fmt.Errorf("%w: received quantity must be positive", ErrInvalidPurchaseOrder)
+		}
+		line := po.line(received.ProductID, received.VariantSKU)
+		if line == nil {
+			return fmt.Errorf("%w: %s is not on the purchase order", ErrInvalidPurchaseOrder, describeLine(received.ProductID, received.VariantSKU))
+		}
+		if received.Quantity > line.Outstanding() {
+			return fmt.Errorf("%w: %d units of %s received, only %d outstanding", ErrInvalidPurchaseOrder,
+				received.Quantity, describeLine(received.ProductID, received.VariantSKU), line.Outstanding())
+		}
+		line.Received += received.Quantity
+	}
+
+	po.Status = PurchaseOrderReceived
+	for _, line := range po.Lines {
+		if line.Outstanding() > 0 {
+			po.Status = PurchaseOrderPartiallyReceived
+			break
+		}
+	}
+	return nil
+}
+
+func (po *PurchaseOrder) line(productID, sku string) *PurchaseOrderLine {
+	for i := range po.Lines {
+		if po.Lines[i].ProductID == productID && po.Lines[i].VariantSKU == sku {
+			return &po.Lines[i]
+		}
+	}
+	return nil
+}
+
+func describeLine(productID, sku string) string {
+	if sku != "" {
+		return "variant " + sku
+	}
+	return "product " + productID
+}
+
+// PurchaseOrderFilter narrows a purchase order listing; empty fields match
+// every order.
+type PurchaseOrderFilter struct {
+	SupplierID string
+	Status     PurchaseOrderStatus
+}
+
+// PurchaseOrderCursor returns the cursor positioned just after po.
+func PurchaseOrderCursor(po *PurchaseOrder) *PageCursor {
+	return &PageCursor{CreatedAt: po.CreatedAt, ID: po.ID}
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type ReservationStatus string
+
+const (
+	ReservationStatusActive    ReservationStatus = "active"
+	ReservationStatusCommitted ReservationStatus = "committed"
+	ReservationStatusReleased  ReservationStatus = "released"
+	ReservationStatusExpired   ReservationStatus = "expired"
+	// ReservationStatusBackordered holds no stock yet; the reservation
+	// becomes active once stock for all of its items has arrived
+	ReservationStatusBackordered ReservationStatus = "backordered"
+)
+
+// ReservationItem holds stock of a product, or of one of its variants when
+// VariantSKU is set. WarehouseID names the warehouse the units were
+// allocated from; it is empty for units taken from unassigned stock.
+type ReservationItem struct {
+	ProductID   string `json:"product_id"`
+	VariantSKU  string `json:"variant_sku,omitempty"`
+	WarehouseID string `json:"warehouse_id,omitempty"`
+	Quantity    int    `json:"quantity"`
+}
+
+// Reservation holds stock for a single order until it is committed,
+// released or its ExpiresAt deadline passes. A backordered reservation
+// waits for stock without a deadline; ShipTo is kept so its stock can be
+// allocated once it arrives. Returns lists the customer returns taken back
+// against a committed reservation.
+type Reservation struct {
+	OrderID   string              `json:"order_id"`
+	Items     []ReservationItem   `json:"items"`
+	Status    ReservationStatus   `json:"status"`
+	ShipTo    *GeoPoint           `json:"ship_to,omitempty"`
+	Returns   []ReservationReturn `json:"returns,omitempty"`
+	ExpiresAt time.Time           `json:"expires_at"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// ReturnedItem is a quantity of a sold product or variant that a customer
+// sent back. Resellable units go back into stock; the others are written
+// off.
+type ReturnedItem struct {
+	ProductID  string `json:"product_id"`
+	VariantSKU string `json:"variant_sku,omitempty"`
+	Quantity   int    `json:"quantity"`
+	Resellable bool   `json:"resellable"`
+}
+
+// ReservationReturn is a customer return taken back against a committed
+// reservation, with the items it took back.
+type ReservationReturn struct {
+	ID    string         `json:"id"`
+	Items []ReturnedItem `json:"items,omitempty"`
+}
+
+// HasReturn reports whether the return with id was taken back.
+func (r *Reservation) HasReturn(id string) bool {
+	for _, ret := range r.Returns {
+		if ret.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckReturn fails with ErrInvalidReturn unless every item of a return
+// was sold with the reservation and not already sent back by an earlier
+// return.
+func (r *Reservation) CheckReturn(items []ReturnedItem) error {
+	type key struct{ productID, sku string }
+	left := map[key]int{}
+	for _, item := range r.Items {
+		left[key{item.ProductID, item.VariantSKU}] += item.Quantity
+	}
+	for _, ret := range r.Returns {
+		for _, item := range ret.Items {
+			left[key{item.ProductID, item.VariantSKU}] -= item.Quantity
+		}
+	}
+
+	for i, item := range items {
+		k := key{item.ProductID, item.VariantSKU}
+		if item.Quantity <= 0 || item.Quantity > left[k] {
+			name := "product " + item.ProductID
+			if item.VariantSKU != "" {
+				name = "variant " + item.VariantSKU
+			}
+			return fmt.Errorf("%w: item %d returns more of %s than is left of order %s to return", ErrInvalidReturn, i, name, r.OrderID)
+		}
+		left[k] -= item.Quantity
+	}
+	return nil
+}
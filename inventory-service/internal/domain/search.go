@@ -0,0 +1,56 @@
+package domain
+
+type ProductSort string
+
+const (
+	ProductSortRelevance ProductSort = "relevance"
+	ProductSortPrice     ProductSort = "price"
+	ProductSortName      ProductSort = "name"
+	ProductSortCreatedAt ProductSort = "created_at"
+)
+
+func (s ProductSort) IsValid() bool {
+	switch s {
+	case ProductSortRelevance, ProductSortPrice, ProductSortName, ProductSortCreatedAt:
+		return true
+	}
+	return false
+}
+
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// ProductSearch describes a filtered, sorted product query. Zero values
+// leave the corresponding filter off, except that archived products are
+// left out unless IncludeArchived is set.
+type ProductSearch struct {
+	Query           string
+	CategoryIDs     []string
+	MinPrice        float64
+	MaxPrice        float64
+	InStockOnly     bool
+	IncludeArchived bool
+	SortBy          ProductSort
+	Order           SortOrder
+	Page            int
+	Limit           int
+}
+
+// CategoryFacet counts the products in one category that match a search
+// when its category filter is ignored.
+type CategoryFacet struct {
+	CategoryID string
+	Count      int
+}
+
+type ProductSearchResult struct {
+	Products []*Product
+	Total    int
+	Page     int
+	Limit    int
+	Facets   []CategoryFacet
+}
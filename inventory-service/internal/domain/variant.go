@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Variant is a sellable version of a product, such as one size and color
+// of a shirt. Products with variants keep their stock per variant.
+type Variant struct {
+	SKU        string            `json:"sku"`
+	ProductID  string            `json:"product_id"`
+	Attributes map[string]string `json:"attributes"`
+	// Price overrides the product price when positive
+	Price     float64   `json:"price"`
+	Stock     int       `json:"stock"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// EffectivePrice is the price the variant sells at.
+func (v *Variant) EffectivePrice(product *Product) float64 {
+	if v.Price > 0 {
+		return v.Price
+	}
+	return product.Price
+}
+
+// VariantUpdate lists the variant fields to change; nil fields are left
+// as they are. Stock changes through AdjustStock instead.
+type VariantUpdate struct {
+	Attributes map[string]string
+	Price      *float64
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Warehouse is a location stock is kept at and shipped from.
+type Warehouse struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Location GeoPoint `json:"location"`
+	// Priority orders warehouses for the priority allocation strategy;
+	// lower values are drawn from first
+	Priority int `json:"priority"`
+	// Inactive warehouses keep their stock but are never allocated from
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WarehouseUpdate lists the warehouse fields to change; nil fields are
+// left as they are.
+type WarehouseUpdate struct {
+	Name     *string
+	Location *GeoPoint
+	Priority *int
+	Active   *bool
+}
+
+// GeoPoint is a position in decimal degrees.
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// IsValid reports whether the point lies within the coordinate ranges.
+func (p GeoPoint) IsValid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+// DistanceKm returns the great-circle distance between two points.
+func (p GeoPoint) DistanceKm(q GeoPoint) float64 {
+	const earthRadiusKm = 6371
+
+	lat1, lat2 := p.Latitude*math.Pi/180, q.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (q.Longitude - p.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// StockLevel is the quantity of a product, or of one of its variants when
+// VariantSKU is set, held at one warehouse.
+type StockLevel struct {
+	WarehouseID string    `json:"warehouse_id"`
+	ProductID   string    `json:"product_id"`
+	VariantSKU  string    `json:"variant_sku,omitempty"`
+	Quantity    int       `json:"quantity"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ProductStock breaks the stock of a product down by warehouse. Total is
+// the product's stock, or the sum over its variants; the part of it not
+// held at any warehouse is Unassigned. Available leaves out stock held at
+// inactive warehouses.
+type ProductStock struct {
+	ProductID  string        `json:"product_id"`
+	Total      int           `json:"total"`
+	Available  int           `json:"available"`
+	Unassigned int           `json:"unassigned"`
+	Levels     []*StockLevel `json:"levels"`
+}
+
+// StockTransfer moves units of a product or variant between warehouses.
+// An empty FromWarehouseID draws on unassigned stock and an empty
+// ToWarehouseID returns the units to it.
+type StockTransfer struct {
+	ProductID       string `json:"product_id"`
+	VariantSKU      string `json:"variant_sku,omitempty"`
+	FromWarehouseID string `json:"from_warehouse_id"`
+	ToWarehouseID   string `json:"to_warehouse_id"`
+	Quantity        int    `json:"quantity"`
+}
+
+// AllocationStrategy decides which warehouses a reservation draws from.
+type AllocationStrategy string
+
+const (
+	// AllocationNearest prefers the warehouses closest to the shipping
+	// address, falling back to priority when none is given.
+	AllocationNearest AllocationStrategy = "nearest"
+	// AllocationMostStock prefers the warehouses holding the most units,
+	// which keeps orders from being split.
+	AllocationMostStock AllocationStrategy = "most_stock"
+	// AllocationPriority follows the warehouses' Priority.
+	AllocationPriority AllocationStrategy = "priority"
+)
+
+func (s AllocationStrategy) IsValid() bool {
+	switch s {
+	case AllocationNearest, AllocationMostStock, AllocationPriority:
+		return true
+	}
+	return false
+}
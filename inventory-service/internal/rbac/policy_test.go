@@ -0,0 +1,130 @@
+package rbac
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"inventory-service/internal/auth"
+)
+
+const (
+	testSecret = "test-secret"
+	testMethod = "/inventory.InventoryService/CreateProduct"
+)
+
+// signedMetadata returns the metadata the gateway sends for a caller,
+// signed with secret.
+func signedMetadata(secret, userID, roles string, expires time.Time) metadata.MD {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(userID + "\n" + roles + "\n" + exp))
+	return metadata.Pairs(
+		auth.MetadataUserID, userID,
+		auth.MetadataRoles, roles,
+		auth.MetadataExpires, exp,
+		auth.MetadataSignature, hex.EncodeToString(mac.Sum(nil)),
+	)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	verifier, err := auth.NewVerifier(testSecret)
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+	policy := &Policy{Methods: map[string][]string{testMethod: {auth.RoleCatalogManager}}}
+	interceptor := UnaryServerInterceptor(policy, verifier)
+	inAMinute := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want codes.Code
+	}{
+		{
+			name: "signed",
+			md:   signedMetadata(testSecret, "u1", auth.RoleCatalogManager, inAMinute),
+			want: codes.OK,
+		},
+		{
+			name: "no metadata",
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "missing signature",
+			md: func() metadata.MD {
+				md := signedMetadata(testSecret, "u1", auth.RoleCatalogManager, inAMinute)
+				md.Delete(auth.MetadataSignature)
+				return md
+			}(),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "signed with another secret",
+			md:   signedMetadata("guessed", "u1", auth.RoleCatalogManager, inAMinute),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "roles changed after signing",
+			md: func() metadata.MD {
+				md := signedMetadata(testSecret, "u1", auth.RoleCustomer, inAMinute)
+				md.Set(auth.MetadataRoles, auth.RoleCatalogManager)
+				return md
+			}(),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "signature not hex",
+			md: func() metadata.MD {
+				md := signedMetadata(testSecret, "u1", auth.RoleCatalogManager, inAMinute)
+				md.Set(auth.MetadataSignature, "not-a-signature")
+				return md
+			}(),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "expired",
+			md:   signedMetadata(testSecret, "u1", auth.RoleCatalogManager, time.Now().Add(-time.Minute)),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "signed without the role",
+			md:   signedMetadata(testSecret, "u1", auth.RoleCustomer, inAMinute),
+			want: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var called bool
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				if identity, ok := auth.FromContext(ctx); !ok || identity.UserID != "u1" {
+					t.Errorf("handler got identity %+v, %v", identity, ok)
+				}
+				return nil, nil
+			}
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: testMethod}, handler)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("interceptor returned %v, want %v", err, tt.want)
+			}
+			if called != (tt.want == codes.OK) {
+				t.Fatalf("handler called = %v for %v", called, tt.want)
+			}
+		})
+	}
+}
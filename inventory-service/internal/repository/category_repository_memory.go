@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type memoryCategoryRepository struct {
+	mu         sync.RWMutex
+	categories map[string]domain.Category
+}
+
+// NewMemoryCategoryRepository returns a thread-safe CategoryRepository
+// that keeps everything in process memory.
+func NewMemoryCategoryRepository() CategoryRepository {
+	return &memoryCategoryRepository{
+		categories: map[string]domain.Category{},
+	}
+}
+
+func (r *memoryCategoryRepository) Create(ctx context.Context, category *domain.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if category.ID == "" {
+		category.ID = primitive.NewObjectID().Hex()
+	}
+	if _, ok := r.categories[category.ID]; ok {
+		return domain.ErrCategoryExists
+	}
+	if r.siblingNamed(category.ID, category.ParentID, category.Name) {
+		return domain.ErrCategoryExists
+	}
+
+	stored := *category
+	stored.CreatedAt = time.Now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.categories[stored.ID] = stored
+
+	return nil
+}
+
+func (r *memoryCategoryRepository) FindByID(ctx context.Context, id string) (*domain.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, ok := r.categories[id]
+	if !ok {
+		return nil, domain.ErrCategoryNotFound
+	}
+
+	return &category, nil
+}
+
+func (r *memoryCategoryRepository) Update(ctx context.Context, category *domain.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.categories[category.ID]
+	if !ok {
+		return domain.ErrCategoryNotFound
+	}
+	if r.siblingNamed(category.ID, category.ParentID, category.Name) {
+		return domain.ErrCategoryExists
+	}
+
+	existing.Name = category.Name
+	existing.Description = category.Description
+	existing.ParentID = category.ParentID
+	existing.UpdatedAt = time.Now()
+	r.categories[category.ID] = existing
+
+	return nil
+}
+
+func (r *memoryCategoryRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.categories[id]; !ok {
+		return domain.ErrCategoryNotFound
+	}
+	delete(r.categories, id)
+
+	return nil
+}
+
+func (r *memoryCategoryRepository) List(ctx context.Context) ([]*domain.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make([]*domain.Category, 0, len(r.categories))
+	for _, category := range r.categories {
+		category := category
+		categories = append(categories, &category)
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := strings.ToLower(categories[i].Name), strings.ToLower(categories[j].Name)
+		if a != b {
+			return a < b
+		}
+		return categories[i].ID < categories[j].ID
+	})
+
+	return categories, nil
+}
+
+// siblingNamed reports whether a category other than id already uses name
+// under parentID. Callers must hold the lock.
+func (r *memoryCategoryRepository) siblingNamed(id, parentID, name string) bool {
+	for _, category := range r.categories {
+		if category.ID != id && category.ParentID == parentID && strings.EqualFold(category.Name, name) {
+			return true
+		}
+	}
+	return false
+}
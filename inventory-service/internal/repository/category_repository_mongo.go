@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"inventory-service/internal/domain"
+)
+
+type categoryRepository struct {
+	collection *mongo.Collection
+}
+
+type categoryDocument struct {
+	ID          string    `bson:"_id"`
+	Name        string    `bson:"name"`
+	Description string    `bson:"description"`
+	ParentID    string    `bson:"parent_id"`
+	CreatedAt   time.Time `bson:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+}
+
+// categoryCollation compares names case-insensitively, matching the
+// unique sibling-name index.
+var categoryCollation = &options.Collation{Locale: "en", Strength: 2}
+
+func NewMongoCategoryRepository(db *mongo.Database) CategoryRepository {
+	return &categoryRepository{
+		collection: db.Collection("categories"),
+	}
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category *domain.Category) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if category.ID == "" {
+		category.ID = primitive.NewObjectID().Hex()
+	}
+
+	now := time.Now()
+	_, err := r.collection.InsertOne(ctx, categoryDocument{
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		ParentID:    category.ParentID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrCategoryExists
+	}
+
+	return err
+}
+
+func (r *categoryRepository) FindByID(ctx context.Context, id string) (*domain.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var result categoryDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *categoryRepository) Update(ctx context.Context, category *domain.Category) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateByID(ctx, category.ID, bson.M{
+		"$set": bson.M{
+			"name":        category.Name,
+			"description": category.Description,
+			"parent_id":   category.ParentID,
+			"updated_at":  time.Now(),
+		},
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrCategoryExists
+	}
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+func (r *categoryRepository) List(ctx context.Context) ([]*domain.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetCollation(categoryCollation)
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	categories := []*domain.Category{}
+	for cursor.Next(ctx) {
+		var result categoryDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		categories = append(categories, result.toDomain())
+	}
+
+	return categories, cursor.Err()
+}
+
+func (d *categoryDocument) toDomain() *domain.Category {
+	return &domain.Category{
+		ID:          d.ID,
+		Name:        d.Name,
+		Description: d.Description,
+		ParentID:    d.ParentID,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type postgresCategoryRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCategoryRepository(db *sql.DB) CategoryRepository {
+	return &postgresCategoryRepository{db: db}
+}
+
+const categoryColumns = `id, name, description, parent_id, created_at, updated_at`
+
+func (r *postgresCategoryRepository) Create(ctx context.Context, category *domain.Category) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if category.ID == "" {
+		category.ID = primitive.NewObjectID().Hex()
+	}
+
+	now := time.Now()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO categories (`+categoryColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $5)`,
+		category.ID, category.Name, category.Description, category.ParentID, now,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrCategoryExists
+	}
+
+	return err
+}
+
+func (r *postgresCategoryRepository) FindByID(ctx context.Context, id string) (*domain.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+categoryColumns+` FROM categories WHERE id = $1`, id)
+	category, err := scanCategory(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrCategoryNotFound
+	}
+
+	return category, err
+}
+
+func (r *postgresCategoryRepository) Update(ctx context.Context, category *domain.Category) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE categories
+		SET name = $2, description = $3, parent_id = $4, updated_at = $5
+		WHERE id = $1`,
+		category.ID, category.Name, category.Description, category.ParentID, time.Now(),
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrCategoryExists
+	}
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, domain.ErrCategoryNotFound)
+}
+
+func (r *postgresCategoryRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, domain.ErrCategoryNotFound)
+}
+
+func (r *postgresCategoryRepository) List(ctx context.Context) ([]*domain.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+categoryColumns+` FROM categories ORDER BY lower(name), id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []*domain.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+func scanCategory(row rowScanner) (*domain.Category, error) {
+	var c domain.Category
+	err := row.Scan(&c.ID, &c.Name, &c.Description, &c.ParentID, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+// NewMemoryRepositories returns repositories that keep all data in process
+// memory, for tests and local development without a database.
+func NewMemoryRepositories() Repositories {
+	outbox := NewMemoryOutbox()
+	return Repositories{
+		Products:       NewMemoryProductRepository(outbox),
+		Categories:     NewMemoryCategoryRepository(),
+		Variants:       NewMemoryVariantRepository(outbox),
+		Warehouses:     NewMemoryWarehouseRepository(),
+		StockLevels:    NewMemoryStockLevelRepository(outbox),
+		Movements:      NewMemoryStockMovementRepository(),
+		Reservations:   NewMemoryReservationRepository(),
+		Suppliers:      NewMemorySupplierRepository(),
+		PurchaseOrders: NewMemoryPurchaseOrderRepository(),
+		Outbox:         outbox,
+	}
+}
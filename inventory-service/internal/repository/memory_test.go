@@ -0,0 +1,14 @@
+package repository_test
+
+import (
+	"testing"
+
+	"inventory-service/internal/repository"
+	"inventory-service/internal/repository/repotest"
+)
+
+func TestMemoryRepositories(t *testing.T) {
+	repotest.TestRepositories(t, func(t *testing.T) repository.Repositories {
+		return repository.NewMemoryRepositories()
+	})
+}
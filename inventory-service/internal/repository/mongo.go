@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NewMongoRepositories returns repositories backed by MongoDB.
+func NewMongoRepositories(db *mongo.Database) Repositories {
+	return Repositories{
+		Products:       NewMongoProductRepository(db),
+		Categories:     NewMongoCategoryRepository(db),
+		Variants:       NewMongoVariantRepository(db),
+		Warehouses:     NewMongoWarehouseRepository(db),
+		StockLevels:    NewMongoStockLevelRepository(db),
+		Movements:      NewMongoStockMovementRepository(db),
+		Reservations:   NewMongoReservationRepository(db),
+		Suppliers:      NewMongoSupplierRepository(db),
+		PurchaseOrders: NewMongoPurchaseOrderRepository(db),
+		Outbox:         NewMongoOutboxRepository(db),
+	}
+}
+
+// CheckMongoTransactions fails unless db is served by a replica set or a
+// sharded cluster. The repositories write outbox events in transactions,
+// which a standalone server refuses.
+func CheckMongoTransactions(ctx context.Context, db *mongo.Database) error {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := db.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return fmt.Errorf("check MongoDB deployment: %w", err)
+	}
+	if hello.SetName == "" && hello.Msg != "isdbgrid" {
+		return errors.New("MongoDB runs standalone but transactions need a replica set; start mongod with --replSet and run rs.initiate() once, a single node will do")
+	}
+	return nil
+}
+
+// EnsureMongoIndexes creates the indexes the MongoDB repositories rely
+// on. It is safe to call on every start.
+func EnsureMongoIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("products").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// Back the cursor range scans of ListProducts
+		{Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "category_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		// Backs the scans of the low-stock checker
+		{Keys: bson.D{{Key: "reorder_point", Value: 1}}},
+		// Backs the scans of the purge job; only archived products have
+		// a date here
+		{
+			Keys: bson.D{{Key: "archived_at", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().
+				SetPartialFilterExpression(bson.M{"archived_at": bson.M{"$type": "date"}}),
+		},
+		// SKUs are optional but unique; products without one have no field
+		{
+			Keys: bson.D{{Key: "sku", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"sku": bson.M{"$type": "string"}}),
+		},
+		// SearchProducts needs the text index; the weights rank name
+		// matches above description matches. Stemming is off so every
+		// backend matches whole words the same way.
+		{
+			Keys: bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}},
+			Options: options.Index().
+				SetName("products_search_idx").
+				SetWeights(bson.M{"name": 5, "description": 2}).
+				SetDefaultLanguage("none"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Sibling names are unique regardless of case
+	_, err = db.Collection("categories").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "parent_id", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetCollation(categoryCollation),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("variants").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// One level per warehouse, product and variant; the unique index lets
+	// concurrent upserts of a new level converge on a single document
+	_, err = db.Collection("stock_levels").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "warehouse_id", Value: 1}, {Key: "product_id", Value: 1}, {Key: "variant_sku", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "warehouse_id", Value: 1}, {Key: "variant_sku", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("stock_movements").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "order_id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Backs the oldest-first scans of backordered reservations
+	_, err = db.Collection("reservations").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("suppliers").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Back the cursor range scans of ListPurchaseOrders
+	_, err = db.Collection("purchase_orders").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "supplier_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Backs the oldest-first scans of the outbox relay
+	_, err = db.Collection("outbox").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	return err
+}
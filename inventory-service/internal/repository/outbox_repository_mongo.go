@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"inventory-service/internal/domain"
+)
+
+type outboxRepository struct {
+	collection *mongo.Collection
+}
+
+type eventDocument struct {
+	ID          string    `bson:"_id"`
+	Type        string    `bson:"type"`
+	AggregateID string    `bson:"aggregate_id"`
+	Payload     []byte    `bson:"payload"`
+	OccurredAt  time.Time `bson:"occurred_at"`
+}
+
+func NewMongoOutboxRepository(db *mongo.Database) OutboxRepository {
+	return &outboxRepository{
+		collection: db.Collection("outbox"),
+	}
+}
+
+func (r *outboxRepository) ListPending(ctx context.Context, limit int) ([]*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{},
+		options.Find().
+			SetLimit(int64(limit)).
+			SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*domain.Event{}
+	for cursor.Next(ctx) {
+		var doc eventDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		events = append(events, &domain.Event{
+			ID:          doc.ID,
+			Type:        domain.EventType(doc.Type),
+			AggregateID: doc.AggregateID,
+			Payload:     doc.Payload,
+			OccurredAt:  doc.OccurredAt,
+		})
+	}
+
+	return events, cursor.Err()
+}
+
+func (r *outboxRepository) Delete(ctx context.Context, ids []string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
+
+// inTransaction runs fn in a MongoDB transaction, so that a change and the
+// events fn writes about it commit together. Transactions need MongoDB to
+// run as a replica set; a single-node one will do.
+func inTransaction(ctx context.Context, db *mongo.Database, fn func(ctx mongo.SessionContext) error) error {
+	return db.Client().UseSession(ctx, func(sc mongo.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(sc mongo.SessionContext) (interface{}, error) {
+			return nil, fn(sc)
+		})
+		return err
+	})
+}
+
+// writeEvent adds an event about aggregateID to the outbox of db; within
+// inTransaction it commits together with the change it describes.
+func writeEvent(ctx context.Context, db *mongo.Database, eventType domain.EventType, aggregateID string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("outbox").InsertOne(ctx, eventDocument{
+		ID:          primitive.NewObjectID().Hex(),
+		Type:        string(eventType),
+		AggregateID: aggregateID,
+		Payload:     data,
+		OccurredAt:  time.Now(),
+	})
+	return err
+}
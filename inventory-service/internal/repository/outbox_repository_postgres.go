@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type postgresOutboxRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOutboxRepository(db *sql.DB) OutboxRepository {
+	return &postgresOutboxRepository{db: db}
+}
+
+func (r *postgresOutboxRepository) ListPending(ctx context.Context, limit int) ([]*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, type, aggregate_id, payload, occurred_at FROM outbox
+		ORDER BY seq
+		LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*domain.Event{}
+	for rows.Next() {
+		var (
+			event     domain.Event
+			eventType string
+			payload   []byte
+		)
+		if err := rows.Scan(&event.ID, &eventType, &event.AggregateID, &payload, &event.OccurredAt); err != nil {
+			return nil, err
+		}
+		event.Type = domain.EventType(eventType)
+		event.Payload = payload
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
+
+func (r *postgresOutboxRepository) Delete(ctx context.Context, ids []string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM outbox WHERE id = ANY($1)`, pq.Array(ids))
+	return err
+}
+
+// inTx runs fn in a transaction that is committed only when fn succeeds,
+// so that a change and the events fn writes about it land together.
+func inTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// writeEventTx adds an event about aggregateID to the outbox within tx.
+func writeEventTx(ctx context.Context, tx *sql.Tx, eventType domain.EventType, aggregateID string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO outbox (id, type, aggregate_id, payload, occurred_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		primitive.NewObjectID().Hex(), string(eventType), aggregateID, data, time.Now(),
+	)
+	return err
+}
@@ -0,0 +1,20 @@
+package repository
+
+import "database/sql"
+
+// NewPostgresRepositories returns repositories backed by PostgreSQL. The
+// schema is created by the migrations package.
+func NewPostgresRepositories(db *sql.DB) Repositories {
+	return Repositories{
+		Products:       NewPostgresProductRepository(db),
+		Categories:     NewPostgresCategoryRepository(db),
+		Variants:       NewPostgresVariantRepository(db),
+		Warehouses:     NewPostgresWarehouseRepository(db),
+		StockLevels:    NewPostgresStockLevelRepository(db),
+		Movements:      NewPostgresStockMovementRepository(db),
+		Reservations:   NewPostgresReservationRepository(db),
+		Suppliers:      NewPostgresSupplierRepository(db),
+		PurchaseOrders: NewPostgresPurchaseOrderRepository(db),
+		Outbox:         NewPostgresOutboxRepository(db),
+	}
+}
@@ -0,0 +1,43 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"inventory-service/internal/repository"
+	"inventory-service/internal/repository/repotest"
+	"inventory-service/migrations"
+)
+
+// TestPostgresRepositories runs the suite against the database in
+// TEST_DB_DSN and is skipped without one. Every repository set starts from
+// emptied tables, so point it at a database kept for tests.
+func TestPostgresRepositories(t *testing.T) {
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN is not set")
+	}
+
+	ctx := context.Background()
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := migrations.Apply(ctx, db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	repotest.TestRepositories(t, func(t *testing.T) repository.Repositories {
+		_, err := db.ExecContext(ctx, `
+			TRUNCATE products, categories, variants, warehouses, stock_levels, stock_movements,
+				reservations, suppliers, purchase_orders, outbox`)
+		if err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+		return repository.NewPostgresRepositories(db)
+	})
+}
@@ -0,0 +1,428 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type memoryProductRepository struct {
+	mu       sync.RWMutex
+	products map[string]domain.Product
+	outbox   *MemoryOutbox
+}
+
+// NewMemoryProductRepository returns a thread-safe ProductRepository that
+// keeps everything in process memory and writes its events to outbox.
+func NewMemoryProductRepository(outbox *MemoryOutbox) ProductRepository {
+	return &memoryProductRepository{
+		products: map[string]domain.Product{},
+		outbox:   outbox,
+	}
+}
+
+func (r *memoryProductRepository) Create(ctx context.Context, product *domain.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if product.ID == "" {
+		product.ID = primitive.NewObjectID().Hex()
+	}
+	if _, ok := r.products[product.ID]; ok || r.skuTaken(product.SKU, product.ID) {
+		return domain.ErrProductExists
+	}
+
+	product.Version = 1
+	stored := *product
+	stored.CreatedAt = time.Now()
+	stored.UpdatedAt = stored.CreatedAt
+	if err := r.outbox.add(domain.EventProductCreated, stored.ID, stored); err != nil {
+		return err
+	}
+	r.products[stored.ID] = stored
+
+	return nil
+}
+
+func (r *memoryProductRepository) FindByID(ctx context.Context, id string) (*domain.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	product, ok := r.products[id]
+	if !ok {
+		return nil, domain.ErrProductNotFound
+	}
+
+	return &product, nil
+}
+
+func (r *memoryProductRepository) FindBySKU(ctx context.Context, sku string) (*domain.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, product := range r.products {
+		if product.SKU == sku {
+			return &product, nil
+		}
+	}
+
+	return nil, domain.ErrProductNotFound
+}
+
+// skuTaken reports whether a product other than id holds sku. The caller
+// must hold the lock.
+func (r *memoryProductRepository) skuTaken(sku, id string) bool {
+	if sku == "" {
+		return false
+	}
+	for _, product := range r.products {
+		if product.SKU == sku && product.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryProductRepository) Update(ctx context.Context, product *domain.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.products[product.ID]
+	if !ok {
+		return domain.ErrProductNotFound
+	}
+	if existing.Version != product.Version {
+		return domain.ErrVersionConflict
+	}
+	if r.skuTaken(product.SKU, product.ID) {
+		return domain.ErrProductExists
+	}
+
+	existing.SKU = product.SKU
+	existing.Name = product.Name
+	existing.Description = product.Description
+	existing.Price = product.Price
+	existing.CategoryID = product.CategoryID
+	existing.ReorderPoint = product.ReorderPoint
+	existing.ReorderQuantity = product.ReorderQuantity
+	existing.BackorderMode = product.BackorderMode
+	existing.AvailableAt = product.AvailableAt
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+	if err := r.outbox.add(domain.EventProductUpdated, existing.ID, existing); err != nil {
+		return err
+	}
+	r.products[product.ID] = existing
+
+	return nil
+}
+
+func (r *memoryProductRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.products[id]; !ok {
+		return domain.ErrProductNotFound
+	}
+	if err := r.outbox.add(domain.EventProductDeleted, id, domain.ProductDeletion{ID: id}); err != nil {
+		return err
+	}
+	delete(r.products, id)
+
+	return nil
+}
+
+func (r *memoryProductRepository) Archive(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok {
+		return domain.ErrProductNotFound
+	}
+	if product.IsArchived() {
+		return nil
+	}
+
+	product.ArchivedAt = &at
+	product.UpdatedAt = at
+	if err := r.outbox.add(domain.EventProductArchived, id, product); err != nil {
+		return err
+	}
+	r.products[id] = product
+
+	return nil
+}
+
+func (r *memoryProductRepository) Restore(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok {
+		return domain.ErrProductNotFound
+	}
+	if !product.IsArchived() {
+		return domain.ErrProductNotArchived
+	}
+
+	product.ArchivedAt = nil
+	product.UpdatedAt = time.Now()
+	if err := r.outbox.add(domain.EventProductRestored, id, product); err != nil {
+		return err
+	}
+	r.products[id] = product
+
+	return nil
+}
+
+func (r *memoryProductRepository) ListArchivedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	products := []*domain.Product{}
+	for _, product := range r.products {
+		if product.IsArchived() && product.ArchivedAt.Before(cutoff) {
+			product := product
+			products = append(products, &product)
+		}
+	}
+
+	// Longest archived first, matching the database backends
+	sort.Slice(products, func(i, j int) bool {
+		if !products[i].ArchivedAt.Equal(*products[j].ArchivedAt) {
+			return products[i].ArchivedAt.Before(*products[j].ArchivedAt)
+		}
+		return products[i].ID < products[j].ID
+	})
+	if len(products) > limit {
+		products = products[:limit]
+	}
+
+	return products, nil
+}
+
+func (r *memoryProductRepository) List(ctx context.Context, categoryIDs []string, includeArchived bool, after *domain.PageCursor, limit int) ([]*domain.Product, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make(map[string]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		categories[id] = true
+	}
+
+	matched := make([]*domain.Product, 0, len(r.products))
+	for _, product := range r.products {
+		if len(categories) > 0 && !categories[product.CategoryID] {
+			continue
+		}
+		if product.IsArchived() && !includeArchived {
+			continue
+		}
+		product := product
+		matched = append(matched, &product)
+	}
+	total := len(matched)
+
+	// Newest first, matching the database backends
+	sort.Slice(matched, func(i, j int) bool {
+		return newerProduct(matched[i], matched[j])
+	})
+
+	if after != nil {
+		cursor := &domain.Product{ID: after.ID, CreatedAt: after.CreatedAt}
+		start := sort.Search(len(matched), func(i int) bool {
+			return newerProduct(cursor, matched[i])
+		})
+		matched = matched[start:]
+	}
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	return matched, total, nil
+}
+
+func (r *memoryProductRepository) Search(ctx context.Context, search domain.ProductSearch) (*domain.ProductSearchResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := searchTerms(search.Query)
+	categories := make(map[string]bool, len(search.CategoryIDs))
+	for _, id := range search.CategoryIDs {
+		categories[id] = true
+	}
+
+	type hit struct {
+		product *domain.Product
+		score   float64
+	}
+
+	var hits []hit
+	counts := map[string]int{}
+	for _, product := range r.products {
+		score, ok := matchProduct(&product, terms, search)
+		if !ok {
+			continue
+		}
+		// Facets ignore the category filter
+		counts[product.CategoryID]++
+		if len(categories) > 0 && !categories[product.CategoryID] {
+			continue
+		}
+		product := product
+		hits = append(hits, hit{product: &product, score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+
+		var c int
+		switch search.SortBy {
+		case domain.ProductSortRelevance:
+			c = cmp.Compare(a.score, b.score)
+		case domain.ProductSortPrice:
+			c = cmp.Compare(a.product.Price, b.product.Price)
+		case domain.ProductSortName:
+			c = cmp.Compare(strings.ToLower(a.product.Name), strings.ToLower(b.product.Name))
+		case domain.ProductSortCreatedAt:
+			c = a.product.CreatedAt.Compare(b.product.CreatedAt)
+		}
+		if search.Order == domain.SortDescending {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+		return newerProduct(a.product, b.product)
+	})
+
+	products := make([]*domain.Product, len(hits))
+	for i, h := range hits {
+		products[i] = h.product
+	}
+
+	facets := make([]domain.CategoryFacet, 0, len(counts))
+	for id, count := range counts {
+		facets = append(facets, domain.CategoryFacet{CategoryID: id, Count: count})
+	}
+	sortFacets(facets)
+
+	return &domain.ProductSearchResult{
+		Products: paginate(products, search.Page, search.Limit),
+		Total:    len(products),
+		Facets:   facets,
+	}, nil
+}
+
+func (r *memoryProductRepository) AdjustStock(ctx context.Context, id string, delta int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	product, ok := r.products[id]
+	if !ok {
+		return 0, domain.ErrProductNotFound
+	}
+	if product.Stock+delta < 0 {
+		return 0, domain.ErrInsufficientStock
+	}
+
+	product.Stock += delta
+	product.UpdatedAt = time.Now()
+	change := domain.StockChange{ProductID: id, Delta: delta, Stock: product.Stock}
+	if err := r.outbox.add(domain.EventStockAdjusted, id, change); err != nil {
+		return 0, err
+	}
+	r.products[id] = product
+
+	return product.Stock, nil
+}
+
+func (r *memoryProductRepository) ListReorderable(ctx context.Context) ([]*domain.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	products := []*domain.Product{}
+	for _, product := range r.products {
+		if product.ReorderPoint > 0 && !product.IsArchived() {
+			product := product
+			products = append(products, &product)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].ID < products[j].ID
+	})
+
+	return products, nil
+}
+
+// newerProduct orders products by creation time, newest first, breaking
+// ties on ID so the order is stable.
+func newerProduct(a, b *domain.Product) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+// paginate returns the 1-based page of items.
+func paginate[T any](items []T, page, limit int) []T {
+	start := (page - 1) * limit
+	if start >= len(items) {
+		return []T{}
+	}
+
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return items[start:end]
+}
+
+// matchProduct applies every search filter except the category one and
+// scores the text match, weighting name hits above description hits.
+func matchProduct(product *domain.Product, terms []string, search domain.ProductSearch) (float64, bool) {
+	if search.MinPrice > 0 && product.Price < search.MinPrice {
+		return 0, false
+	}
+	if search.MaxPrice > 0 && product.Price > search.MaxPrice {
+		return 0, false
+	}
+	if search.InStockOnly && product.Stock <= 0 {
+		return 0, false
+	}
+	if product.IsArchived() && !search.IncludeArchived {
+		return 0, false
+	}
+	if len(terms) == 0 {
+		return 0, true
+	}
+
+	nameWords := countWords(product.Name)
+	descriptionWords := countWords(product.Description)
+
+	var score float64
+	for _, term := range terms {
+		if nameWords[term] == 0 && descriptionWords[term] == 0 {
+			return 0, false
+		}
+		score += float64(nameWords[term]) + 0.4*float64(descriptionWords[term])
+	}
+
+	return score, true
+}
+
+func countWords(text string) map[string]int {
+	words := map[string]int{}
+	for _, word := range searchTerms(text) {
+		words[word]++
+	}
+	return words
+}
@@ -0,0 +1,552 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"inventory-service/internal/domain"
+)
+
+type productRepository struct {
+	collection *mongo.Collection
+}
+
+type productDocument struct {
+	ID              string     `bson:"_id"`
+	SKU             string     `bson:"sku,omitempty"`
+	Name            string     `bson:"name"`
+	Description     string     `bson:"description"`
+	Price           float64    `bson:"price"`
+	Stock           int        `bson:"stock"`
+	CategoryID      string     `bson:"category_id"`
+	ReorderPoint    int        `bson:"reorder_point"`
+	ReorderQuantity int        `bson:"reorder_quantity"`
+	BackorderMode   string     `bson:"backorder_mode"`
+	AvailableAt     *time.Time `bson:"available_at"`
+	ArchivedAt      *time.Time `bson:"archived_at"`
+	Version         int64      `bson:"version"`
+	CreatedAt       time.Time  `bson:"created_at"`
+	UpdatedAt       time.Time  `bson:"updated_at"`
+}
+
+func NewMongoProductRepository(db *mongo.Database) ProductRepository {
+	return &productRepository{
+		collection: db.Collection("products"),
+	}
+}
+
+func (r *productRepository) Create(ctx context.Context, product *domain.Product) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Generate new ObjectID if not provided
+	if product.ID == "" {
+		product.ID = primitive.NewObjectID().Hex()
+	}
+
+	now := time.Now()
+	doc := bson.M{
+		"_id":              product.ID,
+		"name":             product.Name,
+		"description":      product.Description,
+		"price":            product.Price,
+		"stock":            product.Stock,
+		"category_id":      product.CategoryID,
+		"reorder_point":    product.ReorderPoint,
+		"reorder_quantity": product.ReorderQuantity,
+		"backorder_mode":   string(product.BackorderMode),
+		"available_at":     product.AvailableAt,
+		"archived_at":      product.ArchivedAt,
+		"version":          int64(1),
+		"created_at":       now,
+		"updated_at":       now,
+	}
+	// Left out when empty so the unique SKU index skips the product
+	if product.SKU != "" {
+		doc["sku"] = product.SKU
+	}
+	created := *product
+	created.Version = 1
+	created.CreatedAt = now
+	created.UpdatedAt = now
+	err := inTransaction(ctx, r.collection.Database(), func(ctx mongo.SessionContext) error {
+		if _, err := r.collection.InsertOne(ctx, doc); err != nil {
+			return err
+		}
+		return writeEvent(ctx, r.collection.Database(), domain.EventProductCreated, created.ID, created)
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrProductExists
+	}
+	if err != nil {
+		return err
+	}
+
+	product.Version = 1
+	return nil
+}
+
+func (r *productRepository) FindByID(ctx context.Context, id string) (*domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var result productDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *productRepository) FindBySKU(ctx context.Context, sku string) (*domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var result productDocument
+	err := r.collection.FindOne(ctx, bson.M{"sku": sku}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *productRepository) Update(ctx context.Context, product *domain.Product) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	set := bson.M{
+		"name":             product.Name,
+		"description":      product.Description,
+		"price":            product.Price,
+		"category_id":      product.CategoryID,
+		"reorder_point":    product.ReorderPoint,
+		"reorder_quantity": product.ReorderQuantity,
+		"backorder_mode":   string(product.BackorderMode),
+		"available_at":     product.AvailableAt,
+		"updated_at":       time.Now(),
+	}
+	update := bson.M{
+		"$set": set,
+		"$inc": bson.M{"version": 1},
+	}
+	if product.SKU != "" {
+		set["sku"] = product.SKU
+	} else {
+		update["$unset"] = bson.M{"sku": ""}
+	}
+
+	return inTransaction(ctx, r.collection.Database(), func(ctx mongo.SessionContext) error {
+		var updated productDocument
+		err := r.collection.FindOneAndUpdate(ctx,
+			bson.M{"_id": product.ID, "version": versionFilter(product.Version)},
+			update,
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&updated)
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrProductExists
+		}
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			count, err := r.collection.CountDocuments(ctx, bson.M{"_id": product.ID})
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return domain.ErrProductNotFound
+			}
+			return domain.ErrVersionConflict
+		}
+		if err != nil {
+			return err
+		}
+
+		return writeEvent(ctx, r.collection.Database(), domain.EventProductUpdated, product.ID, updated.toDomain())
+	})
+}
+
+func (r *productRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return inTransaction(ctx, r.collection.Database(), func(ctx mongo.SessionContext) error {
+		result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+		if err != nil {
+			return err
+		}
+		if result.DeletedCount == 0 {
+			return domain.ErrProductNotFound
+		}
+
+		return writeEvent(ctx, r.collection.Database(), domain.EventProductDeleted, id, domain.ProductDeletion{ID: id})
+	})
+}
+
+func (r *productRepository) Archive(ctx context.Context, id string, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return inTransaction(ctx, r.collection.Database(), func(ctx mongo.SessionContext) error {
+		var archived productDocument
+		err := r.collection.FindOneAndUpdate(ctx,
+			bson.M{"_id": id, "archived_at": nil},
+			bson.M{"$set": bson.M{"archived_at": at, "updated_at": at}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&archived)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			// Already archived products keep their first archive time
+			count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id})
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return domain.ErrProductNotFound
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return writeEvent(ctx, r.collection.Database(), domain.EventProductArchived, id, archived.toDomain())
+	})
+}
+
+func (r *productRepository) Restore(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return inTransaction(ctx, r.collection.Database(), func(ctx mongo.SessionContext) error {
+		var restored productDocument
+		err := r.collection.FindOneAndUpdate(ctx,
+			bson.M{"_id": id, "archived_at": bson.M{"$ne": nil}},
+			bson.M{"$set": bson.M{"archived_at": nil, "updated_at": time.Now()}},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&restored)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id})
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return domain.ErrProductNotFound
+			}
+			return domain.ErrProductNotArchived
+		}
+		if err != nil {
+			return err
+		}
+
+		return writeEvent(ctx, r.collection.Database(), domain.EventProductRestored, id, restored.toDomain())
+	})
+}
+
+func (r *productRepository) ListArchivedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"archived_at": bson.M{"$lt": cutoff}},
+		options.Find().
+			SetLimit(int64(limit)).
+			SetSort(bson.D{{Key: "archived_at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	products := []*domain.Product{}
+	for cursor.Next(ctx) {
+		var result productDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		products = append(products, result.toDomain())
+	}
+
+	return products, cursor.Err()
+}
+
+func (r *productRepository) List(ctx context.Context, categoryIDs []string, includeArchived bool, after *domain.PageCursor, limit int) ([]*domain.Product, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Build filter
+	filter := bson.M{}
+	if len(categoryIDs) > 0 {
+		filter["category_id"] = bson.M{"$in": categoryIDs}
+	}
+	if !includeArchived {
+		filter["archived_at"] = nil
+	}
+
+	// Get total count
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Resume strictly after the cursor in (created_at, _id) order
+	if after != nil {
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": after.CreatedAt}},
+			bson.M{"created_at": after.CreatedAt, "_id": bson.M{"$lt": after.ID}},
+		}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	// Execute query
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	products := []*domain.Product{}
+	for cursor.Next(ctx) {
+		var result productDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, 0, err
+		}
+		products = append(products, result.toDomain())
+	}
+
+	return products, int(total), cursor.Err()
+}
+
+func (r *productRepository) Search(ctx context.Context, search domain.ProductSearch) (*domain.ProductSearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	terms := searchTerms(search.Query)
+	if len(terms) > 0 {
+		// Quoting each word makes $text require all of them instead of any
+		filter["$text"] = bson.M{"$search": `"` + strings.Join(terms, `" "`) + `"`}
+	}
+	price := bson.M{}
+	if search.MinPrice > 0 {
+		price["$gte"] = search.MinPrice
+	}
+	if search.MaxPrice > 0 {
+		price["$lte"] = search.MaxPrice
+	}
+	if len(price) > 0 {
+		filter["price"] = price
+	}
+	if search.InStockOnly {
+		filter["stock"] = bson.M{"$gt": 0}
+	}
+	if !search.IncludeArchived {
+		// Matches documents written before archiving existed too
+		filter["archived_at"] = nil
+	}
+
+	// Facets are counted before the category filter is added
+	facets, err := r.searchFacets(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(search.CategoryIDs) > 0 {
+		filter["category_id"] = bson.M{"$in": search.CategoryIDs}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	direction := 1
+	if search.Order == domain.SortDescending {
+		direction = -1
+	}
+	sort := bson.D{}
+	opts := options.Find()
+	switch search.SortBy {
+	case domain.ProductSortRelevance:
+		// Text scores can only be sorted best first
+		if len(terms) > 0 {
+			sort = append(sort, bson.E{Key: "score", Value: bson.M{"$meta": "textScore"}})
+			opts.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
+		}
+	case domain.ProductSortPrice:
+		sort = append(sort, bson.E{Key: "price", Value: direction})
+	case domain.ProductSortName:
+		sort = append(sort, bson.E{Key: "name", Value: direction})
+		opts.SetCollation(&options.Collation{Locale: "en", Strength: 2})
+	case domain.ProductSortCreatedAt:
+		sort = append(sort, bson.E{Key: "created_at", Value: direction})
+	}
+	sort = append(sort, bson.E{Key: "created_at", Value: -1}, bson.E{Key: "_id", Value: -1})
+
+	opts.SetSort(sort).
+		SetSkip(int64((search.Page - 1) * search.Limit)).
+		SetLimit(int64(search.Limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	products := []*domain.Product{}
+	for cursor.Next(ctx) {
+		var result productDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		products = append(products, result.toDomain())
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return &domain.ProductSearchResult{
+		Products: products,
+		Total:    int(total),
+		Facets:   facets,
+	}, nil
+}
+
+func (r *productRepository) searchFacets(ctx context.Context, filter bson.M) ([]domain.CategoryFacet, error) {
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$category_id", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	facets := []domain.CategoryFacet{}
+	for cursor.Next(ctx) {
+		var result struct {
+			CategoryID string `bson:"_id"`
+			Count      int    `bson:"count"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		facets = append(facets, domain.CategoryFacet{CategoryID: result.CategoryID, Count: result.Count})
+	}
+	sortFacets(facets)
+
+	return facets, cursor.Err()
+}
+
+func (r *productRepository) AdjustStock(ctx context.Context, id string, delta int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var result productDocument
+	err := inTransaction(ctx, r.collection.Database(), func(ctx mongo.SessionContext) error {
+		// Only match while enough stock is left so concurrent reservations
+		// can never drive the counter below zero
+		err := r.collection.FindOneAndUpdate(ctx,
+			bson.M{"_id": id, "stock": bson.M{"$gte": -delta}},
+			bson.M{
+				"$inc": bson.M{"stock": delta},
+				"$set": bson.M{"updated_at": time.Now()},
+			},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&result)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id})
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return domain.ErrProductNotFound
+			}
+			return domain.ErrInsufficientStock
+		}
+		if err != nil {
+			return err
+		}
+
+		change := domain.StockChange{ProductID: id, Delta: delta, Stock: result.Stock}
+		return writeEvent(ctx, r.collection.Database(), domain.EventStockAdjusted, id, change)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Stock, nil
+}
+
+func (r *productRepository) ListReorderable(ctx context.Context) ([]*domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"reorder_point": bson.M{"$gt": 0}, "archived_at": nil},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	products := []*domain.Product{}
+	for cursor.Next(ctx) {
+		var result productDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		products = append(products, result.toDomain())
+	}
+
+	return products, cursor.Err()
+}
+
+// versionFilter matches a document at version, treating documents stored
+// before versions were tracked as version 0.
+func versionFilter(version int64) interface{} {
+	if version == 0 {
+		return bson.M{"$exists": false}
+	}
+	return version
+}
+
+func (d *productDocument) toDomain() *domain.Product {
+	// Documents written before backorders existed have no mode
+	backorderMode := domain.BackorderMode(d.BackorderMode)
+	if backorderMode == "" {
+		backorderMode = domain.BackorderNone
+	}
+
+	return &domain.Product{
+		ID:              d.ID,
+		SKU:             d.SKU,
+		Name:            d.Name,
+		Description:     d.Description,
+		Price:           d.Price,
+		Stock:           d.Stock,
+		CategoryID:      d.CategoryID,
+		ReorderPoint:    d.ReorderPoint,
+		ReorderQuantity: d.ReorderQuantity,
+		BackorderMode:   backorderMode,
+		AvailableAt:     d.AvailableAt,
+		ArchivedAt:      d.ArchivedAt,
+		Version:         d.Version,
+		CreatedAt:       d.CreatedAt,
+		UpdatedAt:       d.UpdatedAt,
+	}
+}
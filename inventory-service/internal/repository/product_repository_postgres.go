@@ -0,0 +1,503 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type postgresProductRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresProductRepository(db *sql.DB) ProductRepository {
+	return &postgresProductRepository{db: db}
+}
+
+const productColumns = `id, sku, name, description, price, stock, category_id, reorder_point, reorder_quantity, backorder_mode, available_at, archived_at, version, created_at, updated_at`
+
+// productSearchVector must match the expression of products_search_idx
+// for the index to be used.
+const productSearchVector = `(setweight(to_tsvector('simple', name), 'A') || setweight(to_tsvector('simple', description), 'B'))`
+
+func (r *postgresProductRepository) Create(ctx context.Context, product *domain.Product) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if product.ID == "" {
+		product.ID = primitive.NewObjectID().Hex()
+	}
+
+	now := time.Now()
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		created, err := scanProduct(tx.QueryRowContext(ctx, `
+			INSERT INTO products (`+productColumns+`)
+			VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, 1, $13, $13)
+			RETURNING `+productColumns,
+			product.ID, product.SKU, product.Name, product.Description, product.Price, product.Stock, product.CategoryID,
+			product.ReorderPoint, product.ReorderQuantity, string(product.BackorderMode), product.AvailableAt, product.ArchivedAt, now,
+		))
+		if err != nil {
+			return err
+		}
+		return writeEventTx(ctx, tx, domain.EventProductCreated, created.ID, created)
+	})
+	if isUniqueViolation(err) {
+		return domain.ErrProductExists
+	}
+	if err != nil {
+		return err
+	}
+
+	product.Version = 1
+	return nil
+}
+
+func (r *postgresProductRepository) FindByID(ctx context.Context, id string) (*domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE id = $1`, id)
+	product, err := scanProduct(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrProductNotFound
+	}
+
+	return product, err
+}
+
+func (r *postgresProductRepository) FindBySKU(ctx context.Context, sku string) (*domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE sku = $1`, sku)
+	product, err := scanProduct(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrProductNotFound
+	}
+
+	return product, err
+}
+
+func (r *postgresProductRepository) Update(ctx context.Context, product *domain.Product) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		updated, err := scanProduct(tx.QueryRowContext(ctx, `
+			UPDATE products
+			SET sku = NULLIF($2, ''), name = $3, description = $4, price = $5, category_id = $6, reorder_point = $7, reorder_quantity = $8,
+				backorder_mode = $9, available_at = $10, updated_at = $11, version = version + 1
+			WHERE id = $1 AND version = $12
+			RETURNING `+productColumns,
+			product.ID, product.SKU, product.Name, product.Description, product.Price, product.CategoryID,
+			product.ReorderPoint, product.ReorderQuantity, string(product.BackorderMode), product.AvailableAt, time.Now(), product.Version,
+		))
+		if isUniqueViolation(err) {
+			return domain.ErrProductExists
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			var exists bool
+			if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)`, product.ID).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return domain.ErrProductNotFound
+			}
+			return domain.ErrVersionConflict
+		}
+		if err != nil {
+			return err
+		}
+
+		return writeEventTx(ctx, tx, domain.EventProductUpdated, updated.ID, updated)
+	})
+}
+
+func (r *postgresProductRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM products WHERE id = $1`, id)
+		if err != nil {
+			return err
+		}
+		if err := expectAffected(result, domain.ErrProductNotFound); err != nil {
+			return err
+		}
+
+		return writeEventTx(ctx, tx, domain.EventProductDeleted, id, domain.ProductDeletion{ID: id})
+	})
+}
+
+func (r *postgresProductRepository) Archive(ctx context.Context, id string, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		archived, err := scanProduct(tx.QueryRowContext(ctx, `
+			UPDATE products SET archived_at = $2, updated_at = $2
+			WHERE id = $1 AND archived_at IS NULL
+			RETURNING `+productColumns,
+			id, at,
+		))
+		if errors.Is(err, sql.ErrNoRows) {
+			// Already archived, or missing
+			var exists bool
+			if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)`, id).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return domain.ErrProductNotFound
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return writeEventTx(ctx, tx, domain.EventProductArchived, id, archived)
+	})
+}
+
+func (r *postgresProductRepository) Restore(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		restored, err := scanProduct(tx.QueryRowContext(ctx, `
+			UPDATE products SET archived_at = NULL, updated_at = $2
+			WHERE id = $1 AND archived_at IS NOT NULL
+			RETURNING `+productColumns,
+			id, time.Now(),
+		))
+		if errors.Is(err, sql.ErrNoRows) {
+			var exists bool
+			if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)`, id).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return domain.ErrProductNotFound
+			}
+			return domain.ErrProductNotArchived
+		}
+		if err != nil {
+			return err
+		}
+
+		return writeEventTx(ctx, tx, domain.EventProductRestored, id, restored)
+	})
+}
+
+func (r *postgresProductRepository) ListArchivedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+productColumns+` FROM products
+		WHERE archived_at IS NOT NULL AND archived_at < $1
+		ORDER BY archived_at, id
+		LIMIT $2`,
+		cutoff, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []*domain.Product{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+
+	return products, rows.Err()
+}
+
+func (r *postgresProductRepository) List(ctx context.Context, categoryIDs []string, includeArchived bool, after *domain.PageCursor, limit int) ([]*domain.Product, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// An empty or NULL array matches every category
+	conditions := []string{`(coalesce(cardinality($1::text[]), 0) = 0 OR category_id = ANY($1))`}
+	if !includeArchived {
+		conditions = append(conditions, `archived_at IS NULL`)
+	}
+	categories := pq.Array(categoryIDs)
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT count(*) FROM products`+whereClause(conditions), categories).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The row comparison walks the (created_at, id) indexes from the
+	// cursor instead of skipping over earlier pages
+	args := []interface{}{categories, limit}
+	if after != nil {
+		conditions = append(conditions, `(created_at, id) < ($3, $4)`)
+		args = append(args, after.CreatedAt, after.ID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+productColumns+` FROM products`+whereClause(conditions)+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`,
+		args...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	products := []*domain.Product{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		products = append(products, product)
+	}
+
+	return products, total, rows.Err()
+}
+
+func (r *postgresProductRepository) Search(ctx context.Context, search domain.ProductSearch) (*domain.ProductSearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var (
+		args       []interface{}
+		conditions []string
+		rank       string
+	)
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if terms := searchTerms(search.Query); len(terms) > 0 {
+		addCondition(productSearchVector+` @@ plainto_tsquery('simple', $%d)`, strings.Join(terms, " "))
+		rank = fmt.Sprintf(`ts_rank(%s, plainto_tsquery('simple', $%d))`, productSearchVector, len(args))
+	}
+	if search.MinPrice > 0 {
+		addCondition(`price >= $%d`, search.MinPrice)
+	}
+	if search.MaxPrice > 0 {
+		addCondition(`price <= $%d`, search.MaxPrice)
+	}
+	if search.InStockOnly {
+		conditions = append(conditions, `stock > 0`)
+	}
+	if !search.IncludeArchived {
+		conditions = append(conditions, `archived_at IS NULL`)
+	}
+
+	// Facets are counted before the category filter is added
+	facets, err := r.searchFacets(ctx, whereClause(conditions), args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(search.CategoryIDs) > 0 {
+		addCondition(`category_id = ANY($%d)`, pq.Array(search.CategoryIDs))
+	}
+	where := whereClause(conditions)
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT count(*) FROM products`+where, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	direction := "ASC"
+	if search.Order == domain.SortDescending {
+		direction = "DESC"
+	}
+	var order string
+	switch search.SortBy {
+	case domain.ProductSortRelevance:
+		if rank != "" {
+			order = rank + " " + direction + ", "
+		}
+	case domain.ProductSortPrice:
+		order = "price " + direction + ", "
+	case domain.ProductSortName:
+		order = "lower(name) " + direction + ", "
+	case domain.ProductSortCreatedAt:
+		order = "created_at " + direction + ", "
+	}
+
+	args = append(args, search.Limit, (search.Page-1)*search.Limit)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s FROM products%s
+		ORDER BY %screated_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`,
+		productColumns, where, order, len(args)-1, len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []*domain.Product{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &domain.ProductSearchResult{
+		Products: products,
+		Total:    total,
+		Facets:   facets,
+	}, nil
+}
+
+func (r *postgresProductRepository) searchFacets(ctx context.Context, where string, args []interface{}) ([]domain.CategoryFacet, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT category_id, count(*) FROM products`+where+`
+		GROUP BY category_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	facets := []domain.CategoryFacet{}
+	for rows.Next() {
+		var facet domain.CategoryFacet
+		if err := rows.Scan(&facet.CategoryID, &facet.Count); err != nil {
+			return nil, err
+		}
+		facets = append(facets, facet)
+	}
+	sortFacets(facets)
+
+	return facets, rows.Err()
+}
+
+func (r *postgresProductRepository) AdjustStock(ctx context.Context, id string, delta int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var stock int
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		// The stock guard in the WHERE clause keeps concurrent decrements
+		// from overselling
+		err := tx.QueryRowContext(ctx, `
+			UPDATE products SET stock = stock + $2, updated_at = $3
+			WHERE id = $1 AND stock + $2 >= 0
+			RETURNING stock`,
+			id, delta, time.Now(),
+		).Scan(&stock)
+		if errors.Is(err, sql.ErrNoRows) {
+			var exists bool
+			if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)`, id).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return domain.ErrProductNotFound
+			}
+			return domain.ErrInsufficientStock
+		}
+		if err != nil {
+			return err
+		}
+
+		change := domain.StockChange{ProductID: id, Delta: delta, Stock: stock}
+		return writeEventTx(ctx, tx, domain.EventStockAdjusted, id, change)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+func (r *postgresProductRepository) ListReorderable(ctx context.Context) ([]*domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+productColumns+` FROM products WHERE reorder_point > 0 AND archived_at IS NULL ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []*domain.Product{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+
+	return products, rows.Err()
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProduct(row rowScanner) (*domain.Product, error) {
+	var (
+		p             domain.Product
+		sku           sql.NullString
+		backorderMode string
+		availableAt   sql.NullTime
+		archivedAt    sql.NullTime
+	)
+	err := row.Scan(&p.ID, &sku, &p.Name, &p.Description, &p.Price, &p.Stock, &p.CategoryID, &p.ReorderPoint, &p.ReorderQuantity,
+		&backorderMode, &availableAt, &archivedAt, &p.Version, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	p.SKU = sku.String
+	p.BackorderMode = domain.BackorderMode(backorderMode)
+	if availableAt.Valid {
+		p.AvailableAt = &availableAt.Time
+	}
+	if archivedAt.Valid {
+		p.ArchivedAt = &archivedAt.Time
+	}
+	return &p, nil
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// expectAffected turns an update that touched no rows into notFound.
+func expectAffected(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
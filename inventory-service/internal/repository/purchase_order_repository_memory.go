@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type memoryPurchaseOrderRepository struct {
+	mu     sync.RWMutex
+	orders map[string]domain.PurchaseOrder
+}
+
+// NewMemoryPurchaseOrderRepository returns a thread-safe
+// PurchaseOrderRepository that keeps everything in process memory.
+func NewMemoryPurchaseOrderRepository() PurchaseOrderRepository {
+	return &memoryPurchaseOrderRepository{
+		orders: map[string]domain.PurchaseOrder{},
+	}
+}
+
+func (r *memoryPurchaseOrderRepository) Create(ctx context.Context, po *domain.PurchaseOrder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if po.ID == "" {
+		po.ID = primitive.NewObjectID().Hex()
+	}
+	if _, ok := r.orders[po.ID]; ok {
+		return domain.ErrPurchaseOrderExists
+	}
+
+	now := time.Now()
+	po.Version = 1
+	po.CreatedAt = now
+	po.UpdatedAt = now
+	r.orders[po.ID] = copyPurchaseOrder(*po)
+
+	return nil
+}
+
+func (r *memoryPurchaseOrderRepository) FindByID(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	po, ok := r.orders[id]
+	if !ok {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+
+	po = copyPurchaseOrder(po)
+	return &po, nil
+}
+
+func (r *memoryPurchaseOrderRepository) Update(ctx context.Context, po *domain.PurchaseOrder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.orders[po.ID]
+	if !ok {
+		return domain.ErrPurchaseOrderNotFound
+	}
+	if existing.Version != po.Version {
+		return domain.ErrVersionConflict
+	}
+
+	updated := copyPurchaseOrder(*po)
+	existing.Lines = updated.Lines
+	existing.Status = po.Status
+	existing.Note = po.Note
+	existing.Version++
+	existing.UpdatedAt = time.Now()
+	r.orders[po.ID] = existing
+
+	return nil
+}
+
+func (r *memoryPurchaseOrderRepository) List(ctx context.Context, filter domain.PurchaseOrderFilter, after *domain.PageCursor, limit int) ([]*domain.PurchaseOrder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*domain.PurchaseOrder
+	for _, po := range r.orders {
+		if filter.SupplierID != "" && po.SupplierID != filter.SupplierID {
+			continue
+		}
+		if filter.Status != "" && po.Status != filter.Status {
+			continue
+		}
+		po := copyPurchaseOrder(po)
+		matched = append(matched, &po)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return newerPurchaseOrder(matched[i], matched[j])
+	})
+
+	if after != nil {
+		cursor := &domain.PurchaseOrder{ID: after.ID, CreatedAt: after.CreatedAt}
+		start := sort.Search(len(matched), func(i int) bool {
+			return newerPurchaseOrder(cursor, matched[i])
+		})
+		matched = matched[start:]
+	}
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	return append([]*domain.PurchaseOrder{}, matched...), nil
+}
+
+// newerPurchaseOrder orders purchase orders by creation time, newest
+// first, breaking ties on ID so the order is stable.
+func newerPurchaseOrder(a, b *domain.PurchaseOrder) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+// copyPurchaseOrder returns po with its own copy of the lines, so stored
+// orders never share memory with callers.
+func copyPurchaseOrder(po domain.PurchaseOrder) domain.PurchaseOrder {
+	po.Lines = append([]domain.PurchaseOrderLine(nil), po.Lines...)
+	return po
+}
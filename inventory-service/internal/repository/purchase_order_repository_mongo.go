@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"inventory-service/internal/domain"
+)
+
+type purchaseOrderRepository struct {
+	collection *mongo.Collection
+}
+
+type purchaseOrderLineDocument struct {
+	ProductID  string  `bson:"product_id"`
+	VariantSKU string  `bson:"variant_sku,omitempty"`
+	Quantity   int     `bson:"quantity"`
+	Received   int     `bson:"received"`
+	UnitCost   float64 `bson:"unit_cost"`
+}
+
+type purchaseOrderDocument struct {
+	ID          string                      `bson:"_id"`
+	SupplierID  string                      `bson:"supplier_id"`
+	WarehouseID string                      `bson:"warehouse_id"`
+	Lines       []purchaseOrderLineDocument `bson:"lines"`
+	Status      string                      `bson:"status"`
+	Note        string                      `bson:"note"`
+	Version     int64                       `bson:"version"`
+	CreatedAt   time.Time                   `bson:"created_at"`
+	UpdatedAt   time.Time                   `bson:"updated_at"`
+}
+
+func NewMongoPurchaseOrderRepository(db *mongo.Database) PurchaseOrderRepository {
+	return &purchaseOrderRepository{
+		collection: db.Collection("purchase_orders"),
+	}
+}
+
+func (r *purchaseOrderRepository) Create(ctx context.Context, po *domain.PurchaseOrder) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if po.ID == "" {
+		po.ID = primitive.NewObjectID().Hex()
+	}
+
+	now := time.Now()
+	_, err := r.collection.InsertOne(ctx, purchaseOrderDocument{
+		ID:          po.ID,
+		SupplierID:  po.SupplierID,
+		WarehouseID: po.WarehouseID,
+		Lines:       toPurchaseOrderLineDocuments(po.Lines),
+		Status:      string(po.Status),
+		Note:        po.Note,
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrPurchaseOrderExists
+	}
+	if err != nil {
+		return err
+	}
+
+	po.Version = 1
+	po.CreatedAt = now
+	po.UpdatedAt = now
+	return nil
+}
+
+func (r *purchaseOrderRepository) FindByID(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var result purchaseOrderDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *purchaseOrderRepository) Update(ctx context.Context, po *domain.PurchaseOrder) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": po.ID, "version": po.Version},
+		bson.M{
+			"$set": bson.M{
+				"lines":      toPurchaseOrderLineDocuments(po.Lines),
+				"status":     string(po.Status),
+				"note":       po.Note,
+				"updated_at": time.Now(),
+			},
+			"$inc": bson.M{"version": 1},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		count, err := r.collection.CountDocuments(ctx, bson.M{"_id": po.ID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return domain.ErrPurchaseOrderNotFound
+		}
+		return domain.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (r *purchaseOrderRepository) List(ctx context.Context, filter domain.PurchaseOrderFilter, after *domain.PageCursor, limit int) ([]*domain.PurchaseOrder, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if filter.SupplierID != "" {
+		query["supplier_id"] = filter.SupplierID
+	}
+	if filter.Status != "" {
+		query["status"] = string(filter.Status)
+	}
+	if after != nil {
+		query["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": after.CreatedAt}},
+			bson.M{"created_at": after.CreatedAt, "_id": bson.M{"$lt": after.ID}},
+		}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	orders := []*domain.PurchaseOrder{}
+	for cursor.Next(ctx) {
+		var result purchaseOrderDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		orders = append(orders, result.toDomain())
+	}
+
+	return orders, cursor.Err()
+}
+
+func toPurchaseOrderLineDocuments(lines []domain.PurchaseOrderLine) []purchaseOrderLineDocument {
+	docs := make([]purchaseOrderLineDocument, len(lines))
+	for i, line := range lines {
+		docs[i] = purchaseOrderLineDocument{
+			ProductID:  line.ProductID,
+			VariantSKU: line.VariantSKU,
+			Quantity:   line.Quantity,
+			Received:   line.Received,
+			UnitCost:   line.UnitCost,
+		}
+	}
+	return docs
+}
+
+func (d *purchaseOrderDocument) toDomain() *domain.PurchaseOrder {
+	lines := make([]domain.PurchaseOrderLine, len(d.Lines))
+	for i, line := range d.Lines {
+		lines[i] = domain.PurchaseOrderLine{
+			ProductID:  line.ProductID,
+			VariantSKU: line.VariantSKU,
+			Quantity:   line.Quantity,
+			Received:   line.Received,
+			UnitCost:   line.UnitCost,
+		}
+	}
+
+	return &domain.PurchaseOrder{
+		ID:          d.ID,
+		SupplierID:  d.SupplierID,
+		WarehouseID: d.WarehouseID,
+		Lines:       lines,
+		Status:      domain.PurchaseOrderStatus(d.Status),
+		Note:        d.Note,
+		Version:     d.Version,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type postgresPurchaseOrderRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresPurchaseOrderRepository(db *sql.DB) PurchaseOrderRepository {
+	return &postgresPurchaseOrderRepository{db: db}
+}
+
+const purchaseOrderColumns = `id, supplier_id, warehouse_id, lines, status, note, version, created_at, updated_at`
+
+func (r *postgresPurchaseOrderRepository) Create(ctx context.Context, po *domain.PurchaseOrder) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if po.ID == "" {
+		po.ID = primitive.NewObjectID().Hex()
+	}
+	lines, err := json.Marshal(po.Lines)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO purchase_orders (`+purchaseOrderColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, 1, $7, $7)`,
+		po.ID, po.SupplierID, po.WarehouseID, lines, string(po.Status), po.Note, now,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrPurchaseOrderExists
+	}
+	if err != nil {
+		return err
+	}
+
+	po.Version = 1
+	po.CreatedAt = now
+	po.UpdatedAt = now
+	return nil
+}
+
+func (r *postgresPurchaseOrderRepository) FindByID(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+purchaseOrderColumns+` FROM purchase_orders WHERE id = $1`, id)
+	po, err := scanPurchaseOrder(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrPurchaseOrderNotFound
+	}
+
+	return po, err
+}
+
+func (r *postgresPurchaseOrderRepository) Update(ctx context.Context, po *domain.PurchaseOrder) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	lines, err := json.Marshal(po.Lines)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE purchase_orders
+		SET lines = $2, status = $3, note = $4, updated_at = $5, version = version + 1
+		WHERE id = $1 AND version = $6`,
+		po.ID, lines, string(po.Status), po.Note, time.Now(), po.Version,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		var exists bool
+		if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM purchase_orders WHERE id = $1)`, po.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrPurchaseOrderNotFound
+		}
+		return domain.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (r *postgresPurchaseOrderRepository) List(ctx context.Context, filter domain.PurchaseOrderFilter, after *domain.PageCursor, limit int) ([]*domain.PurchaseOrder, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.SupplierID != "" {
+		where(`supplier_id = $%d`, filter.SupplierID)
+	}
+	if filter.Status != "" {
+		where(`status = $%d`, string(filter.Status))
+	}
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		conditions = append(conditions, fmt.Sprintf(`(created_at, id) < ($%d, $%d)`, len(args)-1, len(args)))
+	}
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+purchaseOrderColumns+` FROM purchase_orders`+whereClause(conditions)+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT $`+fmt.Sprint(len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []*domain.PurchaseOrder{}
+	for rows.Next() {
+		po, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, po)
+	}
+
+	return orders, rows.Err()
+}
+
+func scanPurchaseOrder(row rowScanner) (*domain.PurchaseOrder, error) {
+	var (
+		po     domain.PurchaseOrder
+		lines  []byte
+		status string
+	)
+	err := row.Scan(&po.ID, &po.SupplierID, &po.WarehouseID, &lines, &status, &po.Note, &po.Version, &po.CreatedAt, &po.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(lines, &po.Lines); err != nil {
+		return nil, err
+	}
+	po.Status = domain.PurchaseOrderStatus(status)
+
+	return &po, nil
+}
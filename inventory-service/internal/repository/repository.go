@@ -1,11 +1,191 @@
 package repository
 
-import "inventory-service/internal/domain"
+import (
+	"context"
+	"time"
 
+	"inventory-service/internal/domain"
+)
+
+// ProductRepository stores products. Every change it makes is written
+// together with its event in the outbox.
 type ProductRepository interface {
-	Create(product *domain.Product) error
-	FindByID(id string) (*domain.Product, error)
-	Update(product *domain.Product) error
-	Delete(id string) error
-	List(page, limit int, categoryID string) ([]*domain.Product, int, error)
+	// Create stores a product at version 1. It fails with
+	// ErrProductExists when the ID or the SKU is taken.
+	Create(ctx context.Context, product *domain.Product) error
+	FindByID(ctx context.Context, id string) (*domain.Product, error)
+	// FindBySKU looks a product up by its SKU, which is optional but
+	// unique among products when set.
+	FindBySKU(ctx context.Context, sku string) (*domain.Product, error)
+	// Update writes every field but Stock, which only changes through
+	// AdjustStock, and bumps the version. It only applies while the stored
+	// version equals product.Version and fails with ErrVersionConflict
+	// otherwise.
+	Update(ctx context.Context, product *domain.Product) error
+	// Delete removes a product for good; deleting a product through the
+	// API archives it instead.
+	Delete(ctx context.Context, id string) error
+	// Archive sets the archive time of a product that is not archived yet
+	// and leaves an archived one as it is.
+	Archive(ctx context.Context, id string, at time.Time) error
+	// Restore clears the archive time of an archived product and fails
+	// with ErrProductNotArchived for any other.
+	Restore(ctx context.Context, id string) error
+	// ListArchivedBefore returns up to limit products archived before
+	// cutoff, longest archived first.
+	ListArchivedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Product, error)
+	// List returns up to limit products newest first, starting after the
+	// cursor when one is given, together with the total matching count.
+	// An empty categoryIDs matches every product. Archived products are
+	// left out unless includeArchived is set.
+	List(ctx context.Context, categoryIDs []string, includeArchived bool, after *domain.PageCursor, limit int) ([]*domain.Product, int, error)
+	// Search expects a search already normalized by the caller: SortBy and
+	// Order set and Page and Limit positive.
+	Search(ctx context.Context, search domain.ProductSearch) (*domain.ProductSearchResult, error)
+	// AdjustStock atomically adds delta to the stock of a product and
+	// returns the new stock. It fails with ErrInsufficientStock rather
+	// than drive the stock below zero.
+	AdjustStock(ctx context.Context, id string, delta int) (int, error)
+	// ListReorderable returns every product with a reorder point set that
+	// is not archived.
+	ListReorderable(ctx context.Context) ([]*domain.Product, error)
+}
+
+// CategoryRepository stores the category tree. Names are unique among
+// siblings, compared case-insensitively.
+type CategoryRepository interface {
+	Create(ctx context.Context, category *domain.Category) error
+	FindByID(ctx context.Context, id string) (*domain.Category, error)
+	Update(ctx context.Context, category *domain.Category) error
+	Delete(ctx context.Context, id string) error
+	// List returns every category ordered by name. Category trees are
+	// small enough to be walked in memory.
+	List(ctx context.Context) ([]*domain.Category, error)
+}
+
+// VariantRepository stores product variants keyed by their SKU, which is
+// unique across all products.
+type VariantRepository interface {
+	Create(ctx context.Context, variant *domain.Variant) error
+	FindBySKU(ctx context.Context, sku string) (*domain.Variant, error)
+	// Update writes every field but Stock, which only changes through
+	// AdjustStock.
+	Update(ctx context.Context, variant *domain.Variant) error
+	Delete(ctx context.Context, sku string) error
+	DeleteByProduct(ctx context.Context, productID string) error
+	// ListByProduct returns the variants of a product ordered by SKU.
+	ListByProduct(ctx context.Context, productID string) ([]*domain.Variant, error)
+	// AdjustStock atomically adds delta to the stock of a variant and
+	// returns the new stock, failing with ErrInsufficientStock rather
+	// than going below zero. The change is written together with an
+	// EventStockAdjusted in the outbox.
+	AdjustStock(ctx context.Context, sku string, delta int) (int, error)
+}
+
+// WarehouseRepository stores the warehouses stock is kept at.
+type WarehouseRepository interface {
+	Create(ctx context.Context, warehouse *domain.Warehouse) error
+	FindByID(ctx context.Context, id string) (*domain.Warehouse, error)
+	Update(ctx context.Context, warehouse *domain.Warehouse) error
+	Delete(ctx context.Context, id string) error
+	// List returns every warehouse ordered by priority, then ID.
+	List(ctx context.Context) ([]*domain.Warehouse, error)
+}
+
+// StockLevelRepository stores per-warehouse quantities keyed by warehouse,
+// product and variant SKU, which is empty for products without variants.
+type StockLevelRepository interface {
+	// ListByProduct returns the levels of a product and its variants
+	// ordered by warehouse ID, then SKU.
+	ListByProduct(ctx context.Context, productID string) ([]*domain.StockLevel, error)
+	// ListByWarehouse returns the levels held at a warehouse ordered by
+	// product ID, then SKU.
+	ListByWarehouse(ctx context.Context, warehouseID string) ([]*domain.StockLevel, error)
+	// Adjust adds delta to a level, creating the level when it is missing.
+	// It fails with ErrInsufficientStock rather than drive the quantity
+	// below zero. The change is written together with an
+	// EventStockAdjusted in the outbox.
+	Adjust(ctx context.Context, warehouseID, productID, variantSKU string, delta int) error
+	DeleteByProduct(ctx context.Context, productID string) error
+	DeleteByVariant(ctx context.Context, sku string) error
+	DeleteByWarehouse(ctx context.Context, warehouseID string) error
+}
+
+// StockMovementRepository is the append-only stock ledger. Entries are
+// never changed or removed.
+type StockMovementRepository interface {
+	// Append stores a movement, assigning its ID and creation time.
+	Append(ctx context.Context, movement *domain.StockMovement) error
+	// List returns up to limit movements matching filter newest first,
+	// starting after the cursor when one is given.
+	List(ctx context.Context, filter domain.MovementFilter, after *domain.PageCursor, limit int) ([]*domain.StockMovement, error)
+	// Balances sums the ledger of a product per variant and warehouse.
+	Balances(ctx context.Context, productID string) ([]domain.StockBalance, error)
+}
+
+type ReservationRepository interface {
+	Create(ctx context.Context, reservation *domain.Reservation) error
+	FindByOrderID(ctx context.Context, orderID string) (*domain.Reservation, error)
+	UpdateStatus(ctx context.Context, orderID string, from, to domain.ReservationStatus) error
+	ListExpired(ctx context.Context, before time.Time, limit int) ([]*domain.Reservation, error)
+	// ListBackordered returns up to limit backordered reservations, oldest
+	// first.
+	ListBackordered(ctx context.Context, limit int) ([]*domain.Reservation, error)
+	// Fulfil stores the stock allocated to a backordered reservation and
+	// makes it active until expiresAt. It fails with ErrReservationClosed
+	// once the reservation is no longer backordered.
+	Fulfil(ctx context.Context, orderID string, items []domain.ReservationItem, expiresAt time.Time) error
+	// AddReturn records that a customer return was taken back against a
+	// committed reservation. It fails with ErrReturnExists when the return
+	// is already recorded, with ErrReservationClosed when the reservation
+	// is not committed and with ErrInvalidReturn when earlier returns and
+	// this one together send back more than was sold.
+	AddReturn(ctx context.Context, orderID string, ret domain.ReservationReturn) error
+}
+
+// SupplierRepository stores the suppliers purchase orders are placed with.
+type SupplierRepository interface {
+	Create(ctx context.Context, supplier *domain.Supplier) error
+	FindByID(ctx context.Context, id string) (*domain.Supplier, error)
+	Update(ctx context.Context, supplier *domain.Supplier) error
+	// List returns every supplier ordered by name, then ID.
+	List(ctx context.Context) ([]*domain.Supplier, error)
+}
+
+// PurchaseOrderRepository stores purchase orders together with their
+// lines.
+type PurchaseOrderRepository interface {
+	// Create stores a purchase order at version 1.
+	Create(ctx context.Context, po *domain.PurchaseOrder) error
+	FindByID(ctx context.Context, id string) (*domain.PurchaseOrder, error)
+	// Update writes the lines, status and note of a purchase order and
+	// bumps its version. It only applies while the stored version equals
+	// po.Version and fails with ErrVersionConflict otherwise.
+	Update(ctx context.Context, po *domain.PurchaseOrder) error
+	// List returns up to limit purchase orders matching filter, newest
+	// first, starting after the cursor when one is given.
+	List(ctx context.Context, filter domain.PurchaseOrderFilter, after *domain.PageCursor, limit int) ([]*domain.PurchaseOrder, error)
+}
+
+// OutboxRepository reads the domain events the other repositories write
+// together with the changes they describe, for the relay to publish.
+type OutboxRepository interface {
+	// ListPending returns up to limit unpublished events, oldest first.
+	ListPending(ctx context.Context, limit int) ([]*domain.Event, error)
+	// Delete removes published events; unknown IDs are ignored.
+	Delete(ctx context.Context, ids []string) error
+}
+
+// Repositories bundles every repository of one storage backend.
+type Repositories struct {
+	Products       ProductRepository
+	Categories     CategoryRepository
+	Variants       VariantRepository
+	Warehouses     WarehouseRepository
+	StockLevels    StockLevelRepository
+	Movements      StockMovementRepository
+	Reservations   ReservationRepository
+	Suppliers      SupplierRepository
+	PurchaseOrders PurchaseOrderRepository
+	Outbox         OutboxRepository
 }
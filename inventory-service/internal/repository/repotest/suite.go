@@ -0,0 +1,1373 @@
+// Package repotest is the conformance suite every storage backend of
+// inventory-service must pass. A backend's test calls the Test functions
+// with a constructor returning a fresh, empty repository set.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"inventory-service/internal/domain"
+	"inventory-service/internal/repository"
+)
+
+// Factory returns repositories backed by empty storage.
+type Factory func(t *testing.T) repository.Repositories
+
+// TestRepositories runs the whole suite.
+func TestRepositories(t *testing.T, newRepos Factory) {
+	t.Run("Products", func(t *testing.T) { TestProductRepository(t, newRepos) })
+	t.Run("Categories", func(t *testing.T) { TestCategoryRepository(t, newRepos) })
+	t.Run("Variants", func(t *testing.T) { TestVariantRepository(t, newRepos) })
+	t.Run("Warehouses", func(t *testing.T) { TestWarehouseRepository(t, newRepos) })
+	t.Run("StockLevels", func(t *testing.T) { TestStockLevelRepository(t, newRepos) })
+	t.Run("StockMovements", func(t *testing.T) { TestStockMovementRepository(t, newRepos) })
+	t.Run("Reservations", func(t *testing.T) { TestReservationRepository(t, newRepos) })
+	t.Run("Suppliers", func(t *testing.T) { TestSupplierRepository(t, newRepos) })
+	t.Run("PurchaseOrders", func(t *testing.T) { TestPurchaseOrderRepository(t, newRepos) })
+	t.Run("Outbox", func(t *testing.T) { TestOutboxRepository(t, newRepos) })
+}
+
+func TestProductRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo := newRepos(t).Products
+		product := &domain.Product{ID: "p1", Name: "Mug", Description: "Blue", Price: 9.5, Stock: 3, CategoryID: "kitchen"}
+		mustCreate(t, repo, product)
+
+		got, err := repo.FindByID(ctx, "p1")
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Name != "Mug" || got.Description != "Blue" || got.Price != 9.5 || got.Stock != 3 || got.CategoryID != "kitchen" {
+			t.Fatalf("FindByID returned %+v", got)
+		}
+		if got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+			t.Fatalf("timestamps not set: %+v", got)
+		}
+		if product.Version != 1 || got.Version != 1 {
+			t.Fatalf("Create set version %d, stored %d, want 1", product.Version, got.Version)
+		}
+	})
+
+	t.Run("CreateGeneratesID", func(t *testing.T) {
+		repo := newRepos(t).Products
+		product := &domain.Product{Name: "Plate", Price: 4}
+		mustCreate(t, repo, product)
+		if product.ID == "" {
+			t.Fatal("Create did not assign an ID")
+		}
+	})
+
+	t.Run("FindMissing", func(t *testing.T) {
+		repo := newRepos(t).Products
+		if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, domain.ErrProductNotFound) {
+			t.Fatalf("FindByID(missing) = %v, want ErrProductNotFound", err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepos(t).Products
+		mustCreate(t, repo, &domain.Product{ID: "p1", Name: "Mug", Price: 9.5, Stock: 3})
+
+		if err := repo.Update(ctx, &domain.Product{ID: "p1", Name: "Cup", Price: 7, Stock: 5, CategoryID: "c", Version: 1}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, _ := repo.FindByID(ctx, "p1")
+		// Stock only moves through AdjustStock
+		if got.Name != "Cup" || got.Price != 7 || got.Stock != 3 || got.CategoryID != "c" || got.Version != 2 {
+			t.Fatalf("after Update got %+v", got)
+		}
+
+		// A stale version must not overwrite the newer update
+		if err := repo.Update(ctx, &domain.Product{ID: "p1", Name: "Bowl", Price: 1, Version: 1}); !errors.Is(err, domain.ErrVersionConflict) {
+			t.Fatalf("Update(stale) = %v, want ErrVersionConflict", err)
+		}
+		if got, _ := repo.FindByID(ctx, "p1"); got.Name != "Cup" || got.Version != 2 {
+			t.Fatalf("stale Update changed the product: %+v", got)
+		}
+
+		if err := repo.Update(ctx, &domain.Product{ID: "missing", Name: "x", Price: 1}); !errors.Is(err, domain.ErrProductNotFound) {
+			t.Fatalf("Update(missing) = %v, want ErrProductNotFound", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepos(t).Products
+		mustCreate(t, repo, &domain.Product{ID: "p1", Name: "Mug", Price: 9.5})
+
+		if err := repo.Delete(ctx, "p1"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindByID(ctx, "p1"); !errors.Is(err, domain.ErrProductNotFound) {
+			t.Fatalf("FindByID after Delete = %v, want ErrProductNotFound", err)
+		}
+		if err := repo.Delete(ctx, "p1"); !errors.Is(err, domain.ErrProductNotFound) {
+			t.Fatalf("second Delete = %v, want ErrProductNotFound", err)
+		}
+	})
+
+	t.Run("ListNewestFirstWithCursor", func(t *testing.T) {
+		repo := newRepos(t).Products
+		for _, id := range []string{"a", "b", "c", "d", "e"} {
+			mustCreate(t, repo, &domain.Product{ID: id, Name: id, Price: 1})
+			// Keep creation times distinct so the order is well defined
+			time.Sleep(2 * time.Millisecond)
+		}
+
+		page1, total, err := repo.List(ctx, nil, false, nil, 2)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 5 {
+			t.Fatalf("total = %d, want 5", total)
+		}
+		assertIDs(t, page1, "e", "d")
+
+		// Rows inserted after the first page do not shift later pages
+		mustCreate(t, repo, &domain.Product{ID: "f", Name: "f", Price: 1})
+
+		page2, total, err := repo.List(ctx, nil, false, domain.ProductCursor(page1[1]), 2)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 6 {
+			t.Fatalf("total = %d, want 6", total)
+		}
+		assertIDs(t, page2, "c", "b")
+
+		page3, _, err := repo.List(ctx, nil, false, domain.ProductCursor(page2[1]), 2)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertIDs(t, page3, "a")
+
+		beyond, _, err := repo.List(ctx, nil, false, domain.ProductCursor(page3[0]), 2)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertIDs(t, beyond)
+	})
+
+	t.Run("ListCursorBreaksTiesOnID", func(t *testing.T) {
+		repo := newRepos(t).Products
+		for _, id := range []string{"a", "b", "c"} {
+			mustCreate(t, repo, &domain.Product{ID: id, Name: id, Price: 1})
+		}
+		all, _, err := repo.List(ctx, nil, false, nil, 10)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+
+		// Creation times may collide here; the ID tiebreak keeps resuming exact
+		rest, _, err := repo.List(ctx, nil, false, &domain.PageCursor{CreatedAt: all[0].CreatedAt, ID: all[0].ID}, 10)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		want := make([]string, 0, len(all)-1)
+		for _, p := range all[1:] {
+			want = append(want, p.ID)
+		}
+		assertIDs(t, rest, want...)
+	})
+
+	t.Run("ListByCategory", func(t *testing.T) {
+		repo := newRepos(t).Products
+		mustCreate(t, repo, &domain.Product{ID: "a", Name: "a", Price: 1, CategoryID: "x"})
+		mustCreate(t, repo, &domain.Product{ID: "b", Name: "b", Price: 1, CategoryID: "y"})
+		mustCreate(t, repo, &domain.Product{ID: "c", Name: "c", Price: 1, CategoryID: "x"})
+
+		products, total, err := repo.List(ctx, []string{"x"}, false, nil, 10)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 2 || len(products) != 2 {
+			t.Fatalf("List(category x) returned %d of %d, want 2 of 2", len(products), total)
+		}
+		for _, p := range products {
+			if p.CategoryID != "x" {
+				t.Fatalf("List(category x) returned product in %q", p.CategoryID)
+			}
+		}
+
+		products, total, err = repo.List(ctx, []string{"x", "y"}, false, nil, 10)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if total != 3 || len(products) != 3 {
+			t.Fatalf("List(categories x, y) returned %d of %d, want 3 of 3", len(products), total)
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) { testProductSearch(t, newRepos) })
+
+	t.Run("StockCounters", func(t *testing.T) {
+		repo := newRepos(t).Products
+		mustCreate(t, repo, &domain.Product{ID: "p1", Name: "Mug", Price: 1, Stock: 3})
+
+		if stock, err := repo.AdjustStock(ctx, "p1", -2); err != nil || stock != 1 {
+			t.Fatalf("AdjustStock(-2) = %d, %v, want 1", stock, err)
+		}
+		if _, err := repo.AdjustStock(ctx, "p1", -2); !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Fatalf("AdjustStock past zero = %v, want ErrInsufficientStock", err)
+		}
+		if stock, err := repo.AdjustStock(ctx, "p1", 4); err != nil || stock != 5 {
+			t.Fatalf("AdjustStock(4) = %d, %v, want 5", stock, err)
+		}
+		if got, _ := repo.FindByID(ctx, "p1"); got.Stock != 5 {
+			t.Fatalf("stock = %d, want 5", got.Stock)
+		}
+
+		if _, err := repo.AdjustStock(ctx, "missing", -1); !errors.Is(err, domain.ErrProductNotFound) {
+			t.Fatalf("AdjustStock(missing, -1) = %v, want ErrProductNotFound", err)
+		}
+		if _, err := repo.AdjustStock(ctx, "missing", 1); !errors.Is(err, domain.ErrProductNotFound) {
+			t.Fatalf("AdjustStock(missing, 1) = %v, want ErrProductNotFound", err)
+		}
+	})
+
+	t.Run("ListReorderable", func(t *testing.T) {
+		repo := newRepos(t).Products
+		mustCreate(t, repo, &domain.Product{ID: "b", Name: "b", Price: 1, ReorderPoint: 5, ReorderQuantity: 20})
+		mustCreate(t, repo, &domain.Product{ID: "a", Name: "a", Price: 1, Stock: 9})
+		mustCreate(t, repo, &domain.Product{ID: "c", Name: "c", Price: 1})
+
+		if err := repo.Update(ctx, &domain.Product{ID: "c", Name: "c", Price: 1, ReorderPoint: 2, Version: 1}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		products, err := repo.ListReorderable(ctx)
+		if err != nil {
+			t.Fatalf("ListReorderable: %v", err)
+		}
+		assertIDs(t, products, "b", "c")
+		if products[0].ReorderPoint != 5 || products[0].ReorderQuantity != 20 || products[1].ReorderPoint != 2 {
+			t.Fatalf("ListReorderable returned %+v, %+v", products[0], products[1])
+		}
+	})
+
+	t.Run("BackorderFields", func(t *testing.T) {
+		repo := newRepos(t).Products
+		availableAt := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second)
+		mustCreate(t, repo, &domain.Product{ID: "p", Name: "p", Price: 1, BackorderMode: domain.BackorderPreorder, AvailableAt: &availableAt})
+
+		got, _ := repo.FindByID(ctx, "p")
+		if got.BackorderMode != domain.BackorderPreorder || got.AvailableAt == nil || !got.AvailableAt.Equal(availableAt) {
+			t.Fatalf("FindByID returned mode %q available at %v", got.BackorderMode, got.AvailableAt)
+		}
+
+		got.BackorderMode = domain.BackorderNone
+		got.AvailableAt = nil
+		if err := repo.Update(ctx, got); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, _ = repo.FindByID(ctx, "p")
+		if got.BackorderMode != domain.BackorderNone || got.AvailableAt != nil {
+			t.Fatalf("after Update got mode %q available at %v", got.BackorderMode, got.AvailableAt)
+		}
+	})
+
+	t.Run("ArchiveAndRestore", func(t *testing.T) {
+		repo := newRepos(t).Products
+		mustCreate(t, repo, &domain.Product{ID: "kept", Name: "Blue mug", Price: 1, CategoryID: "c", ReorderPoint: 5})
+		mustCreate(t, repo, &domain.Product{ID: "gone", Name: "Blue cup", Price: 1, CategoryID: "c", ReorderPoint: 5})
+
+		archivedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+		if err := repo.Archive(ctx, "gone", archivedAt); err != nil {
+			t.Fatalf("Archive: %v", err)
+		}
+		// Archiving again keeps the first archive time
+		if err := repo.Archive(ctx, "gone", time.Now()); err != nil {
+			t.Fatalf("second Archive: %v", err)
+		}
+		if err := repo.Archive(ctx, "missing", time.Now()); !errors.Is(err, domain.ErrProductNotFound) {
+			t.Fatalf("Archive of a missing product returned %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, "gone")
+		if err != nil || got.ArchivedAt == nil || !got.ArchivedAt.Equal(archivedAt) {
+			t.Fatalf("FindByID returned %+v, %v", got, err)
+		}
+
+		products, total, _ := repo.List(ctx, []string{"c"}, false, nil, 10)
+		if total != 1 || len(products) != 1 || products[0].ID != "kept" {
+			t.Fatalf("List left %d of %d products, want only kept", len(products), total)
+		}
+		if _, total, _ := repo.List(ctx, []string{"c"}, true, nil, 10); total != 2 {
+			t.Fatalf("List with archived counted %d products, want 2", total)
+		}
+		result, err := repo.Search(ctx, domain.ProductSearch{Query: "blue", SortBy: domain.ProductSortRelevance, Order: domain.SortDescending, Page: 1, Limit: 10})
+		if err != nil || result.Total != 1 {
+			t.Fatalf("Search matched %+v, %v; want only kept", result, err)
+		}
+		if reorderable, _ := repo.ListReorderable(ctx); len(reorderable) != 1 {
+			t.Fatalf("ListReorderable returned %d products, want 1", len(reorderable))
+		}
+
+		if old, _ := repo.ListArchivedBefore(ctx, archivedAt, 10); len(old) != 0 {
+			t.Fatalf("ListArchivedBefore the archive time returned %d products", len(old))
+		}
+		old, err := repo.ListArchivedBefore(ctx, time.Now(), 10)
+		if err != nil || len(old) != 1 || old[0].ID != "gone" {
+			t.Fatalf("ListArchivedBefore returned %v, %v", old, err)
+		}
+
+		if err := repo.Restore(ctx, "gone"); err != nil {
+			t.Fatalf("Restore: %v", err)
+		}
+		if err := repo.Restore(ctx, "gone"); !errors.Is(err, domain.ErrProductNotArchived) {
+			t.Fatalf("Restore of a live product returned %v", err)
+		}
+		if err := repo.Restore(ctx, "missing"); !errors.Is(err, domain.ErrProductNotFound) {
+			t.Fatalf("Restore of a missing product returned %v", err)
+		}
+		if _, total, _ := repo.List(ctx, []string{"c"}, false, nil, 10); total != 2 {
+			t.Fatalf("List after Restore counted %d products, want 2", total)
+		}
+	})
+
+	t.Run("SKUsAreUnique", func(t *testing.T) {
+		repo := newRepos(t).Products
+		mustCreate(t, repo, &domain.Product{ID: "a", SKU: "MUG-1", Name: "a", Price: 1})
+		// Any number of products may go without a SKU
+		mustCreate(t, repo, &domain.Product{ID: "b", Name: "b", Price: 1})
+		mustCreate(t, repo, &domain.Product{ID: "c", Name: "c", Price: 1})
+
+		if err := repo.Create(ctx, &domain.Product{ID: "d", SKU: "MUG-1", Name: "d", Price: 1}); !errors.Is(err, domain.ErrProductExists) {
+			t.Fatalf("Create with a taken SKU returned %v, want ErrProductExists", err)
+		}
+
+		got, err := repo.FindBySKU(ctx, "MUG-1")
+		if err != nil || got.ID != "a" {
+			t.Fatalf("FindBySKU returned %+v, %v", got, err)
+		}
+		if _, err := repo.FindBySKU(ctx, "MUG-2"); !errors.Is(err, domain.ErrProductNotFound) {
+			t.Fatalf("FindBySKU of an unknown SKU returned %v", err)
+		}
+
+		b, _ := repo.FindByID(ctx, "b")
+		b.SKU = "MUG-1"
+		if err := repo.Update(ctx, b); !errors.Is(err, domain.ErrProductExists) {
+			t.Fatalf("Update to a taken SKU returned %v, want ErrProductExists", err)
+		}
+		b.SKU = "MUG-2"
+		if err := repo.Update(ctx, b); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if got, err := repo.FindBySKU(ctx, "MUG-2"); err != nil || got.ID != "b" {
+			t.Fatalf("FindBySKU after Update returned %+v, %v", got, err)
+		}
+	})
+}
+
+func testProductSearch(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	seed := func(t *testing.T) repository.ProductRepository {
+		repo := newRepos(t).Products
+		for _, p := range []*domain.Product{
+			{ID: "mug", Name: "Blue mug", Description: "Ceramic coffee cup", Price: 12, Stock: 4, CategoryID: "kitchen"},
+			{ID: "cup", Name: "Travel cup", Description: "Steel cup for blue days", Price: 20, Stock: 0, CategoryID: "kitchen"},
+			{ID: "shirt", Name: "Blue shirt", Description: "Cotton", Price: 30, Stock: 2, CategoryID: "clothing"},
+			{ID: "sock", Name: "Red sock", Description: "Wool", Price: 5, Stock: 9, CategoryID: "clothing"},
+			{ID: "lamp", Name: "Desk lamp", Description: "Bright", Price: 45, Stock: 1, CategoryID: "home"},
+		} {
+			mustCreate(t, repo, p)
+			time.Sleep(2 * time.Millisecond)
+		}
+		return repo
+	}
+
+	search := func(t *testing.T, repo repository.ProductRepository, s domain.ProductSearch) *domain.ProductSearchResult {
+		t.Helper()
+		if s.SortBy == "" {
+			s.SortBy, s.Order = domain.ProductSortCreatedAt, domain.SortDescending
+		}
+		if s.Page == 0 {
+			s.Page, s.Limit = 1, 10
+		}
+		result, err := repo.Search(ctx, s)
+		if err != nil {
+			t.Fatalf("Search(%+v): %v", s, err)
+		}
+		return result
+	}
+
+	t.Run("NoFilters", func(t *testing.T) {
+		result := search(t, seed(t), domain.ProductSearch{})
+		if result.Total != 5 {
+			t.Fatalf("total = %d, want 5", result.Total)
+		}
+		assertIDs(t, result.Products, "lamp", "sock", "shirt", "cup", "mug")
+	})
+
+	t.Run("TextMatchesEveryWord", func(t *testing.T) {
+		repo := seed(t)
+		assertIDs(t, search(t, repo, domain.ProductSearch{Query: "blue"}).Products, "shirt", "cup", "mug")
+		assertIDs(t, search(t, repo, domain.ProductSearch{Query: "Blue CUP"}).Products, "cup", "mug")
+		assertIDs(t, search(t, repo, domain.ProductSearch{Query: "plate"}).Products)
+	})
+
+	t.Run("Relevance", func(t *testing.T) {
+		result := search(t, seed(t), domain.ProductSearch{
+			Query:  "cup",
+			SortBy: domain.ProductSortRelevance,
+			Order:  domain.SortDescending,
+		})
+		// The name match outranks the description-only match
+		assertIDs(t, result.Products, "cup", "mug")
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		repo := seed(t)
+		assertIDs(t, search(t, repo, domain.ProductSearch{MinPrice: 10, MaxPrice: 30}).Products, "shirt", "cup", "mug")
+		assertIDs(t, search(t, repo, domain.ProductSearch{InStockOnly: true, CategoryIDs: []string{"kitchen"}}).Products, "mug")
+		assertIDs(t, search(t, repo, domain.ProductSearch{CategoryIDs: []string{"home", "clothing"}}).Products, "lamp", "sock", "shirt")
+	})
+
+	t.Run("FacetsIgnoreCategoryFilter", func(t *testing.T) {
+		result := search(t, seed(t), domain.ProductSearch{Query: "blue", CategoryIDs: []string{"clothing"}})
+		assertIDs(t, result.Products, "shirt")
+		want := []domain.CategoryFacet{{CategoryID: "kitchen", Count: 2}, {CategoryID: "clothing", Count: 1}}
+		if len(result.Facets) != len(want) {
+			t.Fatalf("facets = %+v, want %+v", result.Facets, want)
+		}
+		for i := range want {
+			if result.Facets[i] != want[i] {
+				t.Fatalf("facets = %+v, want %+v", result.Facets, want)
+			}
+		}
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		repo := seed(t)
+		byPrice := domain.ProductSearch{SortBy: domain.ProductSortPrice, Order: domain.SortAscending}
+		assertIDs(t, search(t, repo, byPrice).Products, "sock", "mug", "cup", "shirt", "lamp")
+		byPrice.Order = domain.SortDescending
+		assertIDs(t, search(t, repo, byPrice).Products, "lamp", "shirt", "cup", "mug", "sock")
+		byName := domain.ProductSearch{SortBy: domain.ProductSortName, Order: domain.SortAscending}
+		assertIDs(t, search(t, repo, byName).Products, "mug", "shirt", "lamp", "sock", "cup")
+		oldest := domain.ProductSearch{SortBy: domain.ProductSortCreatedAt, Order: domain.SortAscending}
+		assertIDs(t, search(t, repo, oldest).Products, "mug", "cup", "shirt", "sock", "lamp")
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		result := search(t, seed(t), domain.ProductSearch{
+			SortBy: domain.ProductSortPrice,
+			Order:  domain.SortAscending,
+			Page:   2,
+			Limit:  2,
+		})
+		if result.Total != 5 {
+			t.Fatalf("total = %d, want 5", result.Total)
+		}
+		assertIDs(t, result.Products, "cup", "shirt")
+	})
+}
+
+func TestCategoryRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	mustCreateCategory := func(t *testing.T, repo repository.CategoryRepository, category *domain.Category) {
+		t.Helper()
+		if err := repo.Create(ctx, category); err != nil {
+			t.Fatalf("Create(%s): %v", category.ID, err)
+		}
+	}
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo := newRepos(t).Categories
+		mustCreateCategory(t, repo, &domain.Category{ID: "kitchen", Name: "Kitchen", Description: "Cookware"})
+		mustCreateCategory(t, repo, &domain.Category{ID: "mugs", Name: "Mugs", ParentID: "kitchen"})
+
+		got, err := repo.FindByID(ctx, "mugs")
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Name != "Mugs" || got.ParentID != "kitchen" || got.CreatedAt.IsZero() {
+			t.Fatalf("FindByID returned %+v", got)
+		}
+		if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, domain.ErrCategoryNotFound) {
+			t.Fatalf("FindByID(missing) = %v, want ErrCategoryNotFound", err)
+		}
+	})
+
+	t.Run("SiblingNamesAreUnique", func(t *testing.T) {
+		repo := newRepos(t).Categories
+		mustCreateCategory(t, repo, &domain.Category{ID: "a", Name: "Tools"})
+		mustCreateCategory(t, repo, &domain.Category{ID: "b", Name: "Garden"})
+		// The same name is fine under a different parent
+		mustCreateCategory(t, repo, &domain.Category{ID: "c", Name: "Tools", ParentID: "b"})
+
+		if err := repo.Create(ctx, &domain.Category{ID: "d", Name: "tools"}); !errors.Is(err, domain.ErrCategoryExists) {
+			t.Fatalf("Create(duplicate name) = %v, want ErrCategoryExists", err)
+		}
+		if err := repo.Update(ctx, &domain.Category{ID: "b", Name: "TOOLS"}); !errors.Is(err, domain.ErrCategoryExists) {
+			t.Fatalf("Update(duplicate name) = %v, want ErrCategoryExists", err)
+		}
+	})
+
+	t.Run("UpdateAndDelete", func(t *testing.T) {
+		repo := newRepos(t).Categories
+		mustCreateCategory(t, repo, &domain.Category{ID: "a", Name: "A"})
+		mustCreateCategory(t, repo, &domain.Category{ID: "b", Name: "B"})
+
+		if err := repo.Update(ctx, &domain.Category{ID: "b", Name: "Bee", Description: "d", ParentID: "a"}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, _ := repo.FindByID(ctx, "b")
+		if got.Name != "Bee" || got.Description != "d" || got.ParentID != "a" {
+			t.Fatalf("after Update got %+v", got)
+		}
+		if err := repo.Update(ctx, &domain.Category{ID: "missing", Name: "x"}); !errors.Is(err, domain.ErrCategoryNotFound) {
+			t.Fatalf("Update(missing) = %v, want ErrCategoryNotFound", err)
+		}
+
+		if err := repo.Delete(ctx, "b"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := repo.Delete(ctx, "b"); !errors.Is(err, domain.ErrCategoryNotFound) {
+			t.Fatalf("second Delete = %v, want ErrCategoryNotFound", err)
+		}
+	})
+
+	t.Run("ListByName", func(t *testing.T) {
+		repo := newRepos(t).Categories
+		mustCreateCategory(t, repo, &domain.Category{ID: "1", Name: "toys"})
+		mustCreateCategory(t, repo, &domain.Category{ID: "2", Name: "Books"})
+		mustCreateCategory(t, repo, &domain.Category{ID: "3", Name: "Garden"})
+
+		categories, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(categories) != 3 || categories[0].Name != "Books" || categories[1].Name != "Garden" || categories[2].Name != "toys" {
+			t.Fatalf("List returned %d categories out of name order", len(categories))
+		}
+	})
+}
+
+func TestVariantRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	mustCreateVariant := func(t *testing.T, repo repository.VariantRepository, variant *domain.Variant) {
+		t.Helper()
+		if err := repo.Create(ctx, variant); err != nil {
+			t.Fatalf("Create(%s): %v", variant.SKU, err)
+		}
+	}
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo := newRepos(t).Variants
+		mustCreateVariant(t, repo, &domain.Variant{
+			SKU:        "TS-RED-M",
+			ProductID:  "shirt",
+			Attributes: map[string]string{"color": "red", "size": "M"},
+			Price:      25,
+			Stock:      3,
+		})
+
+		got, err := repo.FindBySKU(ctx, "TS-RED-M")
+		if err != nil {
+			t.Fatalf("FindBySKU: %v", err)
+		}
+		if got.ProductID != "shirt" || got.Price != 25 || got.Stock != 3 || got.Attributes["size"] != "M" || got.CreatedAt.IsZero() {
+			t.Fatalf("FindBySKU returned %+v", got)
+		}
+
+		if err := repo.Create(ctx, &domain.Variant{SKU: "TS-RED-M", ProductID: "other"}); !errors.Is(err, domain.ErrVariantExists) {
+			t.Fatalf("duplicate Create = %v, want ErrVariantExists", err)
+		}
+		if _, err := repo.FindBySKU(ctx, "missing"); !errors.Is(err, domain.ErrVariantNotFound) {
+			t.Fatalf("FindBySKU(missing) = %v, want ErrVariantNotFound", err)
+		}
+	})
+
+	t.Run("UpdateAndDelete", func(t *testing.T) {
+		repo := newRepos(t).Variants
+		mustCreateVariant(t, repo, &domain.Variant{SKU: "a", ProductID: "p", Attributes: map[string]string{"size": "S"}})
+
+		err := repo.Update(ctx, &domain.Variant{SKU: "a", Attributes: map[string]string{"size": "L"}, Price: 9, Stock: 4})
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, _ := repo.FindBySKU(ctx, "a")
+		if got.Attributes["size"] != "L" || got.Price != 9 || got.Stock != 0 || got.ProductID != "p" {
+			t.Fatalf("after Update got %+v", got)
+		}
+		if err := repo.Update(ctx, &domain.Variant{SKU: "missing"}); !errors.Is(err, domain.ErrVariantNotFound) {
+			t.Fatalf("Update(missing) = %v, want ErrVariantNotFound", err)
+		}
+
+		if err := repo.Delete(ctx, "a"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := repo.Delete(ctx, "a"); !errors.Is(err, domain.ErrVariantNotFound) {
+			t.Fatalf("second Delete = %v, want ErrVariantNotFound", err)
+		}
+	})
+
+	t.Run("ListAndDeleteByProduct", func(t *testing.T) {
+		repo := newRepos(t).Variants
+		mustCreateVariant(t, repo, &domain.Variant{SKU: "b", ProductID: "p"})
+		mustCreateVariant(t, repo, &domain.Variant{SKU: "a", ProductID: "p"})
+		mustCreateVariant(t, repo, &domain.Variant{SKU: "c", ProductID: "q"})
+
+		variants, err := repo.ListByProduct(ctx, "p")
+		if err != nil {
+			t.Fatalf("ListByProduct: %v", err)
+		}
+		if len(variants) != 2 || variants[0].SKU != "a" || variants[1].SKU != "b" {
+			t.Fatalf("ListByProduct returned %d variants, want a then b", len(variants))
+		}
+
+		if err := repo.DeleteByProduct(ctx, "p"); err != nil {
+			t.Fatalf("DeleteByProduct: %v", err)
+		}
+		if variants, _ := repo.ListByProduct(ctx, "p"); len(variants) != 0 {
+			t.Fatalf("%d variants left after DeleteByProduct", len(variants))
+		}
+		if _, err := repo.FindBySKU(ctx, "c"); err != nil {
+			t.Fatalf("variant of another product was deleted: %v", err)
+		}
+	})
+
+	t.Run("StockCounters", func(t *testing.T) {
+		repo := newRepos(t).Variants
+		mustCreateVariant(t, repo, &domain.Variant{SKU: "a", ProductID: "p", Stock: 3})
+
+		if stock, err := repo.AdjustStock(ctx, "a", -2); err != nil || stock != 1 {
+			t.Fatalf("AdjustStock(-2) = %d, %v, want 1", stock, err)
+		}
+		if _, err := repo.AdjustStock(ctx, "a", -2); !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Fatalf("AdjustStock past zero = %v, want ErrInsufficientStock", err)
+		}
+		if stock, err := repo.AdjustStock(ctx, "a", 4); err != nil || stock != 5 {
+			t.Fatalf("AdjustStock(4) = %d, %v, want 5", stock, err)
+		}
+		if got, _ := repo.FindBySKU(ctx, "a"); got.Stock != 5 {
+			t.Fatalf("stock = %d, want 5", got.Stock)
+		}
+		if _, err := repo.AdjustStock(ctx, "missing", -1); !errors.Is(err, domain.ErrVariantNotFound) {
+			t.Fatalf("AdjustStock(missing) = %v, want ErrVariantNotFound", err)
+		}
+	})
+}
+
+func TestWarehouseRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo := newRepos(t).Warehouses
+		warehouse := &domain.Warehouse{
+			Name:     "North",
+			Location: domain.GeoPoint{Latitude: 51.5, Longitude: -0.12},
+			Priority: 2,
+			Active:   true,
+		}
+		if err := repo.Create(ctx, warehouse); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if warehouse.ID == "" {
+			t.Fatal("Create did not assign an ID")
+		}
+
+		got, err := repo.FindByID(ctx, warehouse.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Name != "North" || got.Location != warehouse.Location || got.Priority != 2 || !got.Active || got.CreatedAt.IsZero() {
+			t.Fatalf("FindByID returned %+v", got)
+		}
+
+		if err := repo.Create(ctx, &domain.Warehouse{ID: warehouse.ID, Name: "Again"}); !errors.Is(err, domain.ErrWarehouseExists) {
+			t.Fatalf("duplicate Create = %v, want ErrWarehouseExists", err)
+		}
+		if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, domain.ErrWarehouseNotFound) {
+			t.Fatalf("FindByID(missing) = %v, want ErrWarehouseNotFound", err)
+		}
+	})
+
+	t.Run("UpdateAndDelete", func(t *testing.T) {
+		repo := newRepos(t).Warehouses
+		if err := repo.Create(ctx, &domain.Warehouse{ID: "w", Name: "Old", Active: true}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		err := repo.Update(ctx, &domain.Warehouse{ID: "w", Name: "New", Location: domain.GeoPoint{Latitude: 1, Longitude: 2}, Priority: 5})
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, _ := repo.FindByID(ctx, "w")
+		if got.Name != "New" || got.Location.Longitude != 2 || got.Priority != 5 || got.Active {
+			t.Fatalf("after Update got %+v", got)
+		}
+		if err := repo.Update(ctx, &domain.Warehouse{ID: "missing"}); !errors.Is(err, domain.ErrWarehouseNotFound) {
+			t.Fatalf("Update(missing) = %v, want ErrWarehouseNotFound", err)
+		}
+
+		if err := repo.Delete(ctx, "w"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := repo.Delete(ctx, "w"); !errors.Is(err, domain.ErrWarehouseNotFound) {
+			t.Fatalf("second Delete = %v, want ErrWarehouseNotFound", err)
+		}
+	})
+
+	t.Run("ListByPriority", func(t *testing.T) {
+		repo := newRepos(t).Warehouses
+		for _, warehouse := range []*domain.Warehouse{
+			{ID: "c", Name: "C", Priority: 1},
+			{ID: "b", Name: "B", Priority: 2},
+			{ID: "a", Name: "A", Priority: 1},
+		} {
+			if err := repo.Create(ctx, warehouse); err != nil {
+				t.Fatalf("Create(%s): %v", warehouse.ID, err)
+			}
+		}
+
+		warehouses, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		var ids []string
+		for _, warehouse := range warehouses {
+			ids = append(ids, warehouse.ID)
+		}
+		if len(ids) != 3 || ids[0] != "a" || ids[1] != "c" || ids[2] != "b" {
+			t.Fatalf("List order = %v, want [a c b]", ids)
+		}
+	})
+}
+
+func TestStockLevelRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("AdjustCreatesAndGuards", func(t *testing.T) {
+		repo := newRepos(t).StockLevels
+		if err := repo.Adjust(ctx, "w1", "p", "", -1); !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Fatalf("Adjust of a missing level below zero = %v, want ErrInsufficientStock", err)
+		}
+		if err := repo.Adjust(ctx, "w1", "p", "", 5); err != nil {
+			t.Fatalf("Adjust: %v", err)
+		}
+		if err := repo.Adjust(ctx, "w1", "p", "", 2); err != nil {
+			t.Fatalf("second Adjust: %v", err)
+		}
+		if err := repo.Adjust(ctx, "w1", "p", "", -8); !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Fatalf("Adjust past zero = %v, want ErrInsufficientStock", err)
+		}
+		if err := repo.Adjust(ctx, "w1", "p", "", -7); err != nil {
+			t.Fatalf("Adjust to zero: %v", err)
+		}
+
+		levels, err := repo.ListByProduct(ctx, "p")
+		if err != nil {
+			t.Fatalf("ListByProduct: %v", err)
+		}
+		if len(levels) != 1 || levels[0].Quantity != 0 || levels[0].WarehouseID != "w1" {
+			t.Fatalf("ListByProduct returned %+v", levels)
+		}
+	})
+
+	t.Run("ListAndDelete", func(t *testing.T) {
+		repo := newRepos(t).StockLevels
+		for _, level := range []domain.StockLevel{
+			{WarehouseID: "w2", ProductID: "p", VariantSKU: "p-b", Quantity: 1},
+			{WarehouseID: "w1", ProductID: "p", VariantSKU: "p-b", Quantity: 2},
+			{WarehouseID: "w1", ProductID: "p", VariantSKU: "p-a", Quantity: 3},
+			{WarehouseID: "w1", ProductID: "q", Quantity: 4},
+		} {
+			if err := repo.Adjust(ctx, level.WarehouseID, level.ProductID, level.VariantSKU, level.Quantity); err != nil {
+				t.Fatalf("Adjust: %v", err)
+			}
+		}
+
+		levels, _ := repo.ListByProduct(ctx, "p")
+		if len(levels) != 3 || levels[0].VariantSKU != "p-a" || levels[1].VariantSKU != "p-b" || levels[2].WarehouseID != "w2" {
+			t.Fatalf("ListByProduct returned %+v", levels)
+		}
+		levels, _ = repo.ListByWarehouse(ctx, "w1")
+		if len(levels) != 3 || levels[0].VariantSKU != "p-a" || levels[2].ProductID != "q" {
+			t.Fatalf("ListByWarehouse returned %+v", levels)
+		}
+
+		if err := repo.DeleteByVariant(ctx, "p-a"); err != nil {
+			t.Fatalf("DeleteByVariant: %v", err)
+		}
+		if levels, _ := repo.ListByProduct(ctx, "p"); len(levels) != 2 {
+			t.Fatalf("%d levels left after DeleteByVariant, want 2", len(levels))
+		}
+		if err := repo.DeleteByWarehouse(ctx, "w2"); err != nil {
+			t.Fatalf("DeleteByWarehouse: %v", err)
+		}
+		if levels, _ := repo.ListByProduct(ctx, "p"); len(levels) != 1 {
+			t.Fatalf("%d levels left after DeleteByWarehouse, want 1", len(levels))
+		}
+		if err := repo.DeleteByProduct(ctx, "p"); err != nil {
+			t.Fatalf("DeleteByProduct: %v", err)
+		}
+		if levels, _ := repo.ListByWarehouse(ctx, "w1"); len(levels) != 1 || levels[0].ProductID != "q" {
+			t.Fatalf("ListByWarehouse after DeleteByProduct returned %+v", levels)
+		}
+	})
+}
+
+func TestStockMovementRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	repo := newRepos(t).Movements
+	for _, movement := range []domain.StockMovement{
+		{ProductID: "p", Delta: 5, Reason: domain.MovementRestock},
+		{ProductID: "p", WarehouseID: "w1", Delta: 3, Reason: domain.MovementTransfer},
+		{ProductID: "p", Delta: -3, Reason: domain.MovementTransfer},
+		{ProductID: "p", WarehouseID: "w1", Delta: -2, Reason: domain.MovementSale, OrderID: "o1"},
+		{ProductID: "p", VariantSKU: "p-a", Delta: 4, Reason: domain.MovementRestock},
+		{ProductID: "q", Delta: 1, Reason: domain.MovementManual, Actor: "u1", Note: "found"},
+	} {
+		movement := movement
+		if err := repo.Append(ctx, &movement); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+		if movement.ID == "" || movement.CreatedAt.IsZero() {
+			t.Fatalf("Append did not set ID and CreatedAt: %+v", movement)
+		}
+		// Keep creation times distinct so the order is well defined
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	t.Run("ListNewestFirstWithCursor", func(t *testing.T) {
+		page1, err := repo.List(ctx, domain.MovementFilter{ProductID: "p"}, nil, 3)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(page1) != 3 || page1[0].VariantSKU != "p-a" || page1[1].OrderID != "o1" {
+			t.Fatalf("first page = %+v", page1)
+		}
+
+		page2, err := repo.List(ctx, domain.MovementFilter{ProductID: "p"}, domain.MovementCursor(page1[2]), 3)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(page2) != 2 || page2[0].WarehouseID != "w1" || page2[1].Delta != 5 {
+			t.Fatalf("second page = %+v", page2)
+		}
+	})
+
+	t.Run("ListFilters", func(t *testing.T) {
+		sales, _ := repo.List(ctx, domain.MovementFilter{OrderID: "o1", Reason: domain.MovementSale}, nil, 10)
+		if len(sales) != 1 || sales[0].Delta != -2 {
+			t.Fatalf("sales of o1 = %+v", sales)
+		}
+		atW1, _ := repo.List(ctx, domain.MovementFilter{WarehouseID: "w1"}, nil, 10)
+		if len(atW1) != 2 {
+			t.Fatalf("%d movements at w1, want 2", len(atW1))
+		}
+		manual, _ := repo.List(ctx, domain.MovementFilter{ProductID: "q"}, nil, 10)
+		if len(manual) != 1 || manual[0].Actor != "u1" || manual[0].Note != "found" || manual[0].Reason != domain.MovementManual {
+			t.Fatalf("movements of q = %+v", manual)
+		}
+	})
+
+	t.Run("Balances", func(t *testing.T) {
+		balances, err := repo.Balances(ctx, "p")
+		if err != nil {
+			t.Fatalf("Balances: %v", err)
+		}
+		want := []domain.StockBalance{
+			{WarehouseID: "", Quantity: 2},
+			{WarehouseID: "w1", Quantity: 1},
+			{VariantSKU: "p-a", Quantity: 4},
+		}
+		if len(balances) != len(want) {
+			t.Fatalf("Balances = %+v, want %+v", balances, want)
+		}
+		for i := range want {
+			if balances[i] != want[i] {
+				t.Fatalf("Balances = %+v, want %+v", balances, want)
+			}
+		}
+	})
+}
+
+func TestReservationRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	newReservation := func(orderID string, expiresAt time.Time) *domain.Reservation {
+		return &domain.Reservation{
+			OrderID:   orderID,
+			Items:     []domain.ReservationItem{{ProductID: "p1", Quantity: 2}},
+			Status:    domain.ReservationStatusActive,
+			ExpiresAt: expiresAt,
+		}
+	}
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo := newRepos(t).Reservations
+		reservation := newReservation("o1", time.Now().Add(time.Hour))
+		reservation.Items = append(reservation.Items, domain.ReservationItem{ProductID: "p2", VariantSKU: "p2-red", WarehouseID: "w1", Quantity: 1})
+		if err := repo.Create(ctx, reservation); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		got, err := repo.FindByOrderID(ctx, "o1")
+		if err != nil {
+			t.Fatalf("FindByOrderID: %v", err)
+		}
+		if got.Status != domain.ReservationStatusActive || len(got.Items) != 2 || got.Items[0].Quantity != 2 || got.Items[1].VariantSKU != "p2-red" || got.Items[1].WarehouseID != "w1" {
+			t.Fatalf("FindByOrderID returned %+v", got)
+		}
+
+		if err := repo.Create(ctx, newReservation("o1", time.Now())); !errors.Is(err, domain.ErrReservationExists) {
+			t.Fatalf("duplicate Create = %v, want ErrReservationExists", err)
+		}
+		if _, err := repo.FindByOrderID(ctx, "missing"); !errors.Is(err, domain.ErrReservationNotFound) {
+			t.Fatalf("FindByOrderID(missing) = %v, want ErrReservationNotFound", err)
+		}
+	})
+
+	t.Run("UpdateStatusIsCompareAndSwap", func(t *testing.T) {
+		repo := newRepos(t).Reservations
+		if err := repo.Create(ctx, newReservation("o1", time.Now().Add(time.Hour))); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		if err := repo.UpdateStatus(ctx, "o1", domain.ReservationStatusActive, domain.ReservationStatusReleased); err != nil {
+			t.Fatalf("UpdateStatus: %v", err)
+		}
+		err := repo.UpdateStatus(ctx, "o1", domain.ReservationStatusActive, domain.ReservationStatusExpired)
+		if !errors.Is(err, domain.ErrReservationClosed) {
+			t.Fatalf("stale UpdateStatus = %v, want ErrReservationClosed", err)
+		}
+		err = repo.UpdateStatus(ctx, "missing", domain.ReservationStatusActive, domain.ReservationStatusReleased)
+		if !errors.Is(err, domain.ErrReservationNotFound) {
+			t.Fatalf("UpdateStatus(missing) = %v, want ErrReservationNotFound", err)
+		}
+	})
+
+	t.Run("ListExpired", func(t *testing.T) {
+		repo := newRepos(t).Reservations
+		now := time.Now()
+		for _, r := range []*domain.Reservation{
+			newReservation("late", now.Add(-time.Minute)),
+			newReservation("later", now.Add(-2*time.Minute)),
+			newReservation("fresh", now.Add(time.Hour)),
+			newReservation("done", now.Add(-time.Hour)),
+		} {
+			if err := repo.Create(ctx, r); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+		if err := repo.UpdateStatus(ctx, "done", domain.ReservationStatusActive, domain.ReservationStatusCommitted); err != nil {
+			t.Fatalf("UpdateStatus: %v", err)
+		}
+
+		expired, err := repo.ListExpired(ctx, now, 10)
+		if err != nil {
+			t.Fatalf("ListExpired: %v", err)
+		}
+		if len(expired) != 2 || expired[0].OrderID != "later" || expired[1].OrderID != "late" {
+			t.Fatalf("ListExpired returned %d reservations, want later then late", len(expired))
+		}
+	})
+
+	t.Run("BackordersAreFilledOnce", func(t *testing.T) {
+		repo := newRepos(t).Reservations
+		for _, id := range []string{"first", "second"} {
+			reservation := newReservation(id, time.Time{})
+			reservation.Status = domain.ReservationStatusBackordered
+			reservation.ShipTo = &domain.GeoPoint{Latitude: 52.5, Longitude: 13.4}
+			if err := repo.Create(ctx, reservation); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			// Keep creation times distinct so the order is well defined
+			time.Sleep(2 * time.Millisecond)
+		}
+		if err := repo.Create(ctx, newReservation("active", time.Now().Add(time.Hour))); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		backordered, err := repo.ListBackordered(ctx, 10)
+		if err != nil {
+			t.Fatalf("ListBackordered: %v", err)
+		}
+		if len(backordered) != 2 || backordered[0].OrderID != "first" || backordered[1].OrderID != "second" {
+			t.Fatalf("ListBackordered returned %d reservations, want first then second", len(backordered))
+		}
+		if shipTo := backordered[0].ShipTo; shipTo == nil || shipTo.Latitude != 52.5 {
+			t.Fatalf("ListBackordered lost the ship-to point: %+v", shipTo)
+		}
+
+		expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+		items := []domain.ReservationItem{{ProductID: "p1", WarehouseID: "w1", Quantity: 2}}
+		if err := repo.Fulfil(ctx, "first", items, expiresAt); err != nil {
+			t.Fatalf("Fulfil: %v", err)
+		}
+		got, _ := repo.FindByOrderID(ctx, "first")
+		if got.Status != domain.ReservationStatusActive || !got.ExpiresAt.Equal(expiresAt) || len(got.Items) != 1 || got.Items[0].WarehouseID != "w1" {
+			t.Fatalf("after Fulfil got %+v", got)
+		}
+
+		if err := repo.Fulfil(ctx, "first", items, expiresAt); !errors.Is(err, domain.ErrReservationClosed) {
+			t.Fatalf("second Fulfil = %v, want ErrReservationClosed", err)
+		}
+		if err := repo.Fulfil(ctx, "missing", items, expiresAt); !errors.Is(err, domain.ErrReservationNotFound) {
+			t.Fatalf("Fulfil(missing) = %v, want ErrReservationNotFound", err)
+		}
+		if backordered, _ := repo.ListBackordered(ctx, 10); len(backordered) != 1 || backordered[0].OrderID != "second" {
+			t.Fatalf("ListBackordered after Fulfil returned %d reservations, want second", len(backordered))
+		}
+	})
+
+	t.Run("ReturnsAreRecordedOnce", func(t *testing.T) {
+		repo := newRepos(t).Reservations
+		if err := repo.Create(ctx, newReservation("o1", time.Now().Add(time.Hour))); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		one := []domain.ReturnedItem{{ProductID: "p1", Quantity: 1, Resellable: true}}
+		if err := repo.AddReturn(ctx, "o1", domain.ReservationReturn{ID: "r1", Items: one}); !errors.Is(err, domain.ErrReservationClosed) {
+			t.Fatalf("AddReturn before commit = %v, want ErrReservationClosed", err)
+		}
+		if err := repo.UpdateStatus(ctx, "o1", domain.ReservationStatusActive, domain.ReservationStatusCommitted); err != nil {
+			t.Fatalf("UpdateStatus: %v", err)
+		}
+		for _, id := range []string{"r1", "r2"} {
+			if err := repo.AddReturn(ctx, "o1", domain.ReservationReturn{ID: id, Items: one}); err != nil {
+				t.Fatalf("AddReturn(%s): %v", id, err)
+			}
+		}
+		if err := repo.AddReturn(ctx, "o1", domain.ReservationReturn{ID: "r1", Items: one}); !errors.Is(err, domain.ErrReturnExists) {
+			t.Fatalf("second AddReturn = %v, want ErrReturnExists", err)
+		}
+		// Both units sold are back already
+		if err := repo.AddReturn(ctx, "o1", domain.ReservationReturn{ID: "r3", Items: one}); !errors.Is(err, domain.ErrInvalidReturn) {
+			t.Fatalf("AddReturn beyond the quantity sold = %v, want ErrInvalidReturn", err)
+		}
+		if err := repo.AddReturn(ctx, "missing", domain.ReservationReturn{ID: "r1", Items: one}); !errors.Is(err, domain.ErrReservationNotFound) {
+			t.Fatalf("AddReturn(missing) = %v, want ErrReservationNotFound", err)
+		}
+
+		got, _ := repo.FindByOrderID(ctx, "o1")
+		if len(got.Returns) != 2 || got.Returns[0].ID != "r1" || got.Returns[1].ID != "r2" {
+			t.Fatalf("Returns = %+v, want r1 and r2", got.Returns)
+		}
+		if items := got.Returns[1].Items; len(items) != 1 || items[0].Quantity != 1 || !items[0].Resellable {
+			t.Fatalf("items of r2 = %+v", items)
+		}
+	})
+}
+
+func TestSupplierRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo := newRepos(t).Suppliers
+		supplier := &domain.Supplier{Name: "Acme", Email: "sales@acme.test", Phone: "+1 555 0100"}
+		if err := repo.Create(ctx, supplier); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if supplier.ID == "" {
+			t.Fatal("Create did not assign an ID")
+		}
+
+		got, err := repo.FindByID(ctx, supplier.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Name != "Acme" || got.Email != supplier.Email || got.Phone != supplier.Phone || got.CreatedAt.IsZero() {
+			t.Fatalf("FindByID returned %+v", got)
+		}
+
+		if err := repo.Create(ctx, &domain.Supplier{ID: supplier.ID, Name: "Again"}); !errors.Is(err, domain.ErrSupplierExists) {
+			t.Fatalf("duplicate Create = %v, want ErrSupplierExists", err)
+		}
+		if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, domain.ErrSupplierNotFound) {
+			t.Fatalf("FindByID(missing) = %v, want ErrSupplierNotFound", err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepos(t).Suppliers
+		if err := repo.Create(ctx, &domain.Supplier{ID: "s", Name: "Old", Email: "old@test"}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		if err := repo.Update(ctx, &domain.Supplier{ID: "s", Name: "New", Phone: "123"}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, _ := repo.FindByID(ctx, "s")
+		if got.Name != "New" || got.Email != "" || got.Phone != "123" {
+			t.Fatalf("after Update got %+v", got)
+		}
+		if err := repo.Update(ctx, &domain.Supplier{ID: "missing"}); !errors.Is(err, domain.ErrSupplierNotFound) {
+			t.Fatalf("Update(missing) = %v, want ErrSupplierNotFound", err)
+		}
+	})
+
+	t.Run("ListByName", func(t *testing.T) {
+		repo := newRepos(t).Suppliers
+		for _, supplier := range []*domain.Supplier{
+			{ID: "c", Name: "Beta"},
+			{ID: "b", Name: "Alpha"},
+			{ID: "a", Name: "Beta"},
+		} {
+			if err := repo.Create(ctx, supplier); err != nil {
+				t.Fatalf("Create(%s): %v", supplier.ID, err)
+			}
+		}
+
+		suppliers, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		var ids []string
+		for _, supplier := range suppliers {
+			ids = append(ids, supplier.ID)
+		}
+		if strings.Join(ids, ",") != "b,a,c" {
+			t.Fatalf("List returned %v, want [b a c]", ids)
+		}
+	})
+}
+
+func TestPurchaseOrderRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	newPurchaseOrder := func(id, supplierID string) *domain.PurchaseOrder {
+		return &domain.PurchaseOrder{
+			ID:         id,
+			SupplierID: supplierID,
+			Lines: []domain.PurchaseOrderLine{
+				{ProductID: "p1", Quantity: 10, UnitCost: 2.5},
+				{ProductID: "p2", VariantSKU: "p2-red", Quantity: 4, UnitCost: 7},
+			},
+			Status: domain.PurchaseOrderOpen,
+		}
+	}
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo := newRepos(t).PurchaseOrders
+		po := newPurchaseOrder("", "s1")
+		po.WarehouseID = "w1"
+		po.Note = "spring restock"
+		if err := repo.Create(ctx, po); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if po.ID == "" || po.Version != 1 {
+			t.Fatalf("Create left ID %q and version %d", po.ID, po.Version)
+		}
+
+		got, err := repo.FindByID(ctx, po.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.SupplierID != "s1" || got.WarehouseID != "w1" || got.Note != "spring restock" || got.Status != domain.PurchaseOrderOpen ||
+			got.Version != 1 || got.CreatedAt.IsZero() {
+			t.Fatalf("FindByID returned %+v", got)
+		}
+		if len(got.Lines) != 2 || got.Lines[0].UnitCost != 2.5 || got.Lines[1].VariantSKU != "p2-red" || got.Lines[1].Quantity != 4 {
+			t.Fatalf("FindByID returned lines %+v", got.Lines)
+		}
+
+		if err := repo.Create(ctx, newPurchaseOrder(po.ID, "s1")); !errors.Is(err, domain.ErrPurchaseOrderExists) {
+			t.Fatalf("duplicate Create = %v, want ErrPurchaseOrderExists", err)
+		}
+		if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, domain.ErrPurchaseOrderNotFound) {
+			t.Fatalf("FindByID(missing) = %v, want ErrPurchaseOrderNotFound", err)
+		}
+	})
+
+	t.Run("UpdateIsCompareAndSwap", func(t *testing.T) {
+		repo := newRepos(t).PurchaseOrders
+		if err := repo.Create(ctx, newPurchaseOrder("po", "s1")); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		po, _ := repo.FindByID(ctx, "po")
+		po.Lines[0].Received = 10
+		po.Status = domain.PurchaseOrderPartiallyReceived
+		if err := repo.Update(ctx, po); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, _ := repo.FindByID(ctx, "po")
+		if got.Version != 2 || got.Status != domain.PurchaseOrderPartiallyReceived || got.Lines[0].Received != 10 {
+			t.Fatalf("after Update got %+v", got)
+		}
+
+		// po still carries version 1
+		if err := repo.Update(ctx, po); !errors.Is(err, domain.ErrVersionConflict) {
+			t.Fatalf("stale Update = %v, want ErrVersionConflict", err)
+		}
+		if err := repo.Update(ctx, newPurchaseOrder("missing", "s1")); !errors.Is(err, domain.ErrPurchaseOrderNotFound) {
+			t.Fatalf("Update(missing) = %v, want ErrPurchaseOrderNotFound", err)
+		}
+	})
+
+	t.Run("ListNewestFirstWithFilters", func(t *testing.T) {
+		repo := newRepos(t).PurchaseOrders
+		for _, po := range []*domain.PurchaseOrder{
+			newPurchaseOrder("a", "s1"),
+			newPurchaseOrder("b", "s2"),
+			newPurchaseOrder("c", "s1"),
+			newPurchaseOrder("d", "s1"),
+		} {
+			if err := repo.Create(ctx, po); err != nil {
+				t.Fatalf("Create(%s): %v", po.ID, err)
+			}
+			// Keep creation times distinct so the order is well defined
+			time.Sleep(2 * time.Millisecond)
+		}
+		d, _ := repo.FindByID(ctx, "d")
+		d.Status = domain.PurchaseOrderCancelled
+		if err := repo.Update(ctx, d); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		list := func(filter domain.PurchaseOrderFilter, after *domain.PageCursor, limit int) string {
+			t.Helper()
+			orders, err := repo.List(ctx, filter, after, limit)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			var ids []string
+			for _, po := range orders {
+				ids = append(ids, po.ID)
+			}
+			return strings.Join(ids, ",")
+		}
+
+		if got := list(domain.PurchaseOrderFilter{}, nil, 10); got != "d,c,b,a" {
+			t.Fatalf("List = %s, want d,c,b,a", got)
+		}
+		if got := list(domain.PurchaseOrderFilter{SupplierID: "s1"}, nil, 10); got != "d,c,a" {
+			t.Fatalf("List(s1) = %s, want d,c,a", got)
+		}
+		if got := list(domain.PurchaseOrderFilter{SupplierID: "s1", Status: domain.PurchaseOrderOpen}, nil, 10); got != "c,a" {
+			t.Fatalf("List(s1, open) = %s, want c,a", got)
+		}
+
+		c, _ := repo.FindByID(ctx, "c")
+		if got := list(domain.PurchaseOrderFilter{}, domain.PurchaseOrderCursor(c), 1); got != "b" {
+			t.Fatalf("List after c = %s, want b", got)
+		}
+	})
+}
+
+func TestOutboxRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	repos := newRepos(t)
+	product := &domain.Product{ID: "p1", Name: "Mug", Price: 9.5}
+	mustCreate(t, repos.Products, product)
+	product.Name = "Cup"
+	if err := repos.Products.Update(ctx, product); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := repos.Products.AdjustStock(ctx, "p1", 5); err != nil {
+		t.Fatalf("AdjustStock: %v", err)
+	}
+	if err := repos.Variants.Create(ctx, &domain.Variant{SKU: "MUG-RED", ProductID: "p1"}); err != nil {
+		t.Fatalf("Create(MUG-RED): %v", err)
+	}
+	if _, err := repos.Variants.AdjustStock(ctx, "MUG-RED", 2); err != nil {
+		t.Fatalf("AdjustStock(MUG-RED): %v", err)
+	}
+	if err := repos.StockLevels.Adjust(ctx, "w1", "p1", "", 3); err != nil {
+		t.Fatalf("Adjust: %v", err)
+	}
+	// Refused stock changes leave no event behind
+	if _, err := repos.Products.AdjustStock(ctx, "p1", -10); !errors.Is(err, domain.ErrInsufficientStock) {
+		t.Fatalf("AdjustStock(-10) = %v, want ErrInsufficientStock", err)
+	}
+	if err := repos.StockLevels.Adjust(ctx, "w1", "p1", "", -10); !errors.Is(err, domain.ErrInsufficientStock) {
+		t.Fatalf("Adjust(-10) = %v, want ErrInsufficientStock", err)
+	}
+	if err := repos.Products.Archive(ctx, "p1", time.Now()); err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	// Archiving again changes nothing and must not be announced
+	if err := repos.Products.Archive(ctx, "p1", time.Now()); err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	if err := repos.Products.Restore(ctx, "p1"); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if err := repos.Products.Delete(ctx, "p1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	// Failed writes leave no event behind
+	if err := repos.Products.Update(ctx, product); !errors.Is(err, domain.ErrProductNotFound) {
+		t.Fatalf("Update(deleted) = %v, want ErrProductNotFound", err)
+	}
+
+	events, err := repos.Outbox.ListPending(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListPending: %v", err)
+	}
+	want := []domain.EventType{
+		domain.EventProductCreated,
+		domain.EventProductUpdated,
+		domain.EventStockAdjusted,
+		domain.EventStockAdjusted,
+		domain.EventStockAdjusted,
+		domain.EventProductArchived,
+		domain.EventProductRestored,
+		domain.EventProductDeleted,
+	}
+	if len(events) != len(want) {
+		t.Fatalf("ListPending returned %d events, want %d", len(events), len(want))
+	}
+	for i, event := range events {
+		if event.Type != want[i] || event.AggregateID != "p1" || event.ID == "" || event.OccurredAt.IsZero() {
+			t.Fatalf("event %d = %+v, want %s of p1", i, event, want[i])
+		}
+	}
+	if !strings.Contains(string(events[1].Payload), `"Cup"`) {
+		t.Fatalf("ProductUpdated payload = %s", events[1].Payload)
+	}
+	for i, want := range []string{`"stock":5`, `"variant_sku":"MUG-RED","delta":2,"stock":2`, `"warehouse_id":"w1","delta":3,"stock":3`} {
+		if payload := string(events[2+i].Payload); !strings.Contains(payload, want) {
+			t.Fatalf("StockAdjusted payload = %s, want %s", payload, want)
+		}
+	}
+
+	page, err := repos.Outbox.ListPending(ctx, 2)
+	if err != nil || len(page) != 2 || page[0].ID != events[0].ID {
+		t.Fatalf("ListPending(2) = %+v, %v", page, err)
+	}
+
+	if err := repos.Outbox.Delete(ctx, []string{events[0].ID, events[1].ID, "missing"}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	rest, err := repos.Outbox.ListPending(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListPending: %v", err)
+	}
+	if len(rest) != len(want)-2 || rest[0].ID != events[2].ID {
+		t.Fatalf("ListPending after Delete = %+v", rest)
+	}
+}
+
+func mustCreate(t *testing.T, repo repository.ProductRepository, product *domain.Product) {
+	t.Helper()
+	if err := repo.Create(context.Background(), product); err != nil {
+		t.Fatalf("Create(%s): %v", product.ID, err)
+	}
+}
+
+func assertIDs(t *testing.T, products []*domain.Product, want ...string) {
+	t.Helper()
+	if len(products) != len(want) {
+		t.Fatalf("got %d products, want %v", len(products), want)
+	}
+	for i, p := range products {
+		if p.ID != want[i] {
+			t.Fatalf("product %d is %s, want %s", i, p.ID, want[i])
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"inventory-service/internal/domain"
+)
+
+type memoryReservationRepository struct {
+	mu           sync.RWMutex
+	reservations map[string]domain.Reservation
+}
+
+// NewMemoryReservationRepository returns a thread-safe
+// ReservationRepository that keeps everything in process memory.
+func NewMemoryReservationRepository() ReservationRepository {
+	return &memoryReservationRepository{
+		reservations: map[string]domain.Reservation{},
+	}
+}
+
+func (r *memoryReservationRepository) Create(ctx context.Context, reservation *domain.Reservation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.reservations[reservation.OrderID]; ok {
+		return domain.ErrReservationExists
+	}
+
+	now := time.Now()
+	reservation.CreatedAt = now
+	reservation.UpdatedAt = now
+
+	r.reservations[reservation.OrderID] = copyReservation(*reservation)
+
+	return nil
+}
+
+func (r *memoryReservationRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reservation, ok := r.reservations[orderID]
+	if !ok {
+		return nil, domain.ErrReservationNotFound
+	}
+
+	reservation = copyReservation(reservation)
+	return &reservation, nil
+}
+
+func (r *memoryReservationRepository) UpdateStatus(ctx context.Context, orderID string, from, to domain.ReservationStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, ok := r.reservations[orderID]
+	if !ok {
+		return domain.ErrReservationNotFound
+	}
+	if reservation.Status != from {
+		return domain.ErrReservationClosed
+	}
+
+	reservation.Status = to
+	reservation.UpdatedAt = time.Now()
+	r.reservations[orderID] = reservation
+
+	return nil
+}
+
+func (r *memoryReservationRepository) ListExpired(ctx context.Context, before time.Time, limit int) ([]*domain.Reservation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var expired []*domain.Reservation
+	for _, reservation := range r.reservations {
+		if reservation.Status == domain.ReservationStatusActive && reservation.ExpiresAt.Before(before) {
+			reservation = copyReservation(reservation)
+			expired = append(expired, &reservation)
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].ExpiresAt.Before(expired[j].ExpiresAt)
+	})
+	if len(expired) > limit {
+		expired = expired[:limit]
+	}
+
+	return expired, nil
+}
+
+func (r *memoryReservationRepository) ListBackordered(ctx context.Context, limit int) ([]*domain.Reservation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var backordered []*domain.Reservation
+	for _, reservation := range r.reservations {
+		if reservation.Status == domain.ReservationStatusBackordered {
+			reservation = copyReservation(reservation)
+			backordered = append(backordered, &reservation)
+		}
+	}
+
+	sort.Slice(backordered, func(i, j int) bool {
+		if !backordered[i].CreatedAt.Equal(backordered[j].CreatedAt) {
+			return backordered[i].CreatedAt.Before(backordered[j].CreatedAt)
+		}
+		return backordered[i].OrderID < backordered[j].OrderID
+	})
+	if len(backordered) > limit {
+		backordered = backordered[:limit]
+	}
+
+	return backordered, nil
+}
+
+func (r *memoryReservationRepository) Fulfil(ctx context.Context, orderID string, items []domain.ReservationItem, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, ok := r.reservations[orderID]
+	if !ok {
+		return domain.ErrReservationNotFound
+	}
+	if reservation.Status != domain.ReservationStatusBackordered {
+		return domain.ErrReservationClosed
+	}
+
+	reservation.Items = append([]domain.ReservationItem(nil), items...)
+	reservation.Status = domain.ReservationStatusActive
+	reservation.ExpiresAt = expiresAt
+	reservation.UpdatedAt = time.Now()
+	r.reservations[orderID] = reservation
+
+	return nil
+}
+
+func (r *memoryReservationRepository) AddReturn(ctx context.Context, orderID string, ret domain.ReservationReturn) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, ok := r.reservations[orderID]
+	if !ok {
+		return domain.ErrReservationNotFound
+	}
+	if err := returnRefusal(&reservation, ret); err != nil {
+		return err
+	}
+
+	reservation = copyReservation(reservation)
+	ret.Items = append([]domain.ReturnedItem(nil), ret.Items...)
+	reservation.Returns = append(reservation.Returns, ret)
+	reservation.UpdatedAt = time.Now()
+	r.reservations[orderID] = reservation
+
+	return nil
+}
+
+// returnRefusal explains why a return cannot be recorded against a
+// reservation, or returns nil when it can.
+func returnRefusal(reservation *domain.Reservation, ret domain.ReservationReturn) error {
+	if reservation.HasReturn(ret.ID) {
+		return domain.ErrReturnExists
+	}
+	if reservation.Status != domain.ReservationStatusCommitted {
+		return domain.ErrReservationClosed
+	}
+	return reservation.CheckReturn(ret.Items)
+}
+
+// copyReservation detaches the items slice so callers cannot mutate
+// stored state.
+func copyReservation(reservation domain.Reservation) domain.Reservation {
+	reservation.Items = append([]domain.ReservationItem(nil), reservation.Items...)
+	reservation.Returns = append([]domain.ReservationReturn(nil), reservation.Returns...)
+	if reservation.ShipTo != nil {
+		shipTo := *reservation.ShipTo
+		reservation.ShipTo = &shipTo
+	}
+	return reservation
+}
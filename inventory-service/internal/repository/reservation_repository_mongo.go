@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"inventory-service/internal/domain"
+)
+
+type reservationRepository struct {
+	collection *mongo.Collection
+}
+
+type reservationItemDocument struct {
+	ProductID   string `bson:"product_id"`
+	VariantSKU  string `bson:"variant_sku,omitempty"`
+	WarehouseID string `bson:"warehouse_id,omitempty"`
+	Quantity    int    `bson:"quantity"`
+}
+
+type geoPointDocument struct {
+	Latitude  float64 `bson:"latitude"`
+	Longitude float64 `bson:"longitude"`
+}
+
+type reservationDocument struct {
+	OrderID   string                      `bson:"_id"`
+	Items     []reservationItemDocument   `bson:"items"`
+	Status    string                      `bson:"status"`
+	ShipTo    *geoPointDocument           `bson:"ship_to,omitempty"`
+	Returns   []reservationReturnDocument `bson:"returns,omitempty"`
+	ExpiresAt time.Time                   `bson:"expires_at"`
+	CreatedAt time.Time                   `bson:"created_at"`
+	UpdatedAt time.Time                   `bson:"updated_at"`
+}
+
+// reservationReturnDocument is a return taken back against a reservation.
+type reservationReturnDocument struct {
+	ID    string                 `bson:"id"`
+	Items []returnedItemDocument `bson:"items,omitempty"`
+}
+
+type returnedItemDocument struct {
+	ProductID  string `bson:"product_id"`
+	VariantSKU string `bson:"variant_sku,omitempty"`
+	Quantity   int    `bson:"quantity"`
+	Resellable bool   `bson:"resellable"`
+}
+
+func NewMongoReservationRepository(db *mongo.Database) ReservationRepository {
+	return &reservationRepository{
+		collection: db.Collection("reservations"),
+	}
+}
+
+func (r *reservationRepository) Create(ctx context.Context, reservation *domain.Reservation) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	reservation.CreatedAt = now
+	reservation.UpdatedAt = now
+
+	var shipTo *geoPointDocument
+	if reservation.ShipTo != nil {
+		shipTo = &geoPointDocument{Latitude: reservation.ShipTo.Latitude, Longitude: reservation.ShipTo.Longitude}
+	}
+
+	// The order ID is the document key, so a second reservation for the
+	// same order fails with a duplicate key error
+	_, err := r.collection.InsertOne(ctx, reservationDocument{
+		OrderID:   reservation.OrderID,
+		Items:     reservationItemDocuments(reservation.Items),
+		Status:    string(reservation.Status),
+		ShipTo:    shipTo,
+		ExpiresAt: reservation.ExpiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrReservationExists
+	}
+
+	return err
+}
+
+func (r *reservationRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var result reservationDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": orderID}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrReservationNotFound
+		}
+		return nil, err
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *reservationRepository) UpdateStatus(ctx context.Context, orderID string, from, to domain.ReservationStatus) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Matching on the current status makes the transition a compare-and-swap,
+	// so stock is returned at most once per reservation
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": orderID, "status": string(from)},
+		bson.M{"$set": bson.M{
+			"status":     string(to),
+			"updated_at": time.Now(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		count, err := r.collection.CountDocuments(ctx, bson.M{"_id": orderID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return domain.ErrReservationNotFound
+		}
+		return domain.ErrReservationClosed
+	}
+
+	return nil
+}
+
+func (r *reservationRepository) ListExpired(ctx context.Context, before time.Time, limit int) ([]*domain.Reservation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"status":     string(domain.ReservationStatusActive),
+		"expires_at": bson.M{"$lt": before},
+	}
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "expires_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reservations []*domain.Reservation
+	for cursor.Next(ctx) {
+		var result reservationDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, result.toDomain())
+	}
+
+	return reservations, cursor.Err()
+}
+
+func (r *reservationRepository) ListBackordered(ctx context.Context, limit int) ([]*domain.Reservation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"status": string(domain.ReservationStatusBackordered)}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reservations []*domain.Reservation
+	for cursor.Next(ctx) {
+		var result reservationDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, result.toDomain())
+	}
+
+	return reservations, cursor.Err()
+}
+
+func (r *reservationRepository) Fulfil(ctx context.Context, orderID string, items []domain.ReservationItem, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": orderID, "status": string(domain.ReservationStatusBackordered)},
+		bson.M{"$set": bson.M{
+			"items":      reservationItemDocuments(items),
+			"status":     string(domain.ReservationStatusActive),
+			"expires_at": expiresAt,
+			"updated_at": time.Now(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		count, err := r.collection.CountDocuments(ctx, bson.M{"_id": orderID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return domain.ErrReservationNotFound
+		}
+		return domain.ErrReservationClosed
+	}
+
+	return nil
+}
+
+// AddReturn checks a return against the reservation as read and records
+// it only while the reservation is unchanged, reading it again when
+// another change came first.
+func (r *reservationRepository) AddReturn(ctx context.Context, orderID string, ret domain.ReservationReturn) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	items := make([]returnedItemDocument, len(ret.Items))
+	for i, item := range ret.Items {
+		items[i] = returnedItemDocument{
+			ProductID:  item.ProductID,
+			VariantSKU: item.VariantSKU,
+			Quantity:   item.Quantity,
+			Resellable: item.Resellable,
+		}
+	}
+
+	for {
+		reservation, err := r.FindByOrderID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if err := returnRefusal(reservation, ret); err != nil {
+			return err
+		}
+
+		result, err := r.collection.UpdateOne(ctx,
+			bson.M{"_id": orderID, "updated_at": reservation.UpdatedAt},
+			bson.M{
+				"$push": bson.M{"returns": reservationReturnDocument{ID: ret.ID, Items: items}},
+				"$set":  bson.M{"updated_at": time.Now()},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount > 0 {
+			return nil
+		}
+	}
+}
+
+func reservationItemDocuments(items []domain.ReservationItem) []reservationItemDocument {
+	documents := make([]reservationItemDocument, len(items))
+	for i, item := range items {
+		documents[i] = reservationItemDocument{
+			ProductID:   item.ProductID,
+			VariantSKU:  item.VariantSKU,
+			WarehouseID: item.WarehouseID,
+			Quantity:    item.Quantity,
+		}
+	}
+	return documents
+}
+
+func (d *reservationDocument) toDomain() *domain.Reservation {
+	items := make([]domain.ReservationItem, len(d.Items))
+	for i, item := range d.Items {
+		items[i] = domain.ReservationItem{
+			ProductID:   item.ProductID,
+			VariantSKU:  item.VariantSKU,
+			WarehouseID: item.WarehouseID,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	var shipTo *domain.GeoPoint
+	if d.ShipTo != nil {
+		shipTo = &domain.GeoPoint{Latitude: d.ShipTo.Latitude, Longitude: d.ShipTo.Longitude}
+	}
+
+	var returns []domain.ReservationReturn
+	for _, ret := range d.Returns {
+		var items []domain.ReturnedItem
+		for _, item := range ret.Items {
+			items = append(items, domain.ReturnedItem{
+				ProductID:  item.ProductID,
+				VariantSKU: item.VariantSKU,
+				Quantity:   item.Quantity,
+				Resellable: item.Resellable,
+			})
+		}
+		returns = append(returns, domain.ReservationReturn{ID: ret.ID, Items: items})
+	}
+
+	return &domain.Reservation{
+		OrderID:   d.OrderID,
+		Items:     items,
+		Status:    domain.ReservationStatus(d.Status),
+		ShipTo:    shipTo,
+		Returns:   returns,
+		ExpiresAt: d.ExpiresAt,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"inventory-service/internal/domain"
+)
+
+type postgresReservationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresReservationRepository(db *sql.DB) ReservationRepository {
+	return &postgresReservationRepository{db: db}
+}
+
+const reservationColumns = `order_id, items, status, ship_to, expires_at, created_at, updated_at, returns`
+
+func (r *postgresReservationRepository) Create(ctx context.Context, reservation *domain.Reservation) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	items, err := json.Marshal(reservation.Items)
+	if err != nil {
+		return err
+	}
+	var shipTo []byte
+	if reservation.ShipTo != nil {
+		if shipTo, err = json.Marshal(reservation.ShipTo); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	reservation.CreatedAt = now
+	reservation.UpdatedAt = now
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO reservations (`+reservationColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $6, '[]')`,
+		reservation.OrderID, items, string(reservation.Status), shipTo, reservation.ExpiresAt, now,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrReservationExists
+	}
+
+	return err
+}
+
+func (r *postgresReservationRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+reservationColumns+` FROM reservations WHERE order_id = $1`, orderID)
+	reservation, err := scanReservation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrReservationNotFound
+	}
+
+	return reservation, err
+}
+
+func (r *postgresReservationRepository) UpdateStatus(ctx context.Context, orderID string, from, to domain.ReservationStatus) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE reservations SET status = $3, updated_at = $4
+		WHERE order_id = $1 AND status = $2`,
+		orderID, string(from), string(to), time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.expectTransition(ctx, result, orderID)
+}
+
+func (r *postgresReservationRepository) ListExpired(ctx context.Context, before time.Time, limit int) ([]*domain.Reservation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+reservationColumns+` FROM reservations
+		WHERE status = $1 AND expires_at < $2
+		ORDER BY expires_at
+		LIMIT $3`,
+		string(domain.ReservationStatusActive), before, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []*domain.Reservation
+	for rows.Next() {
+		reservation, err := scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, rows.Err()
+}
+
+func (r *postgresReservationRepository) ListBackordered(ctx context.Context, limit int) ([]*domain.Reservation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+reservationColumns+` FROM reservations
+		WHERE status = $1
+		ORDER BY created_at, order_id
+		LIMIT $2`,
+		string(domain.ReservationStatusBackordered), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []*domain.Reservation
+	for rows.Next() {
+		reservation, err := scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, rows.Err()
+}
+
+func (r *postgresReservationRepository) Fulfil(ctx context.Context, orderID string, items []domain.ReservationItem, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE reservations SET items = $3, status = $4, expires_at = $5, updated_at = $6
+		WHERE order_id = $1 AND status = $2`,
+		orderID, string(domain.ReservationStatusBackordered), encoded, string(domain.ReservationStatusActive), expiresAt, time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return r.expectTransition(ctx, result, orderID)
+}
+
+func (r *postgresReservationRepository) AddReturn(ctx context.Context, orderID string, ret domain.ReservationReturn) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		// The row lock makes concurrent returns check against each other
+		reservation, err := scanReservation(tx.QueryRowContext(ctx, `
+			SELECT `+reservationColumns+` FROM reservations WHERE order_id = $1 FOR UPDATE`,
+			orderID,
+		))
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrReservationNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := returnRefusal(reservation, ret); err != nil {
+			return err
+		}
+
+		returns, err := json.Marshal(append(reservation.Returns, ret))
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE reservations SET returns = $2, updated_at = $3 WHERE order_id = $1`,
+			orderID, returns, time.Now(),
+		)
+		return err
+	})
+}
+
+// expectTransition checks that a status-guarded update changed the
+// reservation, telling a missing reservation apart from one whose status
+// has moved on.
+func (r *postgresReservationRepository) expectTransition(ctx context.Context, result sql.Result, orderID string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		var exists bool
+		if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM reservations WHERE order_id = $1)`, orderID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrReservationNotFound
+		}
+		return domain.ErrReservationClosed
+	}
+
+	return nil
+}
+
+func scanReservation(row rowScanner) (*domain.Reservation, error) {
+	var (
+		reservation domain.Reservation
+		items       []byte
+		status      string
+		shipTo      []byte
+		returns     []byte
+	)
+	err := row.Scan(&reservation.OrderID, &items, &status, &shipTo, &reservation.ExpiresAt, &reservation.CreatedAt, &reservation.UpdatedAt, &returns)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(items, &reservation.Items); err != nil {
+		return nil, err
+	}
+	if shipTo != nil {
+		reservation.ShipTo = &domain.GeoPoint{}
+		if err := json.Unmarshal(shipTo, reservation.ShipTo); err != nil {
+			return nil, err
+		}
+	}
+	reservation.Status = domain.ReservationStatus(status)
+	if err := json.Unmarshal(returns, &reservation.Returns); err != nil {
+		return nil, err
+	}
+	if len(reservation.Returns) == 0 {
+		reservation.Returns = nil
+	}
+
+	return &reservation, nil
+}
+
+// isUniqueViolation reports whether err is a Postgres unique_violation.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
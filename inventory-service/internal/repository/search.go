@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"inventory-service/internal/domain"
+)
+
+// searchTerms splits a free-text query into lower-cased words, the unit
+// every backend matches on.
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// sortFacets orders facets by descending count, then category ID.
+func sortFacets(facets []domain.CategoryFacet) {
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].CategoryID < facets[j].CategoryID
+	})
+}
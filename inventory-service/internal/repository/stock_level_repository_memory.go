@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"inventory-service/internal/domain"
+)
+
+type stockLevelKey struct {
+	warehouseID, productID, sku string
+}
+
+type memoryStockLevelRepository struct {
+	mu     sync.RWMutex
+	levels map[stockLevelKey]domain.StockLevel
+	outbox *MemoryOutbox
+}
+
+// NewMemoryStockLevelRepository returns a thread-safe StockLevelRepository
+// that keeps everything in process memory and writes its events to outbox.
+func NewMemoryStockLevelRepository(outbox *MemoryOutbox) StockLevelRepository {
+	return &memoryStockLevelRepository{
+		levels: map[stockLevelKey]domain.StockLevel{},
+		outbox: outbox,
+	}
+}
+
+func (r *memoryStockLevelRepository) ListByProduct(ctx context.Context, productID string) ([]*domain.StockLevel, error) {
+	levels := r.list(func(level domain.StockLevel) bool { return level.ProductID == productID })
+
+	sort.Slice(levels, func(i, j int) bool {
+		if levels[i].WarehouseID != levels[j].WarehouseID {
+			return levels[i].WarehouseID < levels[j].WarehouseID
+		}
+		return levels[i].VariantSKU < levels[j].VariantSKU
+	})
+
+	return levels, nil
+}
+
+func (r *memoryStockLevelRepository) ListByWarehouse(ctx context.Context, warehouseID string) ([]*domain.StockLevel, error) {
+	levels := r.list(func(level domain.StockLevel) bool { return level.WarehouseID == warehouseID })
+
+	sort.Slice(levels, func(i, j int) bool {
+		if levels[i].ProductID != levels[j].ProductID {
+			return levels[i].ProductID < levels[j].ProductID
+		}
+		return levels[i].VariantSKU < levels[j].VariantSKU
+	})
+
+	return levels, nil
+}
+
+func (r *memoryStockLevelRepository) Adjust(ctx context.Context, warehouseID, productID, variantSKU string, delta int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := stockLevelKey{warehouseID, productID, variantSKU}
+	level, ok := r.levels[key]
+	if !ok {
+		level = domain.StockLevel{WarehouseID: warehouseID, ProductID: productID, VariantSKU: variantSKU}
+	}
+	if level.Quantity+delta < 0 {
+		return domain.ErrInsufficientStock
+	}
+
+	level.Quantity += delta
+	level.UpdatedAt = time.Now()
+	change := domain.StockChange{ProductID: productID, VariantSKU: variantSKU, WarehouseID: warehouseID, Delta: delta, Stock: level.Quantity}
+	if err := r.outbox.add(domain.EventStockAdjusted, productID, change); err != nil {
+		return err
+	}
+	r.levels[key] = level
+
+	return nil
+}
+
+func (r *memoryStockLevelRepository) DeleteByProduct(ctx context.Context, productID string) error {
+	r.delete(func(level domain.StockLevel) bool { return level.ProductID == productID })
+	return nil
+}
+
+func (r *memoryStockLevelRepository) DeleteByVariant(ctx context.Context, sku string) error {
+	r.delete(func(level domain.StockLevel) bool { return level.VariantSKU == sku })
+	return nil
+}
+
+func (r *memoryStockLevelRepository) DeleteByWarehouse(ctx context.Context, warehouseID string) error {
+	r.delete(func(level domain.StockLevel) bool { return level.WarehouseID == warehouseID })
+	return nil
+}
+
+func (r *memoryStockLevelRepository) list(match func(domain.StockLevel) bool) []*domain.StockLevel {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	levels := []*domain.StockLevel{}
+	for _, level := range r.levels {
+		if match(level) {
+			level := level
+			levels = append(levels, &level)
+		}
+	}
+	return levels
+}
+
+func (r *memoryStockLevelRepository) delete(match func(domain.StockLevel) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, level := range r.levels {
+		if match(level) {
+			delete(r.levels, key)
+		}
+	}
+}
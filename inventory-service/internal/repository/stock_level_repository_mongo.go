@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"inventory-service/internal/domain"
+)
+
+type stockLevelRepository struct {
+	collection *mongo.Collection
+}
+
+type stockLevelDocument struct {
+	WarehouseID string    `bson:"warehouse_id"`
+	ProductID   string    `bson:"product_id"`
+	VariantSKU  string    `bson:"variant_sku"`
+	Quantity    int       `bson:"quantity"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+}
+
+func NewMongoStockLevelRepository(db *mongo.Database) StockLevelRepository {
+	return &stockLevelRepository{
+		collection: db.Collection("stock_levels"),
+	}
+}
+
+func (r *stockLevelRepository) ListByProduct(ctx context.Context, productID string) ([]*domain.StockLevel, error) {
+	return r.list(ctx, bson.M{"product_id": productID}, bson.D{{Key: "warehouse_id", Value: 1}, {Key: "variant_sku", Value: 1}})
+}
+
+func (r *stockLevelRepository) ListByWarehouse(ctx context.Context, warehouseID string) ([]*domain.StockLevel, error) {
+	return r.list(ctx, bson.M{"warehouse_id": warehouseID}, bson.D{{Key: "product_id", Value: 1}, {Key: "variant_sku", Value: 1}})
+}
+
+func (r *stockLevelRepository) Adjust(ctx context.Context, warehouseID, productID, variantSKU string, delta int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"warehouse_id": warehouseID, "product_id": productID, "variant_sku": variantSKU}
+	update := bson.M{
+		"$inc": bson.M{"quantity": delta},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if delta >= 0 {
+		opts.SetUpsert(true)
+	} else {
+		// A missing level holds nothing, so it cannot be drawn on either
+		filter["quantity"] = bson.M{"$gte": -delta}
+	}
+
+	return inTransaction(ctx, r.collection.Database(), func(ctx mongo.SessionContext) error {
+		var level stockLevelDocument
+		err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&level)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.ErrInsufficientStock
+		}
+		if err != nil {
+			return err
+		}
+
+		change := domain.StockChange{ProductID: productID, VariantSKU: variantSKU, WarehouseID: warehouseID, Delta: delta, Stock: level.Quantity}
+		return writeEvent(ctx, r.collection.Database(), domain.EventStockAdjusted, productID, change)
+	})
+}
+
+func (r *stockLevelRepository) DeleteByProduct(ctx context.Context, productID string) error {
+	return r.delete(ctx, bson.M{"product_id": productID})
+}
+
+func (r *stockLevelRepository) DeleteByVariant(ctx context.Context, sku string) error {
+	return r.delete(ctx, bson.M{"variant_sku": sku})
+}
+
+func (r *stockLevelRepository) DeleteByWarehouse(ctx context.Context, warehouseID string) error {
+	return r.delete(ctx, bson.M{"warehouse_id": warehouseID})
+}
+
+func (r *stockLevelRepository) list(ctx context.Context, filter bson.M, sort bson.D) ([]*domain.StockLevel, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	levels := []*domain.StockLevel{}
+	for cursor.Next(ctx) {
+		var result stockLevelDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		levels = append(levels, &domain.StockLevel{
+			WarehouseID: result.WarehouseID,
+			ProductID:   result.ProductID,
+			VariantSKU:  result.VariantSKU,
+			Quantity:    result.Quantity,
+			UpdatedAt:   result.UpdatedAt,
+		})
+	}
+
+	return levels, cursor.Err()
+}
+
+func (r *stockLevelRepository) delete(ctx context.Context, filter bson.M) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, filter)
+	return err
+}
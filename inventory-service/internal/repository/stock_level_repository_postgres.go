@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"inventory-service/internal/domain"
+)
+
+type postgresStockLevelRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresStockLevelRepository(db *sql.DB) StockLevelRepository {
+	return &postgresStockLevelRepository{db: db}
+}
+
+const stockLevelColumns = `warehouse_id, product_id, variant_sku, quantity, updated_at`
+
+func (r *postgresStockLevelRepository) ListByProduct(ctx context.Context, productID string) ([]*domain.StockLevel, error) {
+	return r.list(ctx, `WHERE product_id = $1 ORDER BY warehouse_id, variant_sku`, productID)
+}
+
+func (r *postgresStockLevelRepository) ListByWarehouse(ctx context.Context, warehouseID string) ([]*domain.StockLevel, error) {
+	return r.list(ctx, `WHERE warehouse_id = $1 ORDER BY product_id, variant_sku`, warehouseID)
+}
+
+func (r *postgresStockLevelRepository) Adjust(ctx context.Context, warehouseID, productID, variantSKU string, delta int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO stock_levels (` + stockLevelColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (warehouse_id, product_id, variant_sku)
+		DO UPDATE SET quantity = stock_levels.quantity + EXCLUDED.quantity, updated_at = EXCLUDED.updated_at
+		RETURNING quantity`
+	if delta < 0 {
+		// A missing level holds nothing, so it cannot be drawn on either
+		query = `
+			UPDATE stock_levels SET quantity = quantity + $4, updated_at = $5
+			WHERE warehouse_id = $1 AND product_id = $2 AND variant_sku = $3 AND quantity + $4 >= 0
+			RETURNING quantity`
+	}
+
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		var quantity int
+		err := tx.QueryRowContext(ctx, query, warehouseID, productID, variantSKU, delta, time.Now()).Scan(&quantity)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrInsufficientStock
+		}
+		if err != nil {
+			return err
+		}
+
+		change := domain.StockChange{ProductID: productID, VariantSKU: variantSKU, WarehouseID: warehouseID, Delta: delta, Stock: quantity}
+		return writeEventTx(ctx, tx, domain.EventStockAdjusted, productID, change)
+	})
+}
+
+func (r *postgresStockLevelRepository) DeleteByProduct(ctx context.Context, productID string) error {
+	return r.delete(ctx, `product_id = $1`, productID)
+}
+
+func (r *postgresStockLevelRepository) DeleteByVariant(ctx context.Context, sku string) error {
+	return r.delete(ctx, `variant_sku = $1`, sku)
+}
+
+func (r *postgresStockLevelRepository) DeleteByWarehouse(ctx context.Context, warehouseID string) error {
+	return r.delete(ctx, `warehouse_id = $1`, warehouseID)
+}
+
+func (r *postgresStockLevelRepository) list(ctx context.Context, clause string, arg string) ([]*domain.StockLevel, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+stockLevelColumns+` FROM stock_levels `+clause, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := []*domain.StockLevel{}
+	for rows.Next() {
+		var level domain.StockLevel
+		if err := rows.Scan(&level.WarehouseID, &level.ProductID, &level.VariantSKU, &level.Quantity, &level.UpdatedAt); err != nil {
+			return nil, err
+		}
+		levels = append(levels, &level)
+	}
+
+	return levels, rows.Err()
+}
+
+func (r *postgresStockLevelRepository) delete(ctx context.Context, condition string, arg string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM stock_levels WHERE `+condition, arg)
+	return err
+}
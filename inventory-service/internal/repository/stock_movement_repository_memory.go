@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type memoryStockMovementRepository struct {
+	mu        sync.RWMutex
+	movements []domain.StockMovement
+}
+
+// NewMemoryStockMovementRepository returns a thread-safe
+// StockMovementRepository that keeps everything in process memory.
+func NewMemoryStockMovementRepository() StockMovementRepository {
+	return &memoryStockMovementRepository{}
+}
+
+func (r *memoryStockMovementRepository) Append(ctx context.Context, movement *domain.StockMovement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	movement.ID = primitive.NewObjectID().Hex()
+	movement.CreatedAt = time.Now()
+	r.movements = append(r.movements, *movement)
+
+	return nil
+}
+
+func (r *memoryStockMovementRepository) List(ctx context.Context, filter domain.MovementFilter, after *domain.PageCursor, limit int) ([]*domain.StockMovement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*domain.StockMovement
+	for _, movement := range r.movements {
+		if !matchMovement(movement, filter) {
+			continue
+		}
+		movement := movement
+		matched = append(matched, &movement)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return newerMovement(matched[i], matched[j])
+	})
+
+	if after != nil {
+		cursor := &domain.StockMovement{CreatedAt: after.CreatedAt, ID: after.ID}
+		start := sort.Search(len(matched), func(i int) bool {
+			return newerMovement(cursor, matched[i])
+		})
+		matched = matched[start:]
+	}
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	return append([]*domain.StockMovement{}, matched...), nil
+}
+
+func (r *memoryStockMovementRepository) Balances(ctx context.Context, productID string) ([]domain.StockBalance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type key struct{ sku, warehouseID string }
+	sums := map[key]int{}
+	for _, movement := range r.movements {
+		if movement.ProductID == productID {
+			sums[key{movement.VariantSKU, movement.WarehouseID}] += movement.Delta
+		}
+	}
+
+	balances := make([]domain.StockBalance, 0, len(sums))
+	for k, quantity := range sums {
+		balances = append(balances, domain.StockBalance{VariantSKU: k.sku, WarehouseID: k.warehouseID, Quantity: quantity})
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].VariantSKU != balances[j].VariantSKU {
+			return balances[i].VariantSKU < balances[j].VariantSKU
+		}
+		return balances[i].WarehouseID < balances[j].WarehouseID
+	})
+
+	return balances, nil
+}
+
+func matchMovement(movement domain.StockMovement, filter domain.MovementFilter) bool {
+	return (filter.ProductID == "" || movement.ProductID == filter.ProductID) &&
+		(filter.VariantSKU == "" || movement.VariantSKU == filter.VariantSKU) &&
+		(filter.WarehouseID == "" || movement.WarehouseID == filter.WarehouseID) &&
+		(filter.OrderID == "" || movement.OrderID == filter.OrderID) &&
+		(filter.Reason == "" || movement.Reason == filter.Reason)
+}
+
+// newerMovement reports whether a sorts before b in ledger order: newest
+// first, ties broken by descending ID.
+func newerMovement(a, b *domain.StockMovement) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"inventory-service/internal/domain"
+)
+
+type stockMovementRepository struct {
+	collection *mongo.Collection
+}
+
+type stockMovementDocument struct {
+	ID          string    `bson:"_id"`
+	ProductID   string    `bson:"product_id"`
+	VariantSKU  string    `bson:"variant_sku"`
+	WarehouseID string    `bson:"warehouse_id"`
+	Delta       int       `bson:"delta"`
+	Reason      string    `bson:"reason"`
+	Actor       string    `bson:"actor"`
+	OrderID     string    `bson:"order_id,omitempty"`
+	Note        string    `bson:"note,omitempty"`
+	CreatedAt   time.Time `bson:"created_at"`
+}
+
+func NewMongoStockMovementRepository(db *mongo.Database) StockMovementRepository {
+	return &stockMovementRepository{
+		collection: db.Collection("stock_movements"),
+	}
+}
+
+func (r *stockMovementRepository) Append(ctx context.Context, movement *domain.StockMovement) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	movement.ID = primitive.NewObjectID().Hex()
+	movement.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, stockMovementDocument{
+		ID:          movement.ID,
+		ProductID:   movement.ProductID,
+		VariantSKU:  movement.VariantSKU,
+		WarehouseID: movement.WarehouseID,
+		Delta:       movement.Delta,
+		Reason:      string(movement.Reason),
+		Actor:       movement.Actor,
+		OrderID:     movement.OrderID,
+		Note:        movement.Note,
+		CreatedAt:   movement.CreatedAt,
+	})
+
+	return err
+}
+
+func (r *stockMovementRepository) List(ctx context.Context, filter domain.MovementFilter, after *domain.PageCursor, limit int) ([]*domain.StockMovement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := bson.M{}
+	if filter.ProductID != "" {
+		query["product_id"] = filter.ProductID
+	}
+	if filter.VariantSKU != "" {
+		query["variant_sku"] = filter.VariantSKU
+	}
+	if filter.WarehouseID != "" {
+		query["warehouse_id"] = filter.WarehouseID
+	}
+	if filter.OrderID != "" {
+		query["order_id"] = filter.OrderID
+	}
+	if filter.Reason != "" {
+		query["reason"] = string(filter.Reason)
+	}
+	if after != nil {
+		query["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": after.CreatedAt}},
+			bson.M{"created_at": after.CreatedAt, "_id": bson.M{"$lt": after.ID}},
+		}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	movements := []*domain.StockMovement{}
+	for cursor.Next(ctx) {
+		var result stockMovementDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		movements = append(movements, &domain.StockMovement{
+			ID:          result.ID,
+			ProductID:   result.ProductID,
+			VariantSKU:  result.VariantSKU,
+			WarehouseID: result.WarehouseID,
+			Delta:       result.Delta,
+			Reason:      domain.MovementReason(result.Reason),
+			Actor:       result.Actor,
+			OrderID:     result.OrderID,
+			Note:        result.Note,
+			CreatedAt:   result.CreatedAt,
+		})
+	}
+
+	return movements, cursor.Err()
+}
+
+func (r *stockMovementRepository) Balances(ctx context.Context, productID string) ([]domain.StockBalance, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"product_id": productID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      bson.M{"variant_sku": "$variant_sku", "warehouse_id": "$warehouse_id"},
+			"quantity": bson.M{"$sum": "$delta"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.variant_sku", Value: 1}, {Key: "_id.warehouse_id", Value: 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	balances := []domain.StockBalance{}
+	for cursor.Next(ctx) {
+		var result struct {
+			ID struct {
+				VariantSKU  string `bson:"variant_sku"`
+				WarehouseID string `bson:"warehouse_id"`
+			} `bson:"_id"`
+			Quantity int `bson:"quantity"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		balances = append(balances, domain.StockBalance{
+			VariantSKU:  result.ID.VariantSKU,
+			WarehouseID: result.ID.WarehouseID,
+			Quantity:    result.Quantity,
+		})
+	}
+
+	return balances, cursor.Err()
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type postgresStockMovementRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresStockMovementRepository(db *sql.DB) StockMovementRepository {
+	return &postgresStockMovementRepository{db: db}
+}
+
+const stockMovementColumns = `id, product_id, variant_sku, warehouse_id, delta, reason, actor, order_id, note, created_at`
+
+func (r *postgresStockMovementRepository) Append(ctx context.Context, movement *domain.StockMovement) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	movement.ID = primitive.NewObjectID().Hex()
+	movement.CreatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO stock_movements (`+stockMovementColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		movement.ID, movement.ProductID, movement.VariantSKU, movement.WarehouseID, movement.Delta,
+		string(movement.Reason), movement.Actor, movement.OrderID, movement.Note, movement.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *postgresStockMovementRepository) List(ctx context.Context, filter domain.MovementFilter, after *domain.PageCursor, limit int) ([]*domain.StockMovement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ProductID != "" {
+		where(`product_id = $%d`, filter.ProductID)
+	}
+	if filter.VariantSKU != "" {
+		where(`variant_sku = $%d`, filter.VariantSKU)
+	}
+	if filter.WarehouseID != "" {
+		where(`warehouse_id = $%d`, filter.WarehouseID)
+	}
+	if filter.OrderID != "" {
+		where(`order_id = $%d`, filter.OrderID)
+	}
+	if filter.Reason != "" {
+		where(`reason = $%d`, string(filter.Reason))
+	}
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		conditions = append(conditions, fmt.Sprintf(`(created_at, id) < ($%d, $%d)`, len(args)-1, len(args)))
+	}
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+stockMovementColumns+` FROM stock_movements`+whereClause(conditions)+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT $`+fmt.Sprint(len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := []*domain.StockMovement{}
+	for rows.Next() {
+		var (
+			m      domain.StockMovement
+			reason string
+		)
+		err := rows.Scan(&m.ID, &m.ProductID, &m.VariantSKU, &m.WarehouseID, &m.Delta, &reason, &m.Actor, &m.OrderID, &m.Note, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		m.Reason = domain.MovementReason(reason)
+		movements = append(movements, &m)
+	}
+
+	return movements, rows.Err()
+}
+
+func (r *postgresStockMovementRepository) Balances(ctx context.Context, productID string) ([]domain.StockBalance, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT variant_sku, warehouse_id, sum(delta) FROM stock_movements
+		WHERE product_id = $1
+		GROUP BY variant_sku, warehouse_id
+		ORDER BY variant_sku, warehouse_id`,
+		productID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := []domain.StockBalance{}
+	for rows.Next() {
+		var balance domain.StockBalance
+		if err := rows.Scan(&balance.VariantSKU, &balance.WarehouseID, &balance.Quantity); err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+
+	return balances, rows.Err()
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type memorySupplierRepository struct {
+	mu        sync.RWMutex
+	suppliers map[string]domain.Supplier
+}
+
+// NewMemorySupplierRepository returns a thread-safe SupplierRepository
+// that keeps everything in process memory.
+func NewMemorySupplierRepository() SupplierRepository {
+	return &memorySupplierRepository{
+		suppliers: map[string]domain.Supplier{},
+	}
+}
+
+func (r *memorySupplierRepository) Create(ctx context.Context, supplier *domain.Supplier) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if supplier.ID == "" {
+		supplier.ID = primitive.NewObjectID().Hex()
+	}
+	if _, ok := r.suppliers[supplier.ID]; ok {
+		return domain.ErrSupplierExists
+	}
+
+	stored := *supplier
+	stored.CreatedAt = time.Now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.suppliers[stored.ID] = stored
+
+	return nil
+}
+
+func (r *memorySupplierRepository) FindByID(ctx context.Context, id string) (*domain.Supplier, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	supplier, ok := r.suppliers[id]
+	if !ok {
+		return nil, domain.ErrSupplierNotFound
+	}
+
+	return &supplier, nil
+}
+
+func (r *memorySupplierRepository) Update(ctx context.Context, supplier *domain.Supplier) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.suppliers[supplier.ID]
+	if !ok {
+		return domain.ErrSupplierNotFound
+	}
+
+	existing.Name = supplier.Name
+	existing.Email = supplier.Email
+	existing.Phone = supplier.Phone
+	existing.UpdatedAt = time.Now()
+	r.suppliers[supplier.ID] = existing
+
+	return nil
+}
+
+func (r *memorySupplierRepository) List(ctx context.Context) ([]*domain.Supplier, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	suppliers := make([]*domain.Supplier, 0, len(r.suppliers))
+	for _, supplier := range r.suppliers {
+		supplier := supplier
+		suppliers = append(suppliers, &supplier)
+	}
+
+	sort.Slice(suppliers, func(i, j int) bool {
+		if suppliers[i].Name != suppliers[j].Name {
+			return suppliers[i].Name < suppliers[j].Name
+		}
+		return suppliers[i].ID < suppliers[j].ID
+	})
+
+	return suppliers, nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"inventory-service/internal/domain"
+)
+
+type supplierRepository struct {
+	collection *mongo.Collection
+}
+
+type supplierDocument struct {
+	ID        string    `bson:"_id"`
+	Name      string    `bson:"name"`
+	Email     string    `bson:"email"`
+	Phone     string    `bson:"phone"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+func NewMongoSupplierRepository(db *mongo.Database) SupplierRepository {
+	return &supplierRepository{
+		collection: db.Collection("suppliers"),
+	}
+}
+
+func (r *supplierRepository) Create(ctx context.Context, supplier *domain.Supplier) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if supplier.ID == "" {
+		supplier.ID = primitive.NewObjectID().Hex()
+	}
+
+	now := time.Now()
+	_, err := r.collection.InsertOne(ctx, supplierDocument{
+		ID:        supplier.ID,
+		Name:      supplier.Name,
+		Email:     supplier.Email,
+		Phone:     supplier.Phone,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrSupplierExists
+	}
+
+	return err
+}
+
+func (r *supplierRepository) FindByID(ctx context.Context, id string) (*domain.Supplier, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var result supplierDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrSupplierNotFound
+		}
+		return nil, err
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *supplierRepository) Update(ctx context.Context, supplier *domain.Supplier) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateByID(ctx, supplier.ID, bson.M{
+		"$set": bson.M{
+			"name":       supplier.Name,
+			"email":      supplier.Email,
+			"phone":      supplier.Phone,
+			"updated_at": time.Now(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrSupplierNotFound
+	}
+
+	return nil
+}
+
+func (r *supplierRepository) List(ctx context.Context) ([]*domain.Supplier, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	suppliers := []*domain.Supplier{}
+	for cursor.Next(ctx) {
+		var result supplierDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, result.toDomain())
+	}
+
+	return suppliers, cursor.Err()
+}
+
+func (d *supplierDocument) toDomain() *domain.Supplier {
+	return &domain.Supplier{
+		ID:        d.ID,
+		Name:      d.Name,
+		Email:     d.Email,
+		Phone:     d.Phone,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type postgresSupplierRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresSupplierRepository(db *sql.DB) SupplierRepository {
+	return &postgresSupplierRepository{db: db}
+}
+
+const supplierColumns = `id, name, email, phone, created_at, updated_at`
+
+func (r *postgresSupplierRepository) Create(ctx context.Context, supplier *domain.Supplier) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if supplier.ID == "" {
+		supplier.ID = primitive.NewObjectID().Hex()
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO suppliers (`+supplierColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $5)`,
+		supplier.ID, supplier.Name, supplier.Email, supplier.Phone, time.Now(),
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrSupplierExists
+	}
+
+	return err
+}
+
+func (r *postgresSupplierRepository) FindByID(ctx context.Context, id string) (*domain.Supplier, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+supplierColumns+` FROM suppliers WHERE id = $1`, id)
+	supplier, err := scanSupplier(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrSupplierNotFound
+	}
+
+	return supplier, err
+}
+
+func (r *postgresSupplierRepository) Update(ctx context.Context, supplier *domain.Supplier) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE suppliers SET name = $2, email = $3, phone = $4, updated_at = $5
+		WHERE id = $1`,
+		supplier.ID, supplier.Name, supplier.Email, supplier.Phone, time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, domain.ErrSupplierNotFound)
+}
+
+func (r *postgresSupplierRepository) List(ctx context.Context) ([]*domain.Supplier, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+supplierColumns+` FROM suppliers ORDER BY name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppliers := []*domain.Supplier{}
+	for rows.Next() {
+		supplier, err := scanSupplier(rows)
+		if err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, supplier)
+	}
+
+	return suppliers, rows.Err()
+}
+
+func scanSupplier(row rowScanner) (*domain.Supplier, error) {
+	var s domain.Supplier
+	if err := row.Scan(&s.ID, &s.Name, &s.Email, &s.Phone, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
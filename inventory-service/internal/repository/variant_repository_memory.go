@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"inventory-service/internal/domain"
+)
+
+type memoryVariantRepository struct {
+	mu       sync.RWMutex
+	variants map[string]domain.Variant
+	outbox   *MemoryOutbox
+}
+
+// NewMemoryVariantRepository returns a thread-safe VariantRepository that
+// keeps everything in process memory and writes its events to outbox.
+func NewMemoryVariantRepository(outbox *MemoryOutbox) VariantRepository {
+	return &memoryVariantRepository{
+		variants: map[string]domain.Variant{},
+		outbox:   outbox,
+	}
+}
+
+func (r *memoryVariantRepository) Create(ctx context.Context, variant *domain.Variant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.variants[variant.SKU]; ok {
+		return domain.ErrVariantExists
+	}
+
+	stored := copyVariant(*variant)
+	stored.CreatedAt = time.Now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.variants[stored.SKU] = stored
+
+	return nil
+}
+
+func (r *memoryVariantRepository) FindBySKU(ctx context.Context, sku string) (*domain.Variant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	variant, ok := r.variants[sku]
+	if !ok {
+		return nil, domain.ErrVariantNotFound
+	}
+
+	variant = copyVariant(variant)
+	return &variant, nil
+}
+
+func (r *memoryVariantRepository) Update(ctx context.Context, variant *domain.Variant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.variants[variant.SKU]
+	if !ok {
+		return domain.ErrVariantNotFound
+	}
+
+	existing.Attributes = maps.Clone(variant.Attributes)
+	existing.Price = variant.Price
+	existing.UpdatedAt = time.Now()
+	r.variants[variant.SKU] = existing
+
+	return nil
+}
+
+func (r *memoryVariantRepository) Delete(ctx context.Context, sku string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.variants[sku]; !ok {
+		return domain.ErrVariantNotFound
+	}
+	delete(r.variants, sku)
+
+	return nil
+}
+
+func (r *memoryVariantRepository) DeleteByProduct(ctx context.Context, productID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for sku, variant := range r.variants {
+		if variant.ProductID == productID {
+			delete(r.variants, sku)
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryVariantRepository) ListByProduct(ctx context.Context, productID string) ([]*domain.Variant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	variants := []*domain.Variant{}
+	for _, variant := range r.variants {
+		if variant.ProductID != productID {
+			continue
+		}
+		variant = copyVariant(variant)
+		variants = append(variants, &variant)
+	}
+
+	sort.Slice(variants, func(i, j int) bool {
+		return variants[i].SKU < variants[j].SKU
+	})
+
+	return variants, nil
+}
+
+func (r *memoryVariantRepository) AdjustStock(ctx context.Context, sku string, delta int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	variant, ok := r.variants[sku]
+	if !ok {
+		return 0, domain.ErrVariantNotFound
+	}
+	if variant.Stock+delta < 0 {
+		return 0, domain.ErrInsufficientStock
+	}
+
+	variant.Stock += delta
+	variant.UpdatedAt = time.Now()
+	change := domain.StockChange{ProductID: variant.ProductID, VariantSKU: sku, Delta: delta, Stock: variant.Stock}
+	if err := r.outbox.add(domain.EventStockAdjusted, variant.ProductID, change); err != nil {
+		return 0, err
+	}
+	r.variants[sku] = variant
+
+	return variant.Stock, nil
+}
+
+// copyVariant detaches the attribute map so callers cannot mutate stored
+// state.
+func copyVariant(variant domain.Variant) domain.Variant {
+	variant.Attributes = maps.Clone(variant.Attributes)
+	return variant
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"inventory-service/internal/domain"
+)
+
+type variantRepository struct {
+	collection *mongo.Collection
+}
+
+type variantDocument struct {
+	SKU        string            `bson:"_id"`
+	ProductID  string            `bson:"product_id"`
+	Attributes map[string]string `bson:"attributes"`
+	Price      float64           `bson:"price"`
+	Stock      int               `bson:"stock"`
+	CreatedAt  time.Time         `bson:"created_at"`
+	UpdatedAt  time.Time         `bson:"updated_at"`
+}
+
+func NewMongoVariantRepository(db *mongo.Database) VariantRepository {
+	return &variantRepository{
+		collection: db.Collection("variants"),
+	}
+}
+
+func (r *variantRepository) Create(ctx context.Context, variant *domain.Variant) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	_, err := r.collection.InsertOne(ctx, variantDocument{
+		SKU:        variant.SKU,
+		ProductID:  variant.ProductID,
+		Attributes: variant.Attributes,
+		Price:      variant.Price,
+		Stock:      variant.Stock,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrVariantExists
+	}
+
+	return err
+}
+
+func (r *variantRepository) FindBySKU(ctx context.Context, sku string) (*domain.Variant, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var result variantDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": sku}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrVariantNotFound
+		}
+		return nil, err
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *variantRepository) Update(ctx context.Context, variant *domain.Variant) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateByID(ctx, variant.SKU, bson.M{
+		"$set": bson.M{
+			"attributes": variant.Attributes,
+			"price":      variant.Price,
+			"updated_at": time.Now(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func (r *variantRepository) Delete(ctx context.Context, sku string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": sku})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func (r *variantRepository) DeleteByProduct(ctx context.Context, productID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"product_id": productID})
+	return err
+}
+
+func (r *variantRepository) ListByProduct(ctx context.Context, productID string) ([]*domain.Variant, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"product_id": productID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	variants := []*domain.Variant{}
+	for cursor.Next(ctx) {
+		var result variantDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		variants = append(variants, result.toDomain())
+	}
+
+	return variants, cursor.Err()
+}
+
+func (r *variantRepository) AdjustStock(ctx context.Context, sku string, delta int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var result variantDocument
+	err := inTransaction(ctx, r.collection.Database(), func(ctx mongo.SessionContext) error {
+		// Same guard as for products: never drive stock below zero
+		err := r.collection.FindOneAndUpdate(ctx,
+			bson.M{"_id": sku, "stock": bson.M{"$gte": -delta}},
+			bson.M{
+				"$inc": bson.M{"stock": delta},
+				"$set": bson.M{"updated_at": time.Now()},
+			},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&result)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			count, err := r.collection.CountDocuments(ctx, bson.M{"_id": sku})
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return domain.ErrVariantNotFound
+			}
+			return domain.ErrInsufficientStock
+		}
+		if err != nil {
+			return err
+		}
+
+		change := domain.StockChange{ProductID: result.ProductID, VariantSKU: sku, Delta: delta, Stock: result.Stock}
+		return writeEvent(ctx, r.collection.Database(), domain.EventStockAdjusted, result.ProductID, change)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Stock, nil
+}
+
+func (d *variantDocument) toDomain() *domain.Variant {
+	return &domain.Variant{
+		SKU:        d.SKU,
+		ProductID:  d.ProductID,
+		Attributes: d.Attributes,
+		Price:      d.Price,
+		Stock:      d.Stock,
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+	}
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"inventory-service/internal/domain"
+)
+
+type postgresVariantRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresVariantRepository(db *sql.DB) VariantRepository {
+	return &postgresVariantRepository{db: db}
+}
+
+const variantColumns = `sku, product_id, attributes, price, stock, created_at, updated_at`
+
+func (r *postgresVariantRepository) Create(ctx context.Context, variant *domain.Variant) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	attributes, err := marshalAttributes(variant.Attributes)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO variants (`+variantColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)`,
+		variant.SKU, variant.ProductID, attributes, variant.Price, variant.Stock, now,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrVariantExists
+	}
+
+	return err
+}
+
+func (r *postgresVariantRepository) FindBySKU(ctx context.Context, sku string) (*domain.Variant, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+variantColumns+` FROM variants WHERE sku = $1`, sku)
+	variant, err := scanVariant(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrVariantNotFound
+	}
+
+	return variant, err
+}
+
+func (r *postgresVariantRepository) Update(ctx context.Context, variant *domain.Variant) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	attributes, err := marshalAttributes(variant.Attributes)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE variants
+		SET attributes = $2, price = $3, updated_at = $4
+		WHERE sku = $1`,
+		variant.SKU, attributes, variant.Price, time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, domain.ErrVariantNotFound)
+}
+
+func (r *postgresVariantRepository) Delete(ctx context.Context, sku string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM variants WHERE sku = $1`, sku)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, domain.ErrVariantNotFound)
+}
+
+func (r *postgresVariantRepository) DeleteByProduct(ctx context.Context, productID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM variants WHERE product_id = $1`, productID)
+	return err
+}
+
+func (r *postgresVariantRepository) ListByProduct(ctx context.Context, productID string) ([]*domain.Variant, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+variantColumns+` FROM variants
+		WHERE product_id = $1
+		ORDER BY sku`,
+		productID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := []*domain.Variant{}
+	for rows.Next() {
+		variant, err := scanVariant(rows)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+
+	return variants, rows.Err()
+}
+
+func (r *postgresVariantRepository) AdjustStock(ctx context.Context, sku string, delta int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var (
+		stock     int
+		productID string
+	)
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		// Same guard as for products: never drive stock below zero
+		err := tx.QueryRowContext(ctx, `
+			UPDATE variants SET stock = stock + $2, updated_at = $3
+			WHERE sku = $1 AND stock + $2 >= 0
+			RETURNING stock, product_id`,
+			sku, delta, time.Now(),
+		).Scan(&stock, &productID)
+		if errors.Is(err, sql.ErrNoRows) {
+			var exists bool
+			if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM variants WHERE sku = $1)`, sku).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return domain.ErrVariantNotFound
+			}
+			return domain.ErrInsufficientStock
+		}
+		if err != nil {
+			return err
+		}
+
+		change := domain.StockChange{ProductID: productID, VariantSKU: sku, Delta: delta, Stock: stock}
+		return writeEventTx(ctx, tx, domain.EventStockAdjusted, productID, change)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+func scanVariant(row rowScanner) (*domain.Variant, error) {
+	var (
+		v          domain.Variant
+		attributes []byte
+	)
+	err := row.Scan(&v.SKU, &v.ProductID, &attributes, &v.Price, &v.Stock, &v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(attributes, &v.Attributes); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// marshalAttributes stores missing attributes as an empty object.
+func marshalAttributes(attributes map[string]string) ([]byte, error) {
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	return json.Marshal(attributes)
+}
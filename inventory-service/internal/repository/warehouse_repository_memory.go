@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type memoryWarehouseRepository struct {
+	mu         sync.RWMutex
+	warehouses map[string]domain.Warehouse
+}
+
+// NewMemoryWarehouseRepository returns a thread-safe WarehouseRepository
+// that keeps everything in process memory.
+func NewMemoryWarehouseRepository() WarehouseRepository {
+	return &memoryWarehouseRepository{
+		warehouses: map[string]domain.Warehouse{},
+	}
+}
+
+func (r *memoryWarehouseRepository) Create(ctx context.Context, warehouse *domain.Warehouse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if warehouse.ID == "" {
+		warehouse.ID = primitive.NewObjectID().Hex()
+	}
+	if _, ok := r.warehouses[warehouse.ID]; ok {
+		return domain.ErrWarehouseExists
+	}
+
+	stored := *warehouse
+	stored.CreatedAt = time.Now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.warehouses[stored.ID] = stored
+
+	return nil
+}
+
+func (r *memoryWarehouseRepository) FindByID(ctx context.Context, id string) (*domain.Warehouse, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	warehouse, ok := r.warehouses[id]
+	if !ok {
+		return nil, domain.ErrWarehouseNotFound
+	}
+
+	return &warehouse, nil
+}
+
+func (r *memoryWarehouseRepository) Update(ctx context.Context, warehouse *domain.Warehouse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.warehouses[warehouse.ID]
+	if !ok {
+		return domain.ErrWarehouseNotFound
+	}
+
+	existing.Name = warehouse.Name
+	existing.Location = warehouse.Location
+	existing.Priority = warehouse.Priority
+	existing.Active = warehouse.Active
+	existing.UpdatedAt = time.Now()
+	r.warehouses[warehouse.ID] = existing
+
+	return nil
+}
+
+func (r *memoryWarehouseRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.warehouses[id]; !ok {
+		return domain.ErrWarehouseNotFound
+	}
+	delete(r.warehouses, id)
+
+	return nil
+}
+
+func (r *memoryWarehouseRepository) List(ctx context.Context) ([]*domain.Warehouse, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	warehouses := make([]*domain.Warehouse, 0, len(r.warehouses))
+	for _, warehouse := range r.warehouses {
+		warehouse := warehouse
+		warehouses = append(warehouses, &warehouse)
+	}
+
+	sort.Slice(warehouses, func(i, j int) bool {
+		if warehouses[i].Priority != warehouses[j].Priority {
+			return warehouses[i].Priority < warehouses[j].Priority
+		}
+		return warehouses[i].ID < warehouses[j].ID
+	})
+
+	return warehouses, nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"inventory-service/internal/domain"
+)
+
+type warehouseRepository struct {
+	collection *mongo.Collection
+}
+
+type warehouseDocument struct {
+	ID        string    `bson:"_id"`
+	Name      string    `bson:"name"`
+	Latitude  float64   `bson:"latitude"`
+	Longitude float64   `bson:"longitude"`
+	Priority  int       `bson:"priority"`
+	Active    bool      `bson:"active"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+func NewMongoWarehouseRepository(db *mongo.Database) WarehouseRepository {
+	return &warehouseRepository{
+		collection: db.Collection("warehouses"),
+	}
+}
+
+func (r *warehouseRepository) Create(ctx context.Context, warehouse *domain.Warehouse) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if warehouse.ID == "" {
+		warehouse.ID = primitive.NewObjectID().Hex()
+	}
+
+	now := time.Now()
+	_, err := r.collection.InsertOne(ctx, warehouseDocument{
+		ID:        warehouse.ID,
+		Name:      warehouse.Name,
+		Latitude:  warehouse.Location.Latitude,
+		Longitude: warehouse.Location.Longitude,
+		Priority:  warehouse.Priority,
+		Active:    warehouse.Active,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrWarehouseExists
+	}
+
+	return err
+}
+
+func (r *warehouseRepository) FindByID(ctx context.Context, id string) (*domain.Warehouse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var result warehouseDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrWarehouseNotFound
+		}
+		return nil, err
+	}
+
+	return result.toDomain(), nil
+}
+
+func (r *warehouseRepository) Update(ctx context.Context, warehouse *domain.Warehouse) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.UpdateByID(ctx, warehouse.ID, bson.M{
+		"$set": bson.M{
+			"name":       warehouse.Name,
+			"latitude":   warehouse.Location.Latitude,
+			"longitude":  warehouse.Location.Longitude,
+			"priority":   warehouse.Priority,
+			"active":     warehouse.Active,
+			"updated_at": time.Now(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrWarehouseNotFound
+	}
+
+	return nil
+}
+
+func (r *warehouseRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrWarehouseNotFound
+	}
+
+	return nil
+}
+
+func (r *warehouseRepository) List(ctx context.Context) ([]*domain.Warehouse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "priority", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	warehouses := []*domain.Warehouse{}
+	for cursor.Next(ctx) {
+		var result warehouseDocument
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, result.toDomain())
+	}
+
+	return warehouses, cursor.Err()
+}
+
+func (d *warehouseDocument) toDomain() *domain.Warehouse {
+	return &domain.Warehouse{
+		ID:        d.ID,
+		Name:      d.Name,
+		Location:  domain.GeoPoint{Latitude: d.Latitude, Longitude: d.Longitude},
+		Priority:  d.Priority,
+		Active:    d.Active,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
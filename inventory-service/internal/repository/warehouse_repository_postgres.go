@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"inventory-service/internal/domain"
+)
+
+type postgresWarehouseRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresWarehouseRepository(db *sql.DB) WarehouseRepository {
+	return &postgresWarehouseRepository{db: db}
+}
+
+const warehouseColumns = `id, name, latitude, longitude, priority, active, created_at, updated_at`
+
+func (r *postgresWarehouseRepository) Create(ctx context.Context, warehouse *domain.Warehouse) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if warehouse.ID == "" {
+		warehouse.ID = primitive.NewObjectID().Hex()
+	}
+
+	now := time.Now()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO warehouses (`+warehouseColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`,
+		warehouse.ID, warehouse.Name, warehouse.Location.Latitude, warehouse.Location.Longitude,
+		warehouse.Priority, warehouse.Active, now,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrWarehouseExists
+	}
+
+	return err
+}
+
+func (r *postgresWarehouseRepository) FindByID(ctx context.Context, id string) (*domain.Warehouse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+warehouseColumns+` FROM warehouses WHERE id = $1`, id)
+	warehouse, err := scanWarehouse(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWarehouseNotFound
+	}
+
+	return warehouse, err
+}
+
+func (r *postgresWarehouseRepository) Update(ctx context.Context, warehouse *domain.Warehouse) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE warehouses
+		SET name = $2, latitude = $3, longitude = $4, priority = $5, active = $6, updated_at = $7
+		WHERE id = $1`,
+		warehouse.ID, warehouse.Name, warehouse.Location.Latitude, warehouse.Location.Longitude,
+		warehouse.Priority, warehouse.Active, time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, domain.ErrWarehouseNotFound)
+}
+
+func (r *postgresWarehouseRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM warehouses WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(result, domain.ErrWarehouseNotFound)
+}
+
+func (r *postgresWarehouseRepository) List(ctx context.Context) ([]*domain.Warehouse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+warehouseColumns+` FROM warehouses ORDER BY priority, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	warehouses := []*domain.Warehouse{}
+	for rows.Next() {
+		warehouse, err := scanWarehouse(rows)
+		if err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, warehouse)
+	}
+
+	return warehouses, rows.Err()
+}
+
+func scanWarehouse(row rowScanner) (*domain.Warehouse, error) {
+	var w domain.Warehouse
+	err := row.Scan(&w.ID, &w.Name, &w.Location.Latitude, &w.Location.Longitude, &w.Priority, &w.Active, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
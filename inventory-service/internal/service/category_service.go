@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service/internal/domain"
+)
+
+func (s *InventoryServer) CreateCategory(ctx context.Context, req *inventory.CreateCategoryRequest) (*inventory.CategoryResponse, error) {
+	category, err := s.categoryUsecase.CreateCategory(ctx, &domain.Category{
+		ID:          generateID(),
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    req.ParentId,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.CategoryResponse{
+		Category: s.categoryToProto(category),
+	}, nil
+}
+
+func (s *InventoryServer) GetCategory(ctx context.Context, req *inventory.GetCategoryRequest) (*inventory.CategoryResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "category ID is required")
+	}
+
+	category, err := s.categoryUsecase.GetCategory(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.CategoryResponse{
+		Category: s.categoryToProto(category),
+	}, nil
+}
+
+func (s *InventoryServer) UpdateCategory(ctx context.Context, req *inventory.UpdateCategoryRequest) (*inventory.CategoryResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "category ID is required")
+	}
+	if req.MoveToRoot && req.ParentId != "" {
+		return nil, status.Error(codes.InvalidArgument, "parent ID and move to root are mutually exclusive")
+	}
+
+	category, err := s.categoryUsecase.UpdateCategory(ctx, &domain.Category{
+		ID:          req.Id,
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    req.ParentId,
+	}, req.MoveToRoot)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.CategoryResponse{
+		Category: s.categoryToProto(category),
+	}, nil
+}
+
+func (s *InventoryServer) DeleteCategory(ctx context.Context, req *inventory.DeleteCategoryRequest) (*inventory.Empty, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "category ID is required")
+	}
+
+	if err := s.categoryUsecase.DeleteCategory(ctx, req.Id); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.Empty{}, nil
+}
+
+func (s *InventoryServer) ListCategories(ctx context.Context, req *inventory.ListCategoriesRequest) (*inventory.ListCategoriesResponse, error) {
+	categories, err := s.categoryUsecase.ListCategories(ctx, req.ParentId, req.IncludeDescendants)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoCategories := make([]*inventory.Category, len(categories))
+	for i, category := range categories {
+		protoCategories[i] = s.categoryToProto(category)
+	}
+
+	return &inventory.ListCategoriesResponse{
+		Categories: protoCategories,
+	}, nil
+}
+
+func (s *InventoryServer) categoryToProto(category *domain.Category) *inventory.Category {
+	return &inventory.Category{
+		Id:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		ParentId:    category.ParentID,
+		CreatedAt:   category.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   category.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service/internal/domain"
+)
+
+// toStatusError maps domain errors onto gRPC status codes so callers can
+// tell a missing product apart from an exhausted one.
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrProductNotFound),
+		errors.Is(err, domain.ErrReservationNotFound),
+		errors.Is(err, domain.ErrCategoryNotFound),
+		errors.Is(err, domain.ErrVariantNotFound),
+		errors.Is(err, domain.ErrWarehouseNotFound),
+		errors.Is(err, domain.ErrSupplierNotFound),
+		errors.Is(err, domain.ErrPurchaseOrderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInsufficientStock),
+		errors.Is(err, domain.ErrProductArchived),
+		errors.Is(err, domain.ErrProductNotArchived),
+		errors.Is(err, domain.ErrReservationClosed),
+		errors.Is(err, domain.ErrCategoryInUse),
+		errors.Is(err, domain.ErrWarehouseInUse),
+		errors.Is(err, domain.ErrPurchaseOrderClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrProductExists),
+		errors.Is(err, domain.ErrReservationExists),
+		errors.Is(err, domain.ErrReturnExists),
+		errors.Is(err, domain.ErrCategoryExists),
+		errors.Is(err, domain.ErrVariantExists),
+		errors.Is(err, domain.ErrWarehouseExists),
+		errors.Is(err, domain.ErrSupplierExists),
+		errors.Is(err, domain.ErrPurchaseOrderExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrInvalidSearch),
+		errors.Is(err, domain.ErrInvalidCategory),
+		errors.Is(err, domain.ErrInvalidVariant),
+		errors.Is(err, domain.ErrInvalidWarehouse),
+		errors.Is(err, domain.ErrInvalidStockChange),
+		errors.Is(err, domain.ErrInvalidSupplier),
+		errors.Is(err, domain.ErrInvalidPurchaseOrder),
+		errors.Is(err, domain.ErrInvalidReturn),
+		errors.Is(err, domain.ErrInvalidImport):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		return err
+	}
+}
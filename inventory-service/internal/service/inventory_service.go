@@ -2,38 +2,62 @@ package service
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"inventory-service/internal/domain"
 	"inventory-service/internal/usecase"
 )
 
 type InventoryServer struct {
 	inventory.UnimplementedInventoryServiceServer
-	productUsecase usecase.ProductUsecase
+	productUsecase     usecase.ProductUsecase
+	categoryUsecase    usecase.CategoryUsecase
+	variantUsecase     usecase.VariantUsecase
+	warehouseUsecase   usecase.WarehouseUsecase
+	reservationUsecase usecase.ReservationUsecase
+	purchasingUsecase  usecase.PurchasingUsecase
 }
 
-func NewInventoryServer(productUsecase usecase.ProductUsecase) *InventoryServer {
+func NewInventoryServer(productUsecase usecase.ProductUsecase, categoryUsecase usecase.CategoryUsecase, variantUsecase usecase.VariantUsecase, warehouseUsecase usecase.WarehouseUsecase, reservationUsecase usecase.ReservationUsecase, purchasingUsecase usecase.PurchasingUsecase) *InventoryServer {
 	return &InventoryServer{
-		productUsecase: productUsecase,
+		productUsecase:     productUsecase,
+		categoryUsecase:    categoryUsecase,
+		variantUsecase:     variantUsecase,
+		warehouseUsecase:   warehouseUsecase,
+		reservationUsecase: reservationUsecase,
+		purchasingUsecase:  purchasingUsecase,
 	}
 }
 
 func (s *InventoryServer) CreateProduct(ctx context.Context, req *inventory.CreateProductRequest) (*inventory.ProductResponse, error) {
+	availableAt, err := parseAvailableAt(req.AvailableAt)
+	if err != nil {
+		return nil, err
+	}
+
 	product := &domain.Product{
-		ID:          generateID(),
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		Stock:       int(req.Stock),
-		CategoryID:  req.CategoryId,
+		ID:              generateID(),
+		SKU:             req.Sku,
+		Name:            req.Name,
+		Description:     req.Description,
+		Price:           req.Price,
+		Stock:           int(req.Stock),
+		CategoryID:      req.CategoryId,
+		ReorderPoint:    int(req.ReorderPoint),
+		ReorderQuantity: int(req.ReorderQuantity),
+		BackorderMode:   domain.BackorderMode(req.BackorderMode),
+	}
+	if !availableAt.IsZero() {
+		product.AvailableAt = &availableAt
 	}
 
 	createdProduct, err := s.productUsecase.CreateProduct(ctx, product)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &inventory.ProductResponse{
@@ -43,32 +67,58 @@ func (s *InventoryServer) CreateProduct(ctx context.Context, req *inventory.Crea
 
 func (s *InventoryServer) GetProductByID(ctx context.Context, req *inventory.GetProductRequest) (*inventory.ProductResponse, error) {
 	if req.Id == "" {
-		return nil, errors.New("product ID is required")
+		return nil, status.Error(codes.InvalidArgument, "product ID is required")
 	}
 
 	product, err := s.productUsecase.GetProduct(ctx, req.Id)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
+	}
+	stock, err := s.warehouseUsecase.GetProductStock(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
 	}
 
 	return &inventory.ProductResponse{
 		Product: s.domainToProto(product),
+		Stock:   s.productStockToProto(stock),
 	}, nil
 }
 
 func (s *InventoryServer) UpdateProduct(ctx context.Context, req *inventory.UpdateProductRequest) (*inventory.ProductResponse, error) {
 	product := &domain.Product{
-		ID:          req.Id,
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		Stock:       int(req.Stock),
-		CategoryID:  req.CategoryId,
+		ID:              req.Id,
+		SKU:             req.Sku,
+		Name:            req.Name,
+		Description:     req.Description,
+		Price:           req.Price,
+		CategoryID:      req.CategoryId,
+		Version:         req.ExpectedVersion,
+		ReorderPoint:    -1,
+		ReorderQuantity: -1,
+	}
+	// Negative reorder fields are left unchanged by the update
+	if req.ReorderPoint != nil {
+		product.ReorderPoint = int(*req.ReorderPoint)
+	}
+	if req.ReorderQuantity != nil {
+		product.ReorderQuantity = int(*req.ReorderQuantity)
+	}
+	if req.BackorderMode != nil {
+		product.BackorderMode = domain.BackorderMode(*req.BackorderMode)
+	}
+	if req.AvailableAt != nil {
+		// A zero time clears the date
+		availableAt, err := parseAvailableAt(*req.AvailableAt)
+		if err != nil {
+			return nil, err
+		}
+		product.AvailableAt = &availableAt
 	}
 
 	updatedProduct, err := s.productUsecase.UpdateProduct(ctx, product)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &inventory.ProductResponse{
@@ -78,34 +128,49 @@ func (s *InventoryServer) UpdateProduct(ctx context.Context, req *inventory.Upda
 
 func (s *InventoryServer) DeleteProduct(ctx context.Context, req *inventory.DeleteProductRequest) (*inventory.Empty, error) {
 	if req.Id == "" {
-		return nil, errors.New("product ID is required")
+		return nil, status.Error(codes.InvalidArgument, "product ID is required")
 	}
 
 	err := s.productUsecase.DeleteProduct(ctx, req.Id)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &inventory.Empty{}, nil
 }
 
-func (s *InventoryServer) ListProducts(ctx context.Context, req *inventory.ListProductsRequest) (*inventory.ListProductsResponse, error) {
-	// Validate and set default pagination parameters
-	page := int(req.Page)
-	if page < 1 {
-		page = 1
+func (s *InventoryServer) RestoreProduct(ctx context.Context, req *inventory.RestoreProductRequest) (*inventory.ProductResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID is required")
 	}
 
+	product, err := s.productUsecase.RestoreProduct(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.ProductResponse{
+		Product: s.domainToProto(product),
+	}, nil
+}
+
+func (s *InventoryServer) ListProducts(ctx context.Context, req *inventory.ListProductsRequest) (*inventory.ListProductsResponse, error) {
+	// Validate and set default pagination parameters
 	limit := int(req.Limit)
 	if limit < 1 || limit > 100 {
 		limit = 10
 	}
 
-	products, total, err := s.productUsecase.ListProducts(ctx, page, limit, req.CategoryId)
+	after, err := decodePageToken(req.PageToken)
 	if err != nil {
 		return nil, err
 	}
 
+	products, total, next, err := s.productUsecase.ListProducts(ctx, req.CategoryId, req.IncludeDescendants, req.IncludeArchived, after, limit)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
 	// Convert domain products to protobuf products
 	protoProducts := make([]*inventory.Product, len(products))
 	for i, product := range products {
@@ -113,24 +178,113 @@ func (s *InventoryServer) ListProducts(ctx context.Context, req *inventory.ListP
 	}
 
 	return &inventory.ListProductsResponse{
-		Products: protoProducts,
-		Total:    int32(total),
-		Page:     int32(page),
-		Limit:    int32(limit),
+		Products:      protoProducts,
+		Total:         int32(total),
+		Limit:         int32(limit),
+		NextPageToken: encodePageToken(next),
+	}, nil
+}
+
+func (s *InventoryServer) SearchProducts(ctx context.Context, req *inventory.SearchProductsRequest) (*inventory.ListProductsResponse, error) {
+	result, err := s.productUsecase.SearchProducts(ctx, domain.ProductSearch{
+		Query:           req.Query,
+		CategoryIDs:     req.CategoryIds,
+		MinPrice:        req.MinPrice,
+		MaxPrice:        req.MaxPrice,
+		InStockOnly:     req.InStockOnly,
+		IncludeArchived: req.IncludeArchived,
+		SortBy:          domain.ProductSort(req.SortBy),
+		Order:           domain.SortOrder(req.SortOrder),
+		Page:            int(req.Page),
+		Limit:           int(req.Limit),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoProducts := make([]*inventory.Product, len(result.Products))
+	for i, product := range result.Products {
+		protoProducts[i] = s.domainToProto(product)
+	}
+
+	facets := make([]*inventory.CategoryFacet, len(result.Facets))
+	for i, facet := range result.Facets {
+		facets[i] = &inventory.CategoryFacet{
+			CategoryId: facet.CategoryID,
+			Count:      int32(facet.Count),
+		}
+	}
+
+	return &inventory.ListProductsResponse{
+		Products:       protoProducts,
+		Total:          int32(result.Total),
+		Page:           int32(result.Page),
+		Limit:          int32(result.Limit),
+		CategoryFacets: facets,
 	}, nil
 }
 
 func (s *InventoryServer) domainToProto(product *domain.Product) *inventory.Product {
+	var availableAt, archivedAt string
+	if product.AvailableAt != nil {
+		availableAt = product.AvailableAt.Format(time.RFC3339)
+	}
+	if product.ArchivedAt != nil {
+		archivedAt = product.ArchivedAt.Format(time.RFC3339)
+	}
+
 	return &inventory.Product{
-		Id:          product.ID,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Stock:       int32(product.Stock),
-		CategoryId:  product.CategoryID,
-		CreatedAt:   product.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   product.UpdatedAt.Format(time.RFC3339),
+		Id:              product.ID,
+		Sku:             product.SKU,
+		Name:            product.Name,
+		Description:     product.Description,
+		Price:           product.Price,
+		Stock:           int32(product.Stock),
+		CategoryId:      product.CategoryID,
+		ReorderPoint:    int32(product.ReorderPoint),
+		ReorderQuantity: int32(product.ReorderQuantity),
+		BackorderMode:   string(product.BackorderMode),
+		AvailableAt:     availableAt,
+		ArchivedAt:      archivedAt,
+		Version:         product.Version,
+		CreatedAt:       product.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       product.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ListLowStock returns every product whose stock has fallen to its
+// reorder point.
+func (s *InventoryServer) ListLowStock(ctx context.Context, req *inventory.ListLowStockRequest) (*inventory.ListLowStockResponse, error) {
+	alerts, err := s.productUsecase.ListLowStock(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	products := make([]*inventory.LowStockProduct, len(alerts))
+	for i, alert := range alerts {
+		products[i] = &inventory.LowStockProduct{
+			ProductId:       alert.ProductID,
+			Name:            alert.Name,
+			Stock:           int32(alert.Stock),
+			ReorderPoint:    int32(alert.ReorderPoint),
+			ReorderQuantity: int32(alert.ReorderQuantity),
+		}
+	}
+
+	return &inventory.ListLowStockResponse{Products: products}, nil
+}
+
+// parseAvailableAt reads an RFC 3339 availability date; an empty string
+// gives the zero time.
+func parseAvailableAt(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	availableAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, status.Error(codes.InvalidArgument, "available_at must be an RFC 3339 time")
 	}
+	return availableAt, nil
 }
 
 // Helper function to generate IDs
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service/internal/domain"
+)
+
+// exportChunkSize is roughly how many bytes each ExportProducts message
+// carries.
+const exportChunkSize = 32 * 1024
+
+// ImportProducts reads a CSV or JSON Lines file streamed in chunks and
+// upserts its products by SKU, answering with a per-row report.
+func (s *InventoryServer) ImportProducts(stream inventory.InventoryService_ImportProductsServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "import format is required")
+	}
+	if err != nil {
+		return err
+	}
+
+	reader := &importReader{stream: stream, buf: first.Chunk}
+	report, err := s.productUsecase.ImportProducts(stream.Context(), domain.FileFormat(first.Format), reader)
+	if err != nil {
+		return toStatusError(err)
+	}
+
+	rowErrors := make([]*inventory.ImportRowError, len(report.Errors))
+	for i, rowErr := range report.Errors {
+		rowErrors[i] = &inventory.ImportRowError{
+			Row:   int32(rowErr.Row),
+			Sku:   rowErr.SKU,
+			Error: rowErr.Error,
+		}
+	}
+
+	return stream.SendAndClose(&inventory.ImportProductsResponse{
+		Created: int32(report.Created),
+		Updated: int32(report.Updated),
+		Failed:  int32(report.Failed),
+		Errors:  rowErrors,
+	})
+}
+
+// ExportProducts streams the catalog as CSV or JSON Lines.
+func (s *InventoryServer) ExportProducts(req *inventory.ExportProductsRequest, stream inventory.InventoryService_ExportProductsServer) error {
+	writer := bufio.NewWriterSize(&exportWriter{stream: stream}, exportChunkSize)
+	if err := s.productUsecase.ExportProducts(stream.Context(), domain.FileFormat(req.Format), writer); err != nil {
+		return toStatusError(err)
+	}
+	return writer.Flush()
+}
+
+// importReader presents the chunks of an import stream as one file.
+type importReader struct {
+	stream inventory.InventoryService_ImportProductsServer
+	buf    []byte
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = req.Chunk
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// exportWriter sends every write as one message of an export stream.
+type exportWriter struct {
+	stream inventory.InventoryService_ExportProductsServer
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	// The stream may hold on to the message, so it gets its own copy
+	chunk := append([]byte(nil), p...)
+	if err := w.stream.Send(&inventory.ExportProductsChunk{Chunk: chunk}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
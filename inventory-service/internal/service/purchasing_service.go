@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service/internal/domain"
+)
+
+func (s *InventoryServer) CreateSupplier(ctx context.Context, req *inventory.CreateSupplierRequest) (*inventory.SupplierResponse, error) {
+	supplier, err := s.purchasingUsecase.CreateSupplier(ctx, &domain.Supplier{
+		ID:    generateID(),
+		Name:  req.Name,
+		Email: req.Email,
+		Phone: req.Phone,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.SupplierResponse{
+		Supplier: s.supplierToProto(supplier),
+	}, nil
+}
+
+func (s *InventoryServer) GetSupplier(ctx context.Context, req *inventory.GetSupplierRequest) (*inventory.SupplierResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "supplier ID is required")
+	}
+
+	supplier, err := s.purchasingUsecase.GetSupplier(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.SupplierResponse{
+		Supplier: s.supplierToProto(supplier),
+	}, nil
+}
+
+func (s *InventoryServer) UpdateSupplier(ctx context.Context, req *inventory.UpdateSupplierRequest) (*inventory.SupplierResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "supplier ID is required")
+	}
+
+	supplier, err := s.purchasingUsecase.UpdateSupplier(ctx, req.Id, domain.SupplierUpdate{
+		Name:  req.Name,
+		Email: req.Email,
+		Phone: req.Phone,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.SupplierResponse{
+		Supplier: s.supplierToProto(supplier),
+	}, nil
+}
+
+func (s *InventoryServer) ListSuppliers(ctx context.Context, req *inventory.ListSuppliersRequest) (*inventory.ListSuppliersResponse, error) {
+	suppliers, err := s.purchasingUsecase.ListSuppliers(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoSuppliers := make([]*inventory.Supplier, len(suppliers))
+	for i, supplier := range suppliers {
+		protoSuppliers[i] = s.supplierToProto(supplier)
+	}
+
+	return &inventory.ListSuppliersResponse{
+		Suppliers: protoSuppliers,
+	}, nil
+}
+
+func (s *InventoryServer) CreatePurchaseOrder(ctx context.Context, req *inventory.CreatePurchaseOrderRequest) (*inventory.PurchaseOrderResponse, error) {
+	lines := make([]domain.PurchaseOrderLine, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = domain.PurchaseOrderLine{
+			ProductID:  line.ProductId,
+			VariantSKU: line.VariantSku,
+			Quantity:   int(line.Quantity),
+			UnitCost:   line.UnitCost,
+		}
+	}
+
+	po, err := s.purchasingUsecase.CreatePurchaseOrder(ctx, &domain.PurchaseOrder{
+		ID:          generateID(),
+		SupplierID:  req.SupplierId,
+		WarehouseID: req.WarehouseId,
+		Lines:       lines,
+		Note:        req.Note,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.PurchaseOrderResponse{
+		PurchaseOrder: s.purchaseOrderToProto(po),
+	}, nil
+}
+
+func (s *InventoryServer) GetPurchaseOrder(ctx context.Context, req *inventory.GetPurchaseOrderRequest) (*inventory.PurchaseOrderResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "purchase order ID is required")
+	}
+
+	po, err := s.purchasingUsecase.GetPurchaseOrder(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.PurchaseOrderResponse{
+		PurchaseOrder: s.purchaseOrderToProto(po),
+	}, nil
+}
+
+func (s *InventoryServer) ListPurchaseOrders(ctx context.Context, req *inventory.ListPurchaseOrdersRequest) (*inventory.ListPurchaseOrdersResponse, error) {
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, next, err := s.purchasingUsecase.ListPurchaseOrders(ctx, domain.PurchaseOrderFilter{
+		SupplierID: req.SupplierId,
+		Status:     domain.PurchaseOrderStatus(req.Status),
+	}, after, int(req.Limit))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoOrders := make([]*inventory.PurchaseOrder, len(orders))
+	for i, po := range orders {
+		protoOrders[i] = s.purchaseOrderToProto(po)
+	}
+
+	return &inventory.ListPurchaseOrdersResponse{
+		PurchaseOrders: protoOrders,
+		NextPageToken:  encodePageToken(next),
+	}, nil
+}
+
+func (s *InventoryServer) ReceivePurchaseOrder(ctx context.Context, req *inventory.ReceivePurchaseOrderRequest) (*inventory.PurchaseOrderResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "purchase order ID is required")
+	}
+
+	receipt := make([]domain.ReceiptLine, len(req.Lines))
+	for i, line := range req.Lines {
+		receipt[i] = domain.ReceiptLine{
+			ProductID:  line.ProductId,
+			VariantSKU: line.VariantSku,
+			Quantity:   int(line.Quantity),
+		}
+	}
+
+	po, err := s.purchasingUsecase.ReceivePurchaseOrder(ctx, req.Id, receipt)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.PurchaseOrderResponse{
+		PurchaseOrder: s.purchaseOrderToProto(po),
+	}, nil
+}
+
+func (s *InventoryServer) CancelPurchaseOrder(ctx context.Context, req *inventory.CancelPurchaseOrderRequest) (*inventory.PurchaseOrderResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "purchase order ID is required")
+	}
+
+	po, err := s.purchasingUsecase.CancelPurchaseOrder(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.PurchaseOrderResponse{
+		PurchaseOrder: s.purchaseOrderToProto(po),
+	}, nil
+}
+
+func (s *InventoryServer) supplierToProto(supplier *domain.Supplier) *inventory.Supplier {
+	return &inventory.Supplier{
+		Id:        supplier.ID,
+		Name:      supplier.Name,
+		Email:     supplier.Email,
+		Phone:     supplier.Phone,
+		CreatedAt: supplier.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: supplier.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *InventoryServer) purchaseOrderToProto(po *domain.PurchaseOrder) *inventory.PurchaseOrder {
+	lines := make([]*inventory.PurchaseOrderLine, len(po.Lines))
+	for i, line := range po.Lines {
+		lines[i] = &inventory.PurchaseOrderLine{
+			ProductId:  line.ProductID,
+			VariantSku: line.VariantSKU,
+			Quantity:   int32(line.Quantity),
+			Received:   int32(line.Received),
+			UnitCost:   line.UnitCost,
+		}
+	}
+
+	return &inventory.PurchaseOrder{
+		Id:          po.ID,
+		SupplierId:  po.SupplierID,
+		WarehouseId: po.WarehouseID,
+		Lines:       lines,
+		Status:      string(po.Status),
+		Note:        po.Note,
+		Version:     po.Version,
+		CreatedAt:   po.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   po.UpdatedAt.Format(time.RFC3339),
+	}
+}
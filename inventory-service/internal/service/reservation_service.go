@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service/internal/domain"
+)
+
+func (s *InventoryServer) ReserveStock(ctx context.Context, req *inventory.ReserveStockRequest) (*inventory.ReservationResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID is required")
+	}
+	if len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "reservation must contain at least one item")
+	}
+
+	items := make([]domain.ReservationItem, len(req.Items))
+	for i, item := range req.Items {
+		if item.ProductId == "" || item.Quantity <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "item %d must have a product ID and a positive quantity", i)
+		}
+		items[i] = domain.ReservationItem{
+			ProductID:  item.ProductId,
+			VariantSKU: item.VariantSku,
+			Quantity:   int(item.Quantity),
+		}
+	}
+
+	var shipTo *domain.GeoPoint
+	if req.ShipTo != nil {
+		point := protoToGeoPoint(req.ShipTo)
+		shipTo = &point
+	}
+
+	ttl := time.Duration(req.TtlSeconds) * time.Second
+	reservation, err := s.reservationUsecase.ReserveStock(ctx, req.OrderId, items, shipTo, ttl, req.AllowBackorder)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.ReservationResponse{
+		Reservation: s.reservationToProto(reservation),
+	}, nil
+}
+
+func (s *InventoryServer) GetReservation(ctx context.Context, req *inventory.ReservationRequest) (*inventory.ReservationResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID is required")
+	}
+
+	reservation, err := s.reservationUsecase.GetReservation(ctx, req.OrderId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.ReservationResponse{
+		Reservation: s.reservationToProto(reservation),
+	}, nil
+}
+
+func (s *InventoryServer) CommitReservation(ctx context.Context, req *inventory.ReservationRequest) (*inventory.ReservationResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID is required")
+	}
+
+	reservation, err := s.reservationUsecase.CommitReservation(ctx, req.OrderId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.ReservationResponse{
+		Reservation: s.reservationToProto(reservation),
+	}, nil
+}
+
+func (s *InventoryServer) ReleaseReservation(ctx context.Context, req *inventory.ReservationRequest) (*inventory.ReservationResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID is required")
+	}
+
+	reservation, err := s.reservationUsecase.ReleaseReservation(ctx, req.OrderId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.ReservationResponse{
+		Reservation: s.reservationToProto(reservation),
+	}, nil
+}
+
+func (s *InventoryServer) ReturnStock(ctx context.Context, req *inventory.ReturnStockRequest) (*inventory.ReservationResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID is required")
+	}
+
+	items := make([]domain.ReturnedItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = domain.ReturnedItem{
+			ProductID:  item.ProductId,
+			VariantSKU: item.VariantSku,
+			Quantity:   int(item.Quantity),
+			Resellable: item.Resellable,
+		}
+	}
+
+	reservation, err := s.reservationUsecase.ReturnStock(ctx, req.OrderId, req.ReturnId, items)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.ReservationResponse{
+		Reservation: s.reservationToProto(reservation),
+	}, nil
+}
+
+func (s *InventoryServer) reservationToProto(reservation *domain.Reservation) *inventory.Reservation {
+	items := make([]*inventory.ReservationItem, len(reservation.Items))
+	for i, item := range reservation.Items {
+		items[i] = &inventory.ReservationItem{
+			ProductId:   item.ProductID,
+			VariantSku:  item.VariantSKU,
+			WarehouseId: item.WarehouseID,
+			Quantity:    int32(item.Quantity),
+		}
+	}
+
+	var returns []string
+	for _, ret := range reservation.Returns {
+		returns = append(returns, ret.ID)
+	}
+
+	var expiresAt string
+	if !reservation.ExpiresAt.IsZero() {
+		expiresAt = reservation.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return &inventory.Reservation{
+		OrderId:   reservation.OrderID,
+		Items:     items,
+		Status:    string(reservation.Status),
+		Returns:   returns,
+		ExpiresAt: expiresAt,
+		CreatedAt: reservation.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: reservation.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service/internal/domain"
+)
+
+func (s *InventoryServer) CreateVariant(ctx context.Context, req *inventory.CreateVariantRequest) (*inventory.VariantResponse, error) {
+	variant, err := s.variantUsecase.CreateVariant(ctx, &domain.Variant{
+		SKU:        req.Sku,
+		ProductID:  req.ProductId,
+		Attributes: req.Attributes,
+		Price:      req.Price,
+		Stock:      int(req.Stock),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.VariantResponse{
+		Variant: s.variantToProto(variant),
+	}, nil
+}
+
+func (s *InventoryServer) GetVariant(ctx context.Context, req *inventory.GetVariantRequest) (*inventory.VariantResponse, error) {
+	if req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "SKU is required")
+	}
+
+	variant, err := s.variantUsecase.GetVariant(ctx, req.Sku)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.VariantResponse{
+		Variant: s.variantToProto(variant),
+	}, nil
+}
+
+func (s *InventoryServer) UpdateVariant(ctx context.Context, req *inventory.UpdateVariantRequest) (*inventory.VariantResponse, error) {
+	if req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "SKU is required")
+	}
+
+	var update domain.VariantUpdate
+	if len(req.Attributes) > 0 || req.ReplaceAttributes {
+		update.Attributes = req.Attributes
+		if update.Attributes == nil {
+			update.Attributes = map[string]string{}
+		}
+	}
+	update.Price = req.Price
+
+	variant, err := s.variantUsecase.UpdateVariant(ctx, req.Sku, update)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.VariantResponse{
+		Variant: s.variantToProto(variant),
+	}, nil
+}
+
+func (s *InventoryServer) DeleteVariant(ctx context.Context, req *inventory.DeleteVariantRequest) (*inventory.Empty, error) {
+	if req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "SKU is required")
+	}
+
+	if err := s.variantUsecase.DeleteVariant(ctx, req.Sku); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.Empty{}, nil
+}
+
+func (s *InventoryServer) ListVariants(ctx context.Context, req *inventory.ListVariantsRequest) (*inventory.ListVariantsResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	}
+
+	variants, err := s.variantUsecase.ListVariants(ctx, req.ProductId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoVariants := make([]*inventory.Variant, len(variants))
+	for i, variant := range variants {
+		protoVariants[i] = s.variantToProto(variant)
+	}
+
+	return &inventory.ListVariantsResponse{
+		Variants: protoVariants,
+	}, nil
+}
+
+func (s *InventoryServer) variantToProto(variant *domain.Variant) *inventory.Variant {
+	return &inventory.Variant{
+		Sku:        variant.SKU,
+		ProductId:  variant.ProductID,
+		Attributes: variant.Attributes,
+		Price:      variant.Price,
+		Stock:      int32(variant.Stock),
+		CreatedAt:  variant.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  variant.UpdatedAt.Format(time.RFC3339),
+	}
+}
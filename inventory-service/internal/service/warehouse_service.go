@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service/internal/domain"
+)
+
+func (s *InventoryServer) CreateWarehouse(ctx context.Context, req *inventory.CreateWarehouseRequest) (*inventory.WarehouseResponse, error) {
+	warehouse := &domain.Warehouse{
+		ID:       generateID(),
+		Name:     req.Name,
+		Priority: int(req.Priority),
+		Active:   req.Active == nil || *req.Active,
+	}
+	if req.Location != nil {
+		warehouse.Location = protoToGeoPoint(req.Location)
+	}
+
+	warehouse, err := s.warehouseUsecase.CreateWarehouse(ctx, warehouse)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.WarehouseResponse{
+		Warehouse: s.warehouseToProto(warehouse),
+	}, nil
+}
+
+func (s *InventoryServer) GetWarehouse(ctx context.Context, req *inventory.GetWarehouseRequest) (*inventory.WarehouseResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "warehouse ID is required")
+	}
+
+	warehouse, err := s.warehouseUsecase.GetWarehouse(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.WarehouseResponse{
+		Warehouse: s.warehouseToProto(warehouse),
+	}, nil
+}
+
+func (s *InventoryServer) UpdateWarehouse(ctx context.Context, req *inventory.UpdateWarehouseRequest) (*inventory.WarehouseResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "warehouse ID is required")
+	}
+
+	update := domain.WarehouseUpdate{
+		Name:   req.Name,
+		Active: req.Active,
+	}
+	if req.Location != nil {
+		location := protoToGeoPoint(req.Location)
+		update.Location = &location
+	}
+	if req.Priority != nil {
+		priority := int(*req.Priority)
+		update.Priority = &priority
+	}
+
+	warehouse, err := s.warehouseUsecase.UpdateWarehouse(ctx, req.Id, update)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.WarehouseResponse{
+		Warehouse: s.warehouseToProto(warehouse),
+	}, nil
+}
+
+func (s *InventoryServer) DeleteWarehouse(ctx context.Context, req *inventory.DeleteWarehouseRequest) (*inventory.Empty, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "warehouse ID is required")
+	}
+
+	if err := s.warehouseUsecase.DeleteWarehouse(ctx, req.Id); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.Empty{}, nil
+}
+
+func (s *InventoryServer) ListWarehouses(ctx context.Context, req *inventory.ListWarehousesRequest) (*inventory.ListWarehousesResponse, error) {
+	warehouses, err := s.warehouseUsecase.ListWarehouses(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoWarehouses := make([]*inventory.Warehouse, len(warehouses))
+	for i, warehouse := range warehouses {
+		protoWarehouses[i] = s.warehouseToProto(warehouse)
+	}
+
+	return &inventory.ListWarehousesResponse{
+		Warehouses: protoWarehouses,
+	}, nil
+}
+
+func (s *InventoryServer) ListWarehouseStock(ctx context.Context, req *inventory.ListWarehouseStockRequest) (*inventory.ListWarehouseStockResponse, error) {
+	if req.WarehouseId == "" {
+		return nil, status.Error(codes.InvalidArgument, "warehouse ID is required")
+	}
+
+	levels, err := s.warehouseUsecase.ListWarehouseStock(ctx, req.WarehouseId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.ListWarehouseStockResponse{
+		Levels: s.stockLevelsToProto(levels),
+	}, nil
+}
+
+func (s *InventoryServer) SetStockLevel(ctx context.Context, req *inventory.SetStockLevelRequest) (*inventory.ProductStockResponse, error) {
+	if req.WarehouseId == "" || req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "warehouse and product IDs are required")
+	}
+
+	stock, err := s.warehouseUsecase.SetStockLevel(ctx, &domain.StockLevel{
+		WarehouseID: req.WarehouseId,
+		ProductID:   req.ProductId,
+		VariantSKU:  req.VariantSku,
+		Quantity:    int(req.Quantity),
+	}, domain.MovementReason(req.Reason), req.Note)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.ProductStockResponse{
+		Stock: s.productStockToProto(stock),
+	}, nil
+}
+
+func (s *InventoryServer) TransferStock(ctx context.Context, req *inventory.TransferStockRequest) (*inventory.ProductStockResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	}
+
+	stock, err := s.warehouseUsecase.TransferStock(ctx, domain.StockTransfer{
+		ProductID:       req.ProductId,
+		VariantSKU:      req.VariantSku,
+		FromWarehouseID: req.FromWarehouseId,
+		ToWarehouseID:   req.ToWarehouseId,
+		Quantity:        int(req.Quantity),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.ProductStockResponse{
+		Stock: s.productStockToProto(stock),
+	}, nil
+}
+
+func (s *InventoryServer) AdjustStock(ctx context.Context, req *inventory.AdjustStockRequest) (*inventory.AdjustStockResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	}
+
+	stock, err := s.warehouseUsecase.AdjustStock(ctx, domain.StockAdjustment{
+		ProductID:  req.ProductId,
+		VariantSKU: req.VariantSku,
+		Delta:      int(req.Delta),
+		Reason:     domain.MovementReason(req.Reason),
+		Note:       req.Note,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &inventory.AdjustStockResponse{
+		ProductId:  req.ProductId,
+		VariantSku: req.VariantSku,
+		Stock:      int32(stock),
+	}, nil
+}
+
+func (s *InventoryServer) ListStockMovements(ctx context.Context, req *inventory.ListStockMovementsRequest) (*inventory.ListStockMovementsResponse, error) {
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	movements, next, err := s.warehouseUsecase.ListStockMovements(ctx, domain.MovementFilter{
+		ProductID:   req.ProductId,
+		VariantSKU:  req.VariantSku,
+		WarehouseID: req.WarehouseId,
+		OrderID:     req.OrderId,
+		Reason:      domain.MovementReason(req.Reason),
+	}, after, int(req.Limit))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoMovements := make([]*inventory.StockMovement, len(movements))
+	for i, movement := range movements {
+		protoMovements[i] = &inventory.StockMovement{
+			Id:          movement.ID,
+			ProductId:   movement.ProductID,
+			VariantSku:  movement.VariantSKU,
+			WarehouseId: movement.WarehouseID,
+			Delta:       int32(movement.Delta),
+			Reason:      string(movement.Reason),
+			Actor:       movement.Actor,
+			OrderId:     movement.OrderID,
+			Note:        movement.Note,
+			CreatedAt:   movement.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	return &inventory.ListStockMovementsResponse{
+		Movements:     protoMovements,
+		NextPageToken: encodePageToken(next),
+	}, nil
+}
+
+func (s *InventoryServer) ReconcileStock(ctx context.Context, req *inventory.ReconcileStockRequest) (*inventory.ReconcileStockResponse, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product ID is required")
+	}
+
+	discrepancies, err := s.warehouseUsecase.ReconcileStock(ctx, req.ProductId, req.Apply)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoDiscrepancies := make([]*inventory.StockDiscrepancy, len(discrepancies))
+	for i, discrepancy := range discrepancies {
+		protoDiscrepancies[i] = &inventory.StockDiscrepancy{
+			ProductId:   discrepancy.ProductID,
+			VariantSku:  discrepancy.VariantSKU,
+			WarehouseId: discrepancy.WarehouseID,
+			Recorded:    int32(discrepancy.Recorded),
+			Ledger:      int32(discrepancy.Ledger),
+		}
+	}
+
+	return &inventory.ReconcileStockResponse{
+		Discrepancies: protoDiscrepancies,
+	}, nil
+}
+
+func (s *InventoryServer) warehouseToProto(warehouse *domain.Warehouse) *inventory.Warehouse {
+	return &inventory.Warehouse{
+		Id:   warehouse.ID,
+		Name: warehouse.Name,
+		Location: &inventory.GeoPoint{
+			Latitude:  warehouse.Location.Latitude,
+			Longitude: warehouse.Location.Longitude,
+		},
+		Priority:  int32(warehouse.Priority),
+		Active:    warehouse.Active,
+		CreatedAt: warehouse.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: warehouse.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *InventoryServer) productStockToProto(stock *domain.ProductStock) *inventory.ProductStock {
+	return &inventory.ProductStock{
+		ProductId:  stock.ProductID,
+		Total:      int32(stock.Total),
+		Available:  int32(stock.Available),
+		Unassigned: int32(stock.Unassigned),
+		Levels:     s.stockLevelsToProto(stock.Levels),
+	}
+}
+
+func (s *InventoryServer) stockLevelsToProto(levels []*domain.StockLevel) []*inventory.StockLevel {
+	protoLevels := make([]*inventory.StockLevel, len(levels))
+	for i, level := range levels {
+		protoLevels[i] = &inventory.StockLevel{
+			WarehouseId: level.WarehouseID,
+			ProductId:   level.ProductID,
+			VariantSku:  level.VariantSKU,
+			Quantity:    int32(level.Quantity),
+			UpdatedAt:   level.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+	return protoLevels
+}
+
+func protoToGeoPoint(point *inventory.GeoPoint) domain.GeoPoint {
+	return domain.GeoPoint{
+		Latitude:  point.Latitude,
+		Longitude: point.Longitude,
+	}
+}
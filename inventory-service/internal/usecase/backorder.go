@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"inventory-service/internal/domain"
+	"inventory-service/internal/repository"
+)
+
+// BackorderFiller hands stock to backordered reservations as it arrives.
+// Stock added through the repositories returned by Watch triggers a pass
+// right away; a periodic pass catches everything else.
+type BackorderFiller struct {
+	wake chan struct{}
+}
+
+func NewBackorderFiller() *BackorderFiller {
+	return &BackorderFiller{wake: make(chan struct{}, 1)}
+}
+
+// Watch returns repos with the product and variant repositories wrapped
+// so that every stock increase wakes the filler.
+func (f *BackorderFiller) Watch(repos repository.Repositories) repository.Repositories {
+	repos.Products = backorderProducts{ProductRepository: repos.Products, filler: f}
+	repos.Variants = backorderVariants{VariantRepository: repos.Variants, filler: f}
+	return repos
+}
+
+// Run fills backorders through reservations whenever stock arrives and
+// every interval until ctx is done.
+func (f *BackorderFiller) Run(ctx context.Context, reservations ReservationUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		f.fill(ctx, reservations)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (f *BackorderFiller) fill(ctx context.Context, reservations ReservationUsecase) {
+	filled, err := reservations.FillBackorders(ctx)
+	if err != nil {
+		log.Printf("failed to fill backorders: %v", err)
+	}
+	if filled > 0 {
+		log.Printf("filled %d backordered reservations", filled)
+	}
+}
+
+// notify wakes the filler without blocking the caller. A pass already
+// pending covers the new stock too.
+func (f *BackorderFiller) notify() {
+	select {
+	case f.wake <- struct{}{}:
+	default:
+	}
+}
+
+// backorderProducts wakes the filler whenever a product's stock grows.
+type backorderProducts struct {
+	repository.ProductRepository
+	filler *BackorderFiller
+}
+
+func (r backorderProducts) AdjustStock(ctx context.Context, id string, delta int) (int, error) {
+	stock, err := r.ProductRepository.AdjustStock(ctx, id, delta)
+	if err != nil {
+		return 0, err
+	}
+	if delta > 0 {
+		r.filler.notify()
+	}
+	return stock, nil
+}
+
+// backorderVariants wakes the filler whenever a variant is added with
+// stock or its stock grows.
+type backorderVariants struct {
+	repository.VariantRepository
+	filler *BackorderFiller
+}
+
+func (r backorderVariants) Create(ctx context.Context, variant *domain.Variant) error {
+	if err := r.VariantRepository.Create(ctx, variant); err != nil {
+		return err
+	}
+	if variant.Stock > 0 {
+		r.filler.notify()
+	}
+	return nil
+}
+
+func (r backorderVariants) AdjustStock(ctx context.Context, sku string, delta int) (int, error) {
+	stock, err := r.VariantRepository.AdjustStock(ctx, sku, delta)
+	if err != nil {
+		return 0, err
+	}
+	if delta > 0 {
+		r.filler.notify()
+	}
+	return stock, nil
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"inventory-service/internal/domain"
+	"inventory-service/internal/repository"
+)
+
+type CategoryUsecase interface {
+	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
+	GetCategory(ctx context.Context, id string) (*domain.Category, error)
+	UpdateCategory(ctx context.Context, category *domain.Category, moveToRoot bool) (*domain.Category, error)
+	DeleteCategory(ctx context.Context, id string) error
+	ListCategories(ctx context.Context, parentID string, includeDescendants bool) ([]*domain.Category, error)
+}
+
+type categoryUsecase struct {
+	categories repository.CategoryRepository
+	products   repository.ProductRepository
+}
+
+func NewCategoryUsecase(categories repository.CategoryRepository, products repository.ProductRepository) CategoryUsecase {
+	return &categoryUsecase{
+		categories: categories,
+		products:   products,
+	}
+}
+
+func (uc *categoryUsecase) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return nil, fmt.Errorf("%w: category name is required", domain.ErrInvalidCategory)
+	}
+	if category.ParentID != "" {
+		if err := requireCategory(ctx, uc.categories, category.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.categories.Create(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return uc.categories.FindByID(ctx, category.ID)
+}
+
+func (uc *categoryUsecase) GetCategory(ctx context.Context, id string) (*domain.Category, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: category ID is required", domain.ErrInvalidCategory)
+	}
+
+	return uc.categories.FindByID(ctx, id)
+}
+
+// UpdateCategory applies the non-empty fields of category. A category is
+// moved under ParentID when one is given, or made a root when moveToRoot
+// is set.
+func (uc *categoryUsecase) UpdateCategory(ctx context.Context, category *domain.Category, moveToRoot bool) (*domain.Category, error) {
+	if category.ID == "" {
+		return nil, fmt.Errorf("%w: category ID is required", domain.ErrInvalidCategory)
+	}
+
+	existing, err := uc.categories.FindByID(ctx, category.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(category.Name); name != "" {
+		existing.Name = name
+	}
+	if category.Description != "" {
+		existing.Description = category.Description
+	}
+
+	switch {
+	case moveToRoot:
+		existing.ParentID = ""
+	case category.ParentID != "" && category.ParentID != existing.ParentID:
+		if err := requireCategory(ctx, uc.categories, category.ParentID); err != nil {
+			return nil, err
+		}
+		// A category cannot move below itself
+		all, err := uc.categories.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range domain.DescendantIDs(all, existing.ID) {
+			if id == category.ParentID {
+				return nil, fmt.Errorf("%w: category cannot be its own ancestor", domain.ErrInvalidCategory)
+			}
+		}
+		existing.ParentID = category.ParentID
+	}
+
+	if err := uc.categories.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	return uc.categories.FindByID(ctx, existing.ID)
+}
+
+// DeleteCategory removes an empty category. Categories that still have
+// subcategories or products are kept so nothing is orphaned.
+func (uc *categoryUsecase) DeleteCategory(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: category ID is required", domain.ErrInvalidCategory)
+	}
+
+	if _, err := uc.categories.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	all, err := uc.categories.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, category := range all {
+		if category.ParentID == id {
+			return domain.ErrCategoryInUse
+		}
+	}
+
+	// Archived products count too, so restoring one never finds its
+	// category gone
+	_, products, err := uc.products.List(ctx, []string{id}, true, nil, 1)
+	if err != nil {
+		return err
+	}
+	if products > 0 {
+		return domain.ErrCategoryInUse
+	}
+
+	return uc.categories.Delete(ctx, id)
+}
+
+// ListCategories returns every category when parentID is empty. Otherwise
+// it returns the children of parentID, or its whole subtree when
+// includeDescendants is set.
+func (uc *categoryUsecase) ListCategories(ctx context.Context, parentID string, includeDescendants bool) ([]*domain.Category, error) {
+	all, err := uc.categories.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if parentID == "" {
+		return all, nil
+	}
+
+	if _, err := uc.categories.FindByID(ctx, parentID); err != nil {
+		return nil, err
+	}
+
+	wanted := map[string]bool{}
+	if includeDescendants {
+		for _, id := range domain.DescendantIDs(all, parentID)[1:] {
+			wanted[id] = true
+		}
+	}
+
+	categories := []*domain.Category{}
+	for _, category := range all {
+		if category.ParentID == parentID || wanted[category.ID] {
+			categories = append(categories, category)
+		}
+	}
+
+	return categories, nil
+}
+
+// requireCategory checks that a referenced category exists. A missing one
+// is reported as an invalid argument of the referencing request rather
+// than as a lookup that found nothing.
+func requireCategory(ctx context.Context, categories repository.CategoryRepository, id string) error {
+	_, err := categories.FindByID(ctx, id)
+	if errors.Is(err, domain.ErrCategoryNotFound) {
+		return fmt.Errorf("%w: category %s does not exist", domain.ErrInvalidCategory, id)
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"inventory-service/internal/domain"
+	"inventory-service/internal/repository"
+)
+
+// outboxBatchSize bounds how many events one relay pass reads from the
+// outbox.
+const outboxBatchSize = 100
+
+// EventBroker delivers domain events to whoever listens for them. Publish
+// returns once the broker has taken the event; an error makes the relay
+// offer it again later.
+type EventBroker interface {
+	Publish(ctx context.Context, event *domain.Event) error
+}
+
+// EventHandler handles one event delivered by an InProcessBroker.
+type EventHandler func(ctx context.Context, event *domain.Event) error
+
+// InProcessBroker is an EventBroker that hands events straight to handlers
+// subscribed in the same process, needing no infrastructure of its own.
+// Handlers run one after another within Publish; when one fails the event
+// is published again later, so every handler must cope with repeats.
+type InProcessBroker struct {
+	mu       sync.RWMutex
+	handlers []EventHandler
+}
+
+func NewInProcessBroker() *InProcessBroker {
+	return &InProcessBroker{}
+}
+
+// Subscribe adds a handler for every event published from now on.
+func (b *InProcessBroker) Subscribe(handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
+
+func (b *InProcessBroker) Publish(ctx context.Context, event *domain.Event) error {
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LogEvent is an EventHandler that writes events to the service log.
+func LogEvent(ctx context.Context, event *domain.Event) error {
+	log.Printf("event %s: %s of %s", event.ID, event.Type, event.AggregateID)
+	return nil
+}
+
+// OutboxRelay publishes the events the repositories write to the outbox,
+// oldest first, and removes them once the broker took them. An event whose
+// publication fails holds back the ones after it so that they arrive in
+// order; a crash between publishing and removing means the event is
+// published again.
+type OutboxRelay struct {
+	outbox repository.OutboxRepository
+	broker EventBroker
+}
+
+func NewOutboxRelay(outbox repository.OutboxRepository, broker EventBroker) *OutboxRelay {
+	return &OutboxRelay{
+		outbox: outbox,
+		broker: broker,
+	}
+}
+
+// Run relays pending events every interval until ctx is done.
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Relay(ctx); err != nil {
+				log.Printf("failed to relay outbox events: %v", err)
+			}
+		}
+	}
+}
+
+// Relay publishes pending events until the outbox is empty or publishing
+// fails, and returns how many it published.
+func (r *OutboxRelay) Relay(ctx context.Context) (int, error) {
+	relayed := 0
+	for {
+		events, err := r.outbox.ListPending(ctx, outboxBatchSize)
+		if err != nil {
+			return relayed, err
+		}
+
+		published := make([]string, 0, len(events))
+		var publishErr error
+		for _, event := range events {
+			if publishErr = r.broker.Publish(ctx, event); publishErr != nil {
+				break
+			}
+			published = append(published, event.ID)
+		}
+
+		if len(published) > 0 {
+			if err := r.outbox.Delete(ctx, published); err != nil {
+				return relayed, err
+			}
+			relayed += len(published)
+		}
+		if publishErr != nil {
+			return relayed, publishErr
+		}
+		if len(events) < outboxBatchSize {
+			return relayed, nil
+		}
+	}
+}
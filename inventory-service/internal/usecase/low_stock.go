@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"inventory-service/internal/domain"
+	"inventory-service/internal/repository"
+)
+
+// LowStockNotifier is told about every product whose stock falls to its
+// reorder point.
+type LowStockNotifier interface {
+	NotifyLowStock(ctx context.Context, alert domain.LowStock) error
+}
+
+type logLowStockNotifier struct{}
+
+// NewLogLowStockNotifier returns a LowStockNotifier that writes alerts to
+// the service log.
+func NewLogLowStockNotifier() LowStockNotifier {
+	return logLowStockNotifier{}
+}
+
+func (logLowStockNotifier) NotifyLowStock(ctx context.Context, alert domain.LowStock) error {
+	log.Printf("low stock: product %s (%s) is down to %d units, reorder point %d; reorder %d units",
+		alert.ProductID, alert.Name, alert.Stock, alert.ReorderPoint, alert.ReorderQuantity)
+	return nil
+}
+
+// stockCheck names an item whose stock changed. Only one of the fields is
+// set for changes made through the variant repository's AdjustStock.
+type stockCheck struct {
+	productID string
+	sku       string
+}
+
+// LowStockChecker watches stock in the background and notifies once per
+// product each time its stock falls to the reorder point. Changes made
+// through the repositories returned by Watch are checked as they happen;
+// a periodic sweep catches everything else. The checker only remembers
+// which products are low while it runs, so after a restart products that
+// are still low are reported again.
+type LowStockChecker struct {
+	products repository.ProductRepository
+	variants repository.VariantRepository
+	notifier LowStockNotifier
+	checks   chan stockCheck
+
+	mu  sync.Mutex
+	low map[string]bool
+}
+
+func NewLowStockChecker(products repository.ProductRepository, variants repository.VariantRepository, notifier LowStockNotifier) *LowStockChecker {
+	return &LowStockChecker{
+		products: products,
+		variants: variants,
+		notifier: notifier,
+		checks:   make(chan stockCheck, 256),
+		low:      map[string]bool{},
+	}
+}
+
+// Watch returns repos with the product and variant repositories wrapped
+// so that every stock or reorder point change queues a check.
+func (c *LowStockChecker) Watch(repos repository.Repositories) repository.Repositories {
+	repos.Products = watchedProducts{ProductRepository: repos.Products, checker: c}
+	repos.Variants = watchedVariants{VariantRepository: repos.Variants, checker: c}
+	return repos
+}
+
+// Run checks queued changes as they arrive and sweeps all products every
+// interval until ctx is done.
+func (c *LowStockChecker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	c.sweep(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case check := <-c.checks:
+			if err := c.check(ctx, check); err != nil {
+				log.Printf("failed to check for low stock: %v", err)
+			}
+		case <-ticker.C:
+			c.sweep(ctx)
+		}
+	}
+}
+
+// queue asks for a check without blocking the caller. When the queue is
+// full the change is left to the next sweep.
+func (c *LowStockChecker) queue(check stockCheck) {
+	select {
+	case c.checks <- check:
+	default:
+	}
+}
+
+func (c *LowStockChecker) check(ctx context.Context, check stockCheck) error {
+	productID := check.productID
+	if productID == "" {
+		variant, err := c.variants.FindBySKU(ctx, check.sku)
+		if err != nil {
+			return err
+		}
+		productID = variant.ProductID
+	}
+
+	product, err := c.products.FindByID(ctx, productID)
+	if errors.Is(err, domain.ErrProductNotFound) {
+		c.setLow(ctx, productID, nil)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	stock, err := productTotal(ctx, c.variants, product)
+	if err != nil {
+		return err
+	}
+	if product.NeedsReorder(stock) {
+		c.setLow(ctx, productID, lowStockOf(product, stock))
+	} else {
+		c.setLow(ctx, productID, nil)
+	}
+	return nil
+}
+
+func (c *LowStockChecker) sweep(ctx context.Context) {
+	alerts, err := findLowStock(ctx, c.products, c.variants)
+	if err != nil {
+		log.Printf("failed to sweep for low stock: %v", err)
+		return
+	}
+
+	current := make(map[string]bool, len(alerts))
+	for i := range alerts {
+		current[alerts[i].ProductID] = true
+		c.setLow(ctx, alerts[i].ProductID, &alerts[i])
+	}
+
+	c.mu.Lock()
+	for productID := range c.low {
+		if !current[productID] {
+			delete(c.low, productID)
+		}
+	}
+	c.mu.Unlock()
+}
+
+// setLow records whether a product is low, given by a non-nil alert, and
+// notifies when it was not low before.
+func (c *LowStockChecker) setLow(ctx context.Context, productID string, alert *domain.LowStock) {
+	c.mu.Lock()
+	wasLow := c.low[productID]
+	if alert != nil {
+		c.low[productID] = true
+	} else {
+		delete(c.low, productID)
+	}
+	c.mu.Unlock()
+
+	if alert == nil || wasLow {
+		return
+	}
+	if err := c.notifier.NotifyLowStock(ctx, *alert); err != nil {
+		log.Printf("failed to report low stock of product %s: %v", productID, err)
+	}
+}
+
+// findLowStock returns every product whose stock has fallen to its
+// reorder point.
+func findLowStock(ctx context.Context, products repository.ProductRepository, variants repository.VariantRepository) ([]domain.LowStock, error) {
+	reorderable, err := products.ListReorderable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := []domain.LowStock{}
+	for _, product := range reorderable {
+		stock, err := productTotal(ctx, variants, product)
+		if err != nil {
+			return nil, err
+		}
+		if product.NeedsReorder(stock) {
+			alerts = append(alerts, *lowStockOf(product, stock))
+		}
+	}
+	return alerts, nil
+}
+
+// productTotal returns the stock of a product, summed over its variants
+// for products sold in variants.
+func productTotal(ctx context.Context, variants repository.VariantRepository, product *domain.Product) (int, error) {
+	productVariants, err := variants.ListByProduct(ctx, product.ID)
+	if err != nil {
+		return 0, err
+	}
+	if len(productVariants) == 0 {
+		return product.Stock, nil
+	}
+
+	total := 0
+	for _, variant := range productVariants {
+		total += variant.Stock
+	}
+	return total, nil
+}
+
+func lowStockOf(product *domain.Product, stock int) *domain.LowStock {
+	return &domain.LowStock{
+		ProductID:       product.ID,
+		Name:            product.Name,
+		Stock:           stock,
+		ReorderPoint:    product.ReorderPoint,
+		ReorderQuantity: product.ReorderQuantity,
+	}
+}
+
+// watchedProducts queues a low-stock check after every change that can
+// move a product to or from its reorder point.
+type watchedProducts struct {
+	repository.ProductRepository
+	checker *LowStockChecker
+}
+
+func (r watchedProducts) Create(ctx context.Context, product *domain.Product) error {
+	if err := r.ProductRepository.Create(ctx, product); err != nil {
+		return err
+	}
+	r.checker.queue(stockCheck{productID: product.ID})
+	return nil
+}
+
+func (r watchedProducts) Update(ctx context.Context, product *domain.Product) error {
+	if err := r.ProductRepository.Update(ctx, product); err != nil {
+		return err
+	}
+	r.checker.queue(stockCheck{productID: product.ID})
+	return nil
+}
+
+func (r watchedProducts) AdjustStock(ctx context.Context, id string, delta int) (int, error) {
+	stock, err := r.ProductRepository.AdjustStock(ctx, id, delta)
+	if err != nil {
+		return 0, err
+	}
+	r.checker.queue(stockCheck{productID: id})
+	return stock, nil
+}
+
+// watchedVariants queues a low-stock check of the owning product after
+// every change to a variant's stock.
+type watchedVariants struct {
+	repository.VariantRepository
+	checker *LowStockChecker
+}
+
+func (r watchedVariants) Create(ctx context.Context, variant *domain.Variant) error {
+	if err := r.VariantRepository.Create(ctx, variant); err != nil {
+		return err
+	}
+	r.checker.queue(stockCheck{productID: variant.ProductID})
+	return nil
+}
+
+func (r watchedVariants) AdjustStock(ctx context.Context, sku string, delta int) (int, error) {
+	stock, err := r.VariantRepository.AdjustStock(ctx, sku, delta)
+	if err != nil {
+		return 0, err
+	}
+	r.checker.queue(stockCheck{sku: sku})
+	return stock, nil
+}
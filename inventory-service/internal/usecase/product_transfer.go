@@ -0,0 +1,431 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"inventory-service/internal/domain"
+)
+
+const (
+	// maxImportErrors caps the row errors an import reports
+	maxImportErrors = 1000
+	// maxImportLine bounds a JSON Lines row
+	maxImportLine = 1 << 20
+	// exportPageSize is how many products an export reads at a time
+	exportPageSize = 100
+)
+
+// transferColumns are the CSV columns of an export, in order. Imports take
+// them in any order and only need sku, name and price; id is exported for
+// reference and ignored on import, and stock only sets up new products.
+var transferColumns = []string{
+	"id", "sku", "name", "description", "price", "stock", "category_id",
+	"reorder_point", "reorder_quantity", "backorder_mode", "available_at",
+}
+
+// productRecord is a product as it appears in an import or export file.
+// The pointer fields are nil when a row leaves them out.
+type productRecord struct {
+	ID              string     `json:"id,omitempty"`
+	SKU             string     `json:"sku"`
+	Name            string     `json:"name"`
+	Description     string     `json:"description,omitempty"`
+	Price           float64    `json:"price"`
+	Stock           *int       `json:"stock,omitempty"`
+	CategoryID      string     `json:"category_id,omitempty"`
+	ReorderPoint    *int       `json:"reorder_point,omitempty"`
+	ReorderQuantity *int       `json:"reorder_quantity,omitempty"`
+	BackorderMode   string     `json:"backorder_mode,omitempty"`
+	AvailableAt     *time.Time `json:"available_at,omitempty"`
+}
+
+func newProductRecord(product *domain.Product) *productRecord {
+	stock, reorderPoint, reorderQuantity := product.Stock, product.ReorderPoint, product.ReorderQuantity
+	return &productRecord{
+		ID:              product.ID,
+		SKU:             product.SKU,
+		Name:            product.Name,
+		Description:     product.Description,
+		Price:           product.Price,
+		Stock:           &stock,
+		CategoryID:      product.CategoryID,
+		ReorderPoint:    &reorderPoint,
+		ReorderQuantity: &reorderQuantity,
+		BackorderMode:   string(product.BackorderMode),
+		AvailableAt:     product.AvailableAt,
+	}
+}
+
+// product builds the product a record creates. Fields left out take the
+// same defaults as in CreateProduct.
+func (r *productRecord) product() *domain.Product {
+	return &domain.Product{
+		SKU:             r.SKU,
+		Name:            r.Name,
+		Description:     r.Description,
+		Price:           r.Price,
+		Stock:           valueOr(r.Stock, 0),
+		CategoryID:      r.CategoryID,
+		ReorderPoint:    valueOr(r.ReorderPoint, 0),
+		ReorderQuantity: valueOr(r.ReorderQuantity, 0),
+		BackorderMode:   domain.BackorderMode(r.BackorderMode),
+		AvailableAt:     r.AvailableAt,
+	}
+}
+
+// update builds the change a record makes to the existing product id, in
+// the form UpdateProduct takes. Fields left out keep their values, and so
+// does the stock: it is only taken from rows that create a product.
+func (r *productRecord) update(id string) *domain.Product {
+	return &domain.Product{
+		ID:              id,
+		Name:            r.Name,
+		Description:     r.Description,
+		Price:           r.Price,
+		CategoryID:      r.CategoryID,
+		ReorderPoint:    valueOr(r.ReorderPoint, -1),
+		ReorderQuantity: valueOr(r.ReorderQuantity, -1),
+		BackorderMode:   domain.BackorderMode(r.BackorderMode),
+		AvailableAt:     r.AvailableAt,
+	}
+}
+
+func (r *productRecord) csvFields() []string {
+	var availableAt string
+	if r.AvailableAt != nil {
+		availableAt = r.AvailableAt.Format(time.RFC3339)
+	}
+	return []string{
+		r.ID, r.SKU, r.Name, r.Description,
+		strconv.FormatFloat(r.Price, 'f', -1, 64),
+		strconv.Itoa(valueOr(r.Stock, 0)),
+		r.CategoryID,
+		strconv.Itoa(valueOr(r.ReorderPoint, 0)),
+		strconv.Itoa(valueOr(r.ReorderQuantity, 0)),
+		r.BackorderMode,
+		availableAt,
+	}
+}
+
+func valueOr(value *int, def int) int {
+	if value == nil {
+		return def
+	}
+	return *value
+}
+
+// rowError is a row the import cannot read; the rows after it still are.
+type rowError struct {
+	err error
+}
+
+func (e *rowError) Error() string {
+	return e.err.Error()
+}
+
+// recordReader returns the next record of a file, io.EOF after the last
+// one, or a nil record for a row with nothing in it.
+type recordReader func() (*productRecord, error)
+
+// ImportProducts creates or updates a product for every row of r, matching
+// rows to products by SKU. Rows are validated like CreateProduct input;
+// the columns an update row leaves empty keep their values. A row that
+// fails is reported and skipped. Only a file that cannot be read at all
+// fails the import, and then the rows before the failure stay imported.
+func (uc *productUsecase) ImportProducts(ctx context.Context, format domain.FileFormat, r io.Reader) (*domain.ImportReport, error) {
+	var (
+		next recordReader
+		err  error
+	)
+	switch format {
+	case domain.FileFormatCSV:
+		next, err = csvRecords(r)
+	case domain.FileFormatJSONL:
+		next = jsonlRecords(r)
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", domain.ErrInvalidImport, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ImportReport{Errors: []domain.ImportRowError{}}
+	fail := func(row int, sku string, err error) {
+		report.Failed++
+		if len(report.Errors) < maxImportErrors {
+			report.Errors = append(report.Errors, domain.ImportRowError{Row: row, SKU: sku, Error: err.Error()})
+		}
+	}
+
+	for row := 1; ; row++ {
+		record, err := next()
+		if errors.Is(err, io.EOF) {
+			return report, nil
+		}
+		var invalid *rowError
+		if errors.As(err, &invalid) {
+			fail(row, "", invalid)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if record == nil {
+			continue
+		}
+
+		created, err := uc.importRecord(ctx, record)
+		if err != nil {
+			// A cancelled import stops instead of failing every row left
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			fail(row, record.SKU, err)
+			continue
+		}
+		if created {
+			report.Created++
+		} else {
+			report.Updated++
+		}
+	}
+}
+
+// importRecord creates or updates the product with the record's SKU and
+// reports whether it created one.
+func (uc *productUsecase) importRecord(ctx context.Context, record *productRecord) (bool, error) {
+	record.SKU = strings.TrimSpace(record.SKU)
+	if record.SKU == "" {
+		return false, errors.New("product SKU is required")
+	}
+
+	// Every row must make a valid product, even one that only updates
+	product := record.product()
+	if err := uc.validateProduct(ctx, product); err != nil {
+		return false, err
+	}
+
+	existing, err := uc.repo.FindBySKU(ctx, record.SKU)
+	if errors.Is(err, domain.ErrProductNotFound) {
+		_, err := uc.create(ctx, product)
+		return true, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := uc.UpdateProduct(ctx, record.update(existing.ID)); err != nil {
+		return false, err
+	}
+	// Importing a deleted product brings it back
+	if existing.IsArchived() {
+		if err := uc.repo.Restore(ctx, existing.ID); err != nil && !errors.Is(err, domain.ErrProductNotArchived) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// ExportProducts writes the catalog to w, newest products first. Archived
+// products are left out.
+func (uc *productUsecase) ExportProducts(ctx context.Context, format domain.FileFormat, w io.Writer) error {
+	var (
+		write func(*productRecord) error
+		flush = func() error { return nil }
+	)
+	switch format {
+	case domain.FileFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(transferColumns); err != nil {
+			return err
+		}
+		write = func(record *productRecord) error {
+			return writer.Write(record.csvFields())
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case domain.FileFormatJSONL:
+		encoder := json.NewEncoder(w)
+		write = func(record *productRecord) error {
+			return encoder.Encode(record)
+		}
+	default:
+		return fmt.Errorf("%w: unknown format %q", domain.ErrInvalidImport, format)
+	}
+
+	var after *domain.PageCursor
+	for {
+		products, _, err := uc.repo.List(ctx, nil, false, after, exportPageSize)
+		if err != nil {
+			return err
+		}
+		for _, product := range products {
+			if err := write(newProductRecord(product)); err != nil {
+				return err
+			}
+		}
+		// Flushed page by page so a failing writer stops the export early
+		if err := flush(); err != nil {
+			return err
+		}
+
+		if len(products) < exportPageSize {
+			return nil
+		}
+		after = domain.ProductCursor(products[len(products)-1])
+	}
+}
+
+// csvRecords reads records from CSV with a header row. Unknown, repeated
+// or missing required columns fail the whole import.
+func csvRecords(r io.Reader) (recordReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return func() (*productRecord, error) { return nil, io.EOF }, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: read header: %v", domain.ErrInvalidImport, err)
+	}
+
+	known := make(map[string]bool, len(transferColumns))
+	for _, column := range transferColumns {
+		known[column] = true
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !known[name] {
+			return nil, fmt.Errorf("%w: unknown column %q", domain.ErrInvalidImport, name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: column %q appears twice", domain.ErrInvalidImport, name)
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"sku", "name", "price"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", domain.ErrInvalidImport, required)
+		}
+	}
+
+	return func() (*productRecord, error) {
+		fields, err := reader.Read()
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &rowError{parseErr.Err}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) != len(header) {
+			return nil, &rowError{fmt.Errorf("row has %d fields, the header %d", len(fields), len(header))}
+		}
+
+		record, err := parseCSVRecord(columns, fields)
+		if err != nil {
+			return nil, &rowError{err}
+		}
+		return record, nil
+	}, nil
+}
+
+func parseCSVRecord(columns map[string]int, fields []string) (*productRecord, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+	optionalInt := func(name string) (*int, error) {
+		value := field(name)
+		if value == "" {
+			return nil, nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s %q is not a whole number", name, value)
+		}
+		return &n, nil
+	}
+
+	record := &productRecord{
+		SKU:           field("sku"),
+		Name:          field("name"),
+		Description:   field("description"),
+		CategoryID:    field("category_id"),
+		BackorderMode: field("backorder_mode"),
+	}
+
+	var err error
+	if value := field("price"); value != "" {
+		if record.Price, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("price %q is not a number", value)
+		}
+	}
+	if record.Stock, err = optionalInt("stock"); err != nil {
+		return nil, err
+	}
+	if record.ReorderPoint, err = optionalInt("reorder_point"); err != nil {
+		return nil, err
+	}
+	if record.ReorderQuantity, err = optionalInt("reorder_quantity"); err != nil {
+		return nil, err
+	}
+	if value := field("available_at"); value != "" {
+		availableAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("available_at %q is not an RFC 3339 time", value)
+		}
+		record.AvailableAt = &availableAt
+	}
+
+	return record, nil
+}
+
+// jsonlRecords reads one JSON object per line. Blank lines are skipped;
+// a line longer than maxImportLine fails the whole import.
+func jsonlRecords(r io.Reader) recordReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+
+	return func() (*productRecord, error) {
+		if !scanner.Scan() {
+			if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+				return nil, fmt.Errorf("%w: a line is longer than %d bytes", domain.ErrInvalidImport, maxImportLine)
+			}
+			if err := scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			return nil, nil
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		var record productRecord
+		if err := decoder.Decode(&record); err != nil {
+			return nil, &rowError{fmt.Errorf("invalid JSON: %v", err)}
+		}
+		return &record, nil
+	}
+}
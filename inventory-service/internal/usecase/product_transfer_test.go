@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"inventory-service/internal/domain"
+	"inventory-service/internal/repository"
+)
+
+func newTestProductUsecase() (ProductUsecase, repository.Repositories) {
+	repos := repository.NewMemoryRepositories()
+	uc := NewProductUsecase(repos.Products, repos.Categories, repos.Variants, repos.StockLevels, repos.Movements)
+	return uc, repos
+}
+
+func TestImportProducts(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  domain.FileFormat
+		input   string
+		want    domain.ImportReport
+		wantErr error
+	}{
+		{
+			name:   "CSV creates and updates by SKU",
+			format: domain.FileFormatCSV,
+			input: "sku,name,price,stock\n" +
+				"SKU-1,Renamed,12.5,100\n" +
+				"SKU-2,New,3,7\n",
+			want: domain.ImportReport{Created: 1, Updated: 1, Errors: []domain.ImportRowError{}},
+		},
+		{
+			name:   "CSV columns in any order",
+			format: domain.FileFormatCSV,
+			input: "\ufeffPrice, Name ,SKU,reorder_point\n" +
+				"4,Reordered,SKU-2,3\n",
+			want: domain.ImportReport{Created: 1, Errors: []domain.ImportRowError{}},
+		},
+		{
+			name:   "CSV bad rows are reported and skipped",
+			format: domain.FileFormatCSV,
+			input: "sku,name,price,stock\n" +
+				"SKU-2,Cheap,abc,1\n" +
+				"SKU-3,Free,0,1\n" +
+				",Nameless,1,1\n" +
+				"SKU-4,Short\n" +
+				"SKU-5,Fine,2,1\n" +
+				"SKU-6,Negative,2,-1\n",
+			want: domain.ImportReport{Created: 1, Failed: 5, Errors: []domain.ImportRowError{
+				{Row: 1, Error: `price "abc" is not a number`},
+				{Row: 2, SKU: "SKU-3", Error: "product price must be positive"},
+				{Row: 3, Error: "product SKU is required"},
+				{Row: 4, Error: "row has 2 fields, the header 4"},
+				{Row: 6, SKU: "SKU-6", Error: "product stock cannot be negative"},
+			}},
+		},
+		{
+			name:    "CSV unknown column",
+			format:  domain.FileFormatCSV,
+			input:   "sku,name,price,colour\nSKU-2,New,3,red\n",
+			wantErr: domain.ErrInvalidImport,
+		},
+		{
+			name:    "CSV missing required column",
+			format:  domain.FileFormatCSV,
+			input:   "sku,name\nSKU-2,New\n",
+			wantErr: domain.ErrInvalidImport,
+		},
+		{
+			name:   "CSV header only",
+			format: domain.FileFormatCSV,
+			input:  "sku,name,price\n",
+			want:   domain.ImportReport{Errors: []domain.ImportRowError{}},
+		},
+		{
+			name:   "JSON Lines creates and updates by SKU",
+			format: domain.FileFormatJSONL,
+			input: `{"sku":"SKU-1","name":"Renamed","price":12.5,"stock":100}` + "\n" +
+				"\n" +
+				`{"sku":"SKU-2","name":"New","price":3,"backorder_mode":"preorder","available_at":"2030-01-02T00:00:00Z"}` + "\n",
+			want: domain.ImportReport{Created: 1, Updated: 1, Errors: []domain.ImportRowError{}},
+		},
+		{
+			name:   "JSON Lines bad rows are reported and skipped",
+			format: domain.FileFormatJSONL,
+			input: `{"sku":"SKU-2","name":"New",` + "\n" +
+				`{"sku":"SKU-3","name":"New","price":3,"colour":"red"}` + "\n" +
+				`{"sku":"SKU-4","name":"New","price":3,"backorder_mode":"sometimes"}` + "\n" +
+				`{"sku":"SKU-5","name":"New","price":3}` + "\n",
+			want: domain.ImportReport{Created: 1, Failed: 3, Errors: []domain.ImportRowError{
+				{Row: 1, Error: "invalid JSON: unexpected EOF"},
+				{Row: 2, Error: `invalid JSON: json: unknown field "colour"`},
+				{Row: 3, SKU: "SKU-4", Error: `unknown backorder mode "sometimes"`},
+			}},
+		},
+		{
+			name:    "unknown format",
+			format:  "xml",
+			input:   "<products/>",
+			wantErr: domain.ErrInvalidImport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uc, _ := newTestProductUsecase()
+			if _, err := uc.CreateProduct(ctx, &domain.Product{SKU: "SKU-1", Name: "Existing", Price: 10, Stock: 5}); err != nil {
+				t.Fatalf("CreateProduct: %v", err)
+			}
+
+			report, err := uc.ImportProducts(ctx, tt.format, strings.NewReader(tt.input))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ImportProducts returned %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportProducts: %v", err)
+			}
+			if !reflect.DeepEqual(*report, tt.want) {
+				t.Fatalf("report = %+v, want %+v", *report, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportProductsKeepsStockOfExistingProducts(t *testing.T) {
+	ctx := context.Background()
+	uc, repos := newTestProductUsecase()
+	if _, err := uc.CreateProduct(ctx, &domain.Product{SKU: "SKU-1", Name: "Existing", Price: 10, Stock: 5, ReorderPoint: 2}); err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+
+	input := "sku,name,price,stock,reorder_point\nSKU-1,Renamed,12.5,100,\n"
+	if _, err := uc.ImportProducts(ctx, domain.FileFormatCSV, strings.NewReader(input)); err != nil {
+		t.Fatalf("ImportProducts: %v", err)
+	}
+
+	product, err := repos.Products.FindBySKU(ctx, "SKU-1")
+	if err != nil {
+		t.Fatalf("FindBySKU: %v", err)
+	}
+	if product.Name != "Renamed" || product.Price != 12.5 {
+		t.Fatalf("product = %+v, want the row's name and price", product)
+	}
+	if product.Stock != 5 || product.ReorderPoint != 2 {
+		t.Fatalf("stock %d and reorder point %d changed, want 5 and 2", product.Stock, product.ReorderPoint)
+	}
+}
+
+func TestExportProductsRoundTrip(t *testing.T) {
+	availableAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	products := []*domain.Product{
+		{SKU: "SKU-1", Name: "Plain", Price: 10, Stock: 5},
+		{SKU: "SKU-2", Name: "Quoted, \"name\"", Description: "two\nlines", Price: 2.75, Stock: 0, ReorderPoint: 3, ReorderQuantity: 12},
+		{SKU: "SKU-3", Name: "Preorder", Price: 99.99, BackorderMode: domain.BackorderPreorder, AvailableAt: &availableAt},
+	}
+
+	for _, format := range []domain.FileFormat{domain.FileFormatCSV, domain.FileFormatJSONL} {
+		t.Run(string(format), func(t *testing.T) {
+			ctx := context.Background()
+			source, sourceRepos := newTestProductUsecase()
+			for _, product := range products {
+				product := *product
+				if _, err := source.CreateProduct(ctx, &product); err != nil {
+					t.Fatalf("CreateProduct: %v", err)
+				}
+			}
+			// Archived products are left out
+			archived, err := source.CreateProduct(ctx, &domain.Product{SKU: "SKU-4", Name: "Gone", Price: 1})
+			if err != nil {
+				t.Fatalf("CreateProduct: %v", err)
+			}
+			if err := sourceRepos.Products.Archive(ctx, archived.ID, time.Now()); err != nil {
+				t.Fatalf("Archive: %v", err)
+			}
+
+			var file bytes.Buffer
+			if err := source.ExportProducts(ctx, format, &file); err != nil {
+				t.Fatalf("ExportProducts: %v", err)
+			}
+			if format == domain.FileFormatCSV {
+				header, err := csv.NewReader(bytes.NewReader(file.Bytes())).Read()
+				if err != nil || !reflect.DeepEqual(header, transferColumns) {
+					t.Fatalf("CSV header = %v, %v, want %v", header, err, transferColumns)
+				}
+			}
+
+			target, targetRepos := newTestProductUsecase()
+			report, err := target.ImportProducts(ctx, format, &file)
+			if err != nil {
+				t.Fatalf("ImportProducts: %v", err)
+			}
+			if report.Created != len(products) || report.Updated != 0 || report.Failed != 0 {
+				t.Fatalf("report = %+v, want %d created", report, len(products))
+			}
+
+			for _, want := range products {
+				got, err := targetRepos.Products.FindBySKU(ctx, want.SKU)
+				if err != nil {
+					t.Fatalf("FindBySKU(%s): %v", want.SKU, err)
+				}
+				wantMode := want.BackorderMode
+				if wantMode == "" {
+					wantMode = domain.BackorderNone
+				}
+				if got.Name != want.Name || got.Description != want.Description || got.Price != want.Price ||
+					got.Stock != want.Stock || got.ReorderPoint != want.ReorderPoint || got.ReorderQuantity != want.ReorderQuantity ||
+					got.BackorderMode != wantMode {
+					t.Fatalf("imported %+v, want %+v", got, want)
+				}
+				if (got.AvailableAt == nil) != (want.AvailableAt == nil) || (got.AvailableAt != nil && !got.AvailableAt.Equal(*want.AvailableAt)) {
+					t.Fatalf("%s available at %v, want %v", want.SKU, got.AvailableAt, want.AvailableAt)
+				}
+			}
+			if _, err := targetRepos.Products.FindBySKU(ctx, "SKU-4"); !errors.Is(err, domain.ErrProductNotFound) {
+				t.Fatalf("archived product was exported: %v", err)
+			}
+		})
+	}
+}
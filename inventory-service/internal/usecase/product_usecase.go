@@ -3,50 +3,110 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"inventory-service/internal/domain"
 	"inventory-service/internal/repository"
 )
 
+// purgeBatchSize is how many archived products a purge reads at a time.
+const purgeBatchSize = 100
+
 type ProductUsecase interface {
 	CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
 	GetProduct(ctx context.Context, id string) (*domain.Product, error)
 	UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
 	DeleteProduct(ctx context.Context, id string) error
-	ListProducts(ctx context.Context, page, limit int, categoryID string) ([]*domain.Product, int, error)
+	RestoreProduct(ctx context.Context, id string) (*domain.Product, error)
+	PurgeArchivedProducts(ctx context.Context, retention time.Duration) (int, error)
+	ListProducts(ctx context.Context, categoryID string, includeDescendants, includeArchived bool, after *domain.PageCursor, limit int) ([]*domain.Product, int, *domain.PageCursor, error)
+	SearchProducts(ctx context.Context, search domain.ProductSearch) (*domain.ProductSearchResult, error)
+	ListLowStock(ctx context.Context) ([]domain.LowStock, error)
+	ImportProducts(ctx context.Context, format domain.FileFormat, r io.Reader) (*domain.ImportReport, error)
+	ExportProducts(ctx context.Context, format domain.FileFormat, w io.Writer) error
 }
 
 type productUsecase struct {
-	repo repository.ProductRepository
+	repo        repository.ProductRepository
+	categories  repository.CategoryRepository
+	variants    repository.VariantRepository
+	stockLevels repository.StockLevelRepository
+	movements   repository.StockMovementRepository
 }
 
-func NewProductUsecase(repo repository.ProductRepository) ProductUsecase {
-	return &productUsecase{repo: repo}
+func NewProductUsecase(repo repository.ProductRepository, categories repository.CategoryRepository, variants repository.VariantRepository, stockLevels repository.StockLevelRepository, movements repository.StockMovementRepository) ProductUsecase {
+	return &productUsecase{
+		repo:        repo,
+		categories:  categories,
+		variants:    variants,
+		stockLevels: stockLevels,
+		movements:   movements,
+	}
 }
 
 func (uc *productUsecase) CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
-	// Validate required fields
-	if product.Name == "" {
-		return nil, errors.New("product name is required")
-	}
-	if product.Price <= 0 {
-		return nil, errors.New("product price must be positive")
-	}
-	if product.Stock < 0 {
-		return nil, errors.New("product stock cannot be negative")
+	if err := uc.validateProduct(ctx, product); err != nil {
+		return nil, err
 	}
+	return uc.create(ctx, product)
+}
 
+// create stores a validated product and records its initial stock.
+func (uc *productUsecase) create(ctx context.Context, product *domain.Product) (*domain.Product, error) {
 	// Generate ID if not provided
 	if product.ID == "" {
 		product.ID = generateID() // Implement your ID generation logic
 	}
 
-	err := uc.repo.Create(product)
+	err := uc.repo.Create(ctx, product)
 	if err != nil {
 		return nil, err
 	}
+	if err := recordMovements(ctx, uc.movements, domain.StockMovement{
+		ProductID: product.ID,
+		Delta:     product.Stock,
+		Reason:    domain.MovementRestock,
+		Note:      "initial stock",
+	}); err != nil {
+		return nil, fmt.Errorf("product %s created: %w", product.ID, err)
+	}
 
 	// Return the created product with populated fields (like timestamps)
-	return uc.repo.FindByID(product.ID)
+	return uc.repo.FindByID(ctx, product.ID)
+}
+
+// validateProduct checks a new product and fills in its defaults.
+func (uc *productUsecase) validateProduct(ctx context.Context, product *domain.Product) error {
+	// Validate required fields
+	product.SKU = strings.TrimSpace(product.SKU)
+	if product.Name == "" {
+		return errors.New("product name is required")
+	}
+	if product.Price <= 0 {
+		return errors.New("product price must be positive")
+	}
+	if product.Stock < 0 {
+		return errors.New("product stock cannot be negative")
+	}
+	if product.ReorderPoint < 0 || product.ReorderQuantity < 0 {
+		return errors.New("product reorder point and quantity cannot be negative")
+	}
+	if product.BackorderMode == "" {
+		product.BackorderMode = domain.BackorderNone
+	}
+	if !product.BackorderMode.IsValid() {
+		return fmt.Errorf("unknown backorder mode %q", product.BackorderMode)
+	}
+	if product.CategoryID != "" {
+		if err := requireCategory(ctx, uc.categories, product.CategoryID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (uc *productUsecase) GetProduct(ctx context.Context, id string) (*domain.Product, error) {
@@ -54,7 +114,7 @@ func (uc *productUsecase) GetProduct(ctx context.Context, id string) (*domain.Pr
 		return nil, errors.New("product ID is required")
 	}
 
-	product, err := uc.repo.FindByID(id)
+	product, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -62,18 +122,29 @@ func (uc *productUsecase) GetProduct(ctx context.Context, id string) (*domain.Pr
 	return product, nil
 }
 
+// UpdateProduct applies the set fields of product. Empty strings, negative
+// reorder fields and a nil AvailableAt are left unchanged; a zero
+// AvailableAt clears the date. Stock is never changed here, only through
+// AdjustStock.
 func (uc *productUsecase) UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
 	if product.ID == "" {
 		return nil, errors.New("product ID is required")
 	}
 
 	// Verify the product exists
-	existing, err := uc.repo.FindByID(product.ID)
+	existing, err := uc.repo.FindByID(ctx, product.ID)
 	if err != nil {
 		return nil, err
 	}
+	// A version, when given, is the one the caller based its changes on
+	if product.Version != 0 && product.Version != existing.Version {
+		return nil, domain.ErrVersionConflict
+	}
 
 	// Apply updates
+	if sku := strings.TrimSpace(product.SKU); sku != "" {
+		existing.SKU = sku
+	}
 	if product.Name != "" {
 		existing.Name = product.Name
 	}
@@ -83,51 +154,197 @@ func (uc *productUsecase) UpdateProduct(ctx context.Context, product *domain.Pro
 	if product.Price > 0 {
 		existing.Price = product.Price
 	}
-	if product.Stock >= 0 {
-		existing.Stock = product.Stock
-	}
-	if product.CategoryID != "" {
+	if product.CategoryID != "" && product.CategoryID != existing.CategoryID {
+		if err := requireCategory(ctx, uc.categories, product.CategoryID); err != nil {
+			return nil, err
+		}
 		existing.CategoryID = product.CategoryID
 	}
+	if product.ReorderPoint >= 0 {
+		existing.ReorderPoint = product.ReorderPoint
+	}
+	if product.ReorderQuantity >= 0 {
+		existing.ReorderQuantity = product.ReorderQuantity
+	}
+	if product.BackorderMode != "" {
+		if !product.BackorderMode.IsValid() {
+			return nil, fmt.Errorf("unknown backorder mode %q", product.BackorderMode)
+		}
+		existing.BackorderMode = product.BackorderMode
+	}
+	switch {
+	case product.AvailableAt == nil:
+	case product.AvailableAt.IsZero():
+		existing.AvailableAt = nil
+	default:
+		existing.AvailableAt = product.AvailableAt
+	}
 
-	err = uc.repo.Update(existing)
+	err = uc.repo.Update(ctx, existing)
 	if err != nil {
 		return nil, err
 	}
 
 	// Return the updated product
-	return uc.repo.FindByID(product.ID)
+	return uc.repo.FindByID(ctx, product.ID)
 }
 
+// DeleteProduct archives a product. It stays retrievable by ID, for the
+// orders that refer to it, until PurgeArchivedProducts removes it.
 func (uc *productUsecase) DeleteProduct(ctx context.Context, id string) error {
 	if id == "" {
 		return errors.New("product ID is required")
 	}
 
-	// Verify the product exists
-	_, err := uc.repo.FindByID(id)
-	if err != nil {
-		return err
+	return uc.repo.Archive(ctx, id, time.Now())
+}
+
+// RestoreProduct brings an archived product back into the catalog.
+func (uc *productUsecase) RestoreProduct(ctx context.Context, id string) (*domain.Product, error) {
+	if id == "" {
+		return nil, errors.New("product ID is required")
 	}
 
-	return uc.repo.Delete(id)
+	if err := uc.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.repo.FindByID(ctx, id)
+}
+
+// PurgeArchivedProducts removes for good the products archived longer
+// than retention, together with their variants and warehouse levels, and
+// returns how many it removed.
+func (uc *productUsecase) PurgeArchivedProducts(ctx context.Context, retention time.Duration) (int, error) {
+	cutoff := time.Now().Add(-retention)
+
+	purged := 0
+	for {
+		products, err := uc.repo.ListArchivedBefore(ctx, cutoff, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, product := range products {
+			if err := uc.purge(ctx, product.ID); err != nil {
+				return purged, err
+			}
+			purged++
+		}
+
+		if len(products) < purgeBatchSize {
+			return purged, nil
+		}
+	}
 }
 
-func (uc *productUsecase) ListProducts(ctx context.Context, page, limit int, categoryID string) ([]*domain.Product, int, error) {
-	// Validate pagination parameters
-	if page < 1 {
-		page = 1
+// purge removes a product and everything hanging off it.
+func (uc *productUsecase) purge(ctx context.Context, id string) error {
+	// Variants and warehouse levels go first so a failure cannot leave
+	// them without a product
+	if err := uc.stockLevels.DeleteByProduct(ctx, id); err != nil {
+		return err
+	}
+	if err := uc.variants.DeleteByProduct(ctx, id); err != nil {
+		return err
+	}
+
+	err := uc.repo.Delete(ctx, id)
+	if errors.Is(err, domain.ErrProductNotFound) {
+		// Purged concurrently
+		return nil
 	}
+	return err
+}
+
+// ListProducts returns one page of products and the cursor of the next
+// page, which is nil on the last one. With includeDescendants the products
+// of every category below categoryID are listed too. Archived products
+// are only listed with includeArchived.
+func (uc *productUsecase) ListProducts(ctx context.Context, categoryID string, includeDescendants, includeArchived bool, after *domain.PageCursor, limit int) ([]*domain.Product, int, *domain.PageCursor, error) {
 	if limit < 1 || limit > 100 {
 		limit = 10
 	}
 
-	products, total, err := uc.repo.List(page, limit, categoryID)
+	var categoryIDs []string
+	switch {
+	case categoryID == "":
+	case includeDescendants:
+		categories, err := uc.categories.List(ctx)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		categoryIDs = domain.DescendantIDs(categories, categoryID)
+	default:
+		categoryIDs = []string{categoryID}
+	}
+
+	// Fetch one extra product to learn whether another page follows
+	products, total, err := uc.repo.List(ctx, categoryIDs, includeArchived, after, limit+1)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
+	}
+
+	var next *domain.PageCursor
+	if len(products) > limit {
+		products = products[:limit]
+		next = domain.ProductCursor(products[limit-1])
 	}
 
-	return products, total, nil
+	return products, total, next, nil
+}
+
+func (uc *productUsecase) SearchProducts(ctx context.Context, search domain.ProductSearch) (*domain.ProductSearchResult, error) {
+	if search.Page < 1 {
+		search.Page = 1
+	}
+	if search.Limit < 1 || search.Limit > 100 {
+		search.Limit = 10
+	}
+
+	search.Query = strings.TrimSpace(search.Query)
+	if search.MinPrice < 0 || search.MaxPrice < 0 {
+		return nil, fmt.Errorf("%w: price bounds cannot be negative", domain.ErrInvalidSearch)
+	}
+	if search.MaxPrice > 0 && search.MinPrice > search.MaxPrice {
+		return nil, fmt.Errorf("%w: min price exceeds max price", domain.ErrInvalidSearch)
+	}
+
+	if search.SortBy == "" {
+		search.SortBy = domain.ProductSortCreatedAt
+		if search.Query != "" {
+			search.SortBy = domain.ProductSortRelevance
+		}
+	}
+	if !search.SortBy.IsValid() {
+		return nil, fmt.Errorf("%w: unknown sort %q", domain.ErrInvalidSearch, search.SortBy)
+	}
+
+	switch {
+	case search.SortBy == domain.ProductSortRelevance:
+		// Best matches always come first
+		search.Order = domain.SortDescending
+	case search.Order == "":
+		search.Order = domain.SortAscending
+		if search.SortBy == domain.ProductSortCreatedAt {
+			search.Order = domain.SortDescending
+		}
+	case search.Order != domain.SortAscending && search.Order != domain.SortDescending:
+		return nil, fmt.Errorf("%w: unknown sort order %q", domain.ErrInvalidSearch, search.Order)
+	}
+
+	result, err := uc.repo.Search(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+	result.Page, result.Limit = search.Page, search.Limit
+
+	return result, nil
+}
+
+// ListLowStock returns every product whose stock has fallen to its
+// reorder point.
+func (uc *productUsecase) ListLowStock(ctx context.Context) ([]domain.LowStock, error) {
+	return findLowStock(ctx, uc.repo, uc.variants)
 }
 
 // Helper function to generate IDs (replace with your preferred ID generation)
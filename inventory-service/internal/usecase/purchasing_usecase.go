@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"inventory-service/internal/domain"
+	"inventory-service/internal/repository"
+)
+
+// PurchasingUsecase manages suppliers and the purchase orders stock is
+// bought with. Receiving a purchase order raises stock like any other
+// stock change: totals, warehouse levels and the ledger move together.
+type PurchasingUsecase interface {
+	CreateSupplier(ctx context.Context, supplier *domain.Supplier) (*domain.Supplier, error)
+	GetSupplier(ctx context.Context, id string) (*domain.Supplier, error)
+	UpdateSupplier(ctx context.Context, id string, update domain.SupplierUpdate) (*domain.Supplier, error)
+	ListSuppliers(ctx context.Context) ([]*domain.Supplier, error)
+	CreatePurchaseOrder(ctx context.Context, po *domain.PurchaseOrder) (*domain.PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, id string) (*domain.PurchaseOrder, error)
+	ListPurchaseOrders(ctx context.Context, filter domain.PurchaseOrderFilter, after *domain.PageCursor, limit int) ([]*domain.PurchaseOrder, *domain.PageCursor, error)
+	ReceivePurchaseOrder(ctx context.Context, id string, receipt []domain.ReceiptLine) (*domain.PurchaseOrder, error)
+	CancelPurchaseOrder(ctx context.Context, id string) (*domain.PurchaseOrder, error)
+}
+
+type purchasingUsecase struct {
+	suppliers      repository.SupplierRepository
+	purchaseOrders repository.PurchaseOrderRepository
+	stock          stockStore
+}
+
+func NewPurchasingUsecase(suppliers repository.SupplierRepository, purchaseOrders repository.PurchaseOrderRepository, products repository.ProductRepository, variants repository.VariantRepository, warehouses repository.WarehouseRepository, stockLevels repository.StockLevelRepository, movements repository.StockMovementRepository) PurchasingUsecase {
+	return &purchasingUsecase{
+		suppliers:      suppliers,
+		purchaseOrders: purchaseOrders,
+		stock: stockStore{
+			products:   products,
+			variants:   variants,
+			warehouses: warehouses,
+			levels:     stockLevels,
+			ledger:     movements,
+		},
+	}
+}
+
+func (uc *purchasingUsecase) CreateSupplier(ctx context.Context, supplier *domain.Supplier) (*domain.Supplier, error) {
+	supplier.Name = strings.TrimSpace(supplier.Name)
+	if supplier.Name == "" {
+		return nil, fmt.Errorf("%w: supplier name is required", domain.ErrInvalidSupplier)
+	}
+
+	if err := uc.suppliers.Create(ctx, supplier); err != nil {
+		return nil, err
+	}
+
+	return uc.suppliers.FindByID(ctx, supplier.ID)
+}
+
+func (uc *purchasingUsecase) GetSupplier(ctx context.Context, id string) (*domain.Supplier, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: supplier ID is required", domain.ErrInvalidSupplier)
+	}
+
+	return uc.suppliers.FindByID(ctx, id)
+}
+
+func (uc *purchasingUsecase) UpdateSupplier(ctx context.Context, id string, update domain.SupplierUpdate) (*domain.Supplier, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: supplier ID is required", domain.ErrInvalidSupplier)
+	}
+
+	existing, err := uc.suppliers.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: supplier name cannot be empty", domain.ErrInvalidSupplier)
+		}
+		existing.Name = name
+	}
+	if update.Email != nil {
+		existing.Email = strings.TrimSpace(*update.Email)
+	}
+	if update.Phone != nil {
+		existing.Phone = strings.TrimSpace(*update.Phone)
+	}
+
+	if err := uc.suppliers.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	return uc.suppliers.FindByID(ctx, id)
+}
+
+func (uc *purchasingUsecase) ListSuppliers(ctx context.Context) ([]*domain.Supplier, error) {
+	return uc.suppliers.List(ctx)
+}
+
+// CreatePurchaseOrder places an open purchase order with a supplier. Every
+// line names a distinct product or variant that stock can be kept for.
+func (uc *purchasingUsecase) CreatePurchaseOrder(ctx context.Context, po *domain.PurchaseOrder) (*domain.PurchaseOrder, error) {
+	if po.SupplierID == "" {
+		return nil, fmt.Errorf("%w: supplier ID is required", domain.ErrInvalidPurchaseOrder)
+	}
+	if len(po.Lines) == 0 {
+		return nil, fmt.Errorf("%w: purchase order must contain at least one line", domain.ErrInvalidPurchaseOrder)
+	}
+
+	if _, err := uc.suppliers.FindByID(ctx, po.SupplierID); err != nil {
+		return nil, err
+	}
+	if po.WarehouseID != "" {
+		if _, err := uc.stock.warehouses.FindByID(ctx, po.WarehouseID); err != nil {
+			return nil, err
+		}
+	}
+
+	type item struct{ productID, sku string }
+	seen := make(map[item]bool, len(po.Lines))
+	for i := range po.Lines {
+		line := &po.Lines[i]
+		if line.ProductID == "" {
+			return nil, fmt.Errorf("%w: line %d has no product ID", domain.ErrInvalidPurchaseOrder, i)
+		}
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: line %d must have a positive quantity", domain.ErrInvalidPurchaseOrder, i)
+		}
+		if line.UnitCost < 0 {
+			return nil, fmt.Errorf("%w: line %d cannot have a negative unit cost", domain.ErrInvalidPurchaseOrder, i)
+		}
+		key := item{line.ProductID, line.VariantSKU}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %s is on more than one line", domain.ErrInvalidPurchaseOrder, describeItem(domain.ReservationItem{ProductID: line.ProductID, VariantSKU: line.VariantSKU}))
+		}
+		seen[key] = true
+
+		// Also rejects variants of other products and products sold
+		// only in variants
+		if _, err := uc.stock.total(ctx, line.ProductID, line.VariantSKU); err != nil {
+			return nil, err
+		}
+		line.Received = 0
+	}
+
+	po.Status = domain.PurchaseOrderOpen
+	if err := uc.purchaseOrders.Create(ctx, po); err != nil {
+		return nil, err
+	}
+
+	return uc.purchaseOrders.FindByID(ctx, po.ID)
+}
+
+func (uc *purchasingUsecase) GetPurchaseOrder(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: purchase order ID is required", domain.ErrInvalidPurchaseOrder)
+	}
+
+	return uc.purchaseOrders.FindByID(ctx, id)
+}
+
+// ListPurchaseOrders returns one page of purchase orders, newest first,
+// and the cursor of the next page, which is nil on the last one.
+func (uc *purchasingUsecase) ListPurchaseOrders(ctx context.Context, filter domain.PurchaseOrderFilter, after *domain.PageCursor, limit int) ([]*domain.PurchaseOrder, *domain.PageCursor, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, nil, fmt.Errorf("%w: unknown status %q", domain.ErrInvalidPurchaseOrder, filter.Status)
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	// Fetch one extra order to learn whether another page follows
+	orders, err := uc.purchaseOrders.List(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *domain.PageCursor
+	if len(orders) > limit {
+		orders = orders[:limit]
+		next = domain.PurchaseOrderCursor(orders[limit-1])
+	}
+
+	return orders, next, nil
+}
+
+// ReceivePurchaseOrder books a delivery against a purchase order and adds
+// the delivered units to stock at the order's warehouse. The order is
+// saved first and only if no other receipt changed it in the meantime, so
+// a delivery cannot be counted twice.
+func (uc *purchasingUsecase) ReceivePurchaseOrder(ctx context.Context, id string, receipt []domain.ReceiptLine) (*domain.PurchaseOrder, error) {
+	po, err := uc.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := po.Receive(receipt); err != nil {
+		return nil, err
+	}
+
+	// Check everything stock changes depend on before the receipt is saved
+	if po.WarehouseID != "" {
+		if _, err := uc.stock.warehouses.FindByID(ctx, po.WarehouseID); err != nil {
+			return nil, err
+		}
+	}
+	for _, received := range receipt {
+		if _, err := uc.stock.total(ctx, received.ProductID, received.VariantSKU); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.purchaseOrders.Update(ctx, po); err != nil {
+		return nil, err
+	}
+
+	movements := make([]domain.StockMovement, 0, len(receipt))
+	for _, received := range receipt {
+		if err := uc.stock.adjustAt(ctx, received.ProductID, received.VariantSKU, po.WarehouseID, received.Quantity); err != nil {
+			err = fmt.Errorf("receipt saved but stock of %s not raised: %w",
+				describeItem(domain.ReservationItem{ProductID: received.ProductID, VariantSKU: received.VariantSKU}), err)
+			return nil, errors.Join(err, recordMovements(ctx, uc.stock.ledger, movements...))
+		}
+		movements = append(movements, domain.StockMovement{
+			ProductID:   received.ProductID,
+			VariantSKU:  received.VariantSKU,
+			WarehouseID: po.WarehouseID,
+			Delta:       received.Quantity,
+			Reason:      domain.MovementPurchase,
+			Note:        "purchase order " + po.ID,
+		})
+	}
+	if err := recordMovements(ctx, uc.stock.ledger, movements...); err != nil {
+		return nil, fmt.Errorf("receipt saved and stock raised: %w", err)
+	}
+
+	return uc.purchaseOrders.FindByID(ctx, id)
+}
+
+// CancelPurchaseOrder closes a purchase order that is not fully received.
+// Units already received stay in stock.
+func (uc *purchasingUsecase) CancelPurchaseOrder(ctx context.Context, id string) (*domain.PurchaseOrder, error) {
+	po, err := uc.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if po.Status == domain.PurchaseOrderCancelled {
+		return po, nil
+	}
+	if po.Status.IsClosed() {
+		return nil, fmt.Errorf("%w: purchase order is %s", domain.ErrPurchaseOrderClosed, po.Status)
+	}
+
+	po.Status = domain.PurchaseOrderCancelled
+	if err := uc.purchaseOrders.Update(ctx, po); err != nil {
+		return nil, err
+	}
+
+	return uc.purchaseOrders.FindByID(ctx, id)
+}
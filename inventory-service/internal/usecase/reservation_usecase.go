@@ -0,0 +1,623 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"inventory-service/internal/domain"
+	"inventory-service/internal/repository"
+)
+
+// expiryBatchSize caps how many reservations a single ExpireReservations
+// pass releases.
+const expiryBatchSize = 100
+
+// backorderBatchSize caps how many backordered reservations a single
+// FillBackorders pass considers.
+const backorderBatchSize = 100
+
+type ReservationUsecase interface {
+	ReserveStock(ctx context.Context, orderID string, items []domain.ReservationItem, shipTo *domain.GeoPoint, ttl time.Duration, allowBackorder bool) (*domain.Reservation, error)
+	GetReservation(ctx context.Context, orderID string) (*domain.Reservation, error)
+	CommitReservation(ctx context.Context, orderID string) (*domain.Reservation, error)
+	ReleaseReservation(ctx context.Context, orderID string) (*domain.Reservation, error)
+	ReturnStock(ctx context.Context, orderID, returnID string, items []domain.ReturnedItem) (*domain.Reservation, error)
+	ExpireReservations(ctx context.Context) (int, error)
+	FillBackorders(ctx context.Context) (int, error)
+}
+
+type reservationUsecase struct {
+	stock        stockStore
+	reservations repository.ReservationRepository
+	strategy     domain.AllocationStrategy
+	defaultTTL   time.Duration
+}
+
+// NewReservationUsecase returns a ReservationUsecase that allocates stock
+// held at warehouses following strategy.
+func NewReservationUsecase(products repository.ProductRepository, variants repository.VariantRepository, warehouses repository.WarehouseRepository, stockLevels repository.StockLevelRepository, movements repository.StockMovementRepository, reservations repository.ReservationRepository, strategy domain.AllocationStrategy, defaultTTL time.Duration) ReservationUsecase {
+	return &reservationUsecase{
+		stock: stockStore{
+			products:   products,
+			variants:   variants,
+			warehouses: warehouses,
+			levels:     stockLevels,
+			ledger:     movements,
+		},
+		reservations: reservations,
+		strategy:     strategy,
+		defaultTTL:   defaultTTL,
+	}
+}
+
+// ReserveStock holds stock for an order. shipTo, when known, is where the
+// order ships to and steers the nearest allocation strategy. With
+// allowBackorder an order the stock cannot cover is backordered instead
+// of refused, provided every item short of stock accepts backorders.
+func (uc *reservationUsecase) ReserveStock(ctx context.Context, orderID string, items []domain.ReservationItem, shipTo *domain.GeoPoint, ttl time.Duration, allowBackorder bool) (*domain.Reservation, error) {
+	if orderID == "" {
+		return nil, errors.New("order ID is required")
+	}
+	if len(items) == 0 {
+		return nil, errors.New("reservation must contain at least one item")
+	}
+
+	// A retried reserve for the same order returns the existing hold
+	existing, err := uc.reservations.FindByOrderID(ctx, orderID)
+	if err == nil {
+		switch existing.Status {
+		case domain.ReservationStatusActive, domain.ReservationStatusCommitted, domain.ReservationStatusBackordered:
+			return existing, nil
+		}
+		return nil, domain.ErrReservationClosed
+	}
+	if !errors.Is(err, domain.ErrReservationNotFound) {
+		return nil, err
+	}
+
+	merged, err := mergeReservationItems(items)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireOrderable(ctx, merged); err != nil {
+		return nil, err
+	}
+
+	allocated, err := uc.takeAll(ctx, merged, shipTo)
+	if errors.Is(err, domain.ErrInsufficientStock) && allowBackorder {
+		return uc.backorder(ctx, orderID, merged, shipTo)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl <= 0 {
+		ttl = uc.defaultTTL
+	}
+
+	reservation := &domain.Reservation{
+		OrderID:   orderID,
+		Items:     allocated,
+		Status:    domain.ReservationStatusActive,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	if err := uc.reservations.Create(ctx, reservation); err != nil {
+		uc.restock(ctx, allocated)
+		if errors.Is(err, domain.ErrReservationExists) {
+			// Lost a race with a concurrent reserve for the same order
+			return uc.reservations.FindByOrderID(ctx, orderID)
+		}
+		return nil, err
+	}
+	if err := recordMovements(ctx, uc.stock.ledger, itemMovements(allocated, domain.MovementSale, -1, orderID)...); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// requireOrderable refuses items of archived products, which have left
+// the catalog.
+func (uc *reservationUsecase) requireOrderable(ctx context.Context, items []domain.ReservationItem) error {
+	checked := map[string]bool{}
+	for _, item := range items {
+		if checked[item.ProductID] {
+			continue
+		}
+		checked[item.ProductID] = true
+
+		product, err := uc.stock.products.FindByID(ctx, item.ProductID)
+		if err != nil {
+			return err
+		}
+		if product.IsArchived() {
+			return fmt.Errorf("%w: product %s can no longer be ordered", domain.ErrProductArchived, item.ProductID)
+		}
+	}
+	return nil
+}
+
+func (uc *reservationUsecase) GetReservation(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	if orderID == "" {
+		return nil, errors.New("order ID is required")
+	}
+
+	return uc.reservations.FindByOrderID(ctx, orderID)
+}
+
+// backorder records a reservation that waits for stock. Every item the
+// stock cannot cover must belong to a product that accepts backorders.
+func (uc *reservationUsecase) backorder(ctx context.Context, orderID string, items []domain.ReservationItem, shipTo *domain.GeoPoint) (*domain.Reservation, error) {
+	for _, item := range items {
+		total, err := uc.stock.total(ctx, item.ProductID, item.VariantSKU)
+		if err != nil {
+			return nil, err
+		}
+		if total >= item.Quantity {
+			continue
+		}
+		product, err := uc.stock.products.FindByID(ctx, item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if !product.AcceptsBackorders() {
+			return nil, fmt.Errorf("%w: %s cannot be backordered", domain.ErrInsufficientStock, describeItem(item))
+		}
+	}
+
+	reservation := &domain.Reservation{
+		OrderID: orderID,
+		Items:   items,
+		Status:  domain.ReservationStatusBackordered,
+		ShipTo:  shipTo,
+	}
+	if err := uc.reservations.Create(ctx, reservation); err != nil {
+		if errors.Is(err, domain.ErrReservationExists) {
+			return uc.reservations.FindByOrderID(ctx, orderID)
+		}
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// FillBackorders hands stock to backordered reservations, oldest first,
+// and reports how many became active. A reservation that cannot be filled
+// keeps its place: later reservations wanting any of its items wait
+// behind it.
+func (uc *reservationUsecase) FillBackorders(ctx context.Context) (int, error) {
+	backordered, err := uc.reservations.ListBackordered(ctx, backorderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	type key struct{ productID, sku string }
+	blocked := map[key]bool{}
+	block := func(reservation *domain.Reservation) {
+		for _, item := range reservation.Items {
+			blocked[key{item.ProductID, item.VariantSKU}] = true
+		}
+	}
+
+	filled := 0
+	for _, reservation := range backordered {
+		waiting := false
+		for _, item := range reservation.Items {
+			waiting = waiting || blocked[key{item.ProductID, item.VariantSKU}]
+		}
+		if waiting {
+			block(reservation)
+			continue
+		}
+
+		ok, err := uc.fill(ctx, reservation)
+		switch {
+		case errors.Is(err, domain.ErrInsufficientStock):
+			block(reservation)
+		case errors.Is(err, domain.ErrProductNotFound), errors.Is(err, domain.ErrVariantNotFound):
+			// Can never be filled; it waits until the order releases it
+			log.Printf("backordered reservation %s cannot be filled: %v", reservation.OrderID, err)
+		case err != nil:
+			return filled, err
+		case ok:
+			filled++
+		}
+	}
+
+	return filled, nil
+}
+
+// fill takes the stock of a backordered reservation and activates it. It
+// reports false without an error when the reservation was released in
+// the meantime.
+func (uc *reservationUsecase) fill(ctx context.Context, reservation *domain.Reservation) (bool, error) {
+	allocated, err := uc.takeAll(ctx, reservation.Items, reservation.ShipTo)
+	if err != nil {
+		return false, err
+	}
+
+	if err := uc.reservations.Fulfil(ctx, reservation.OrderID, allocated, time.Now().Add(uc.defaultTTL)); err != nil {
+		uc.restock(ctx, allocated)
+		if errors.Is(err, domain.ErrReservationClosed) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := recordMovements(ctx, uc.stock.ledger, itemMovements(allocated, domain.MovementSale, -1, reservation.OrderID)...); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (uc *reservationUsecase) CommitReservation(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	reservation, err := uc.reservations.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reservation.Status {
+	case domain.ReservationStatusCommitted:
+		return reservation, nil
+	case domain.ReservationStatusActive:
+		if time.Now().After(reservation.ExpiresAt) {
+			if err := uc.expire(ctx, reservation); err != nil {
+				return nil, err
+			}
+			return nil, domain.ErrReservationClosed
+		}
+	default:
+		return nil, domain.ErrReservationClosed
+	}
+
+	if err := uc.reservations.UpdateStatus(ctx, orderID, domain.ReservationStatusActive, domain.ReservationStatusCommitted); err != nil {
+		return nil, err
+	}
+
+	return uc.reservations.FindByOrderID(ctx, orderID)
+}
+
+func (uc *reservationUsecase) ReleaseReservation(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	reservation, err := uc.reservations.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Releasing twice is a no-op so callers can safely retry
+	switch reservation.Status {
+	case domain.ReservationStatusActive, domain.ReservationStatusCommitted:
+	case domain.ReservationStatusBackordered:
+		// Nothing has been taken from stock yet
+		err := uc.reservations.UpdateStatus(ctx, orderID, reservation.Status, domain.ReservationStatusReleased)
+		if errors.Is(err, domain.ErrReservationClosed) {
+			// Filled in the meantime; release the stock it now holds
+			return uc.ReleaseReservation(ctx, orderID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return uc.reservations.FindByOrderID(ctx, orderID)
+	default:
+		return reservation, nil
+	}
+
+	if err := uc.reservations.UpdateStatus(ctx, orderID, reservation.Status, domain.ReservationStatusReleased); err != nil {
+		if errors.Is(err, domain.ErrReservationClosed) {
+			return uc.reservations.FindByOrderID(ctx, orderID)
+		}
+		return nil, err
+	}
+	returned := uc.restock(ctx, reservation.Items)
+	if err := recordMovements(ctx, uc.stock.ledger, itemMovements(returned, domain.MovementRelease, 1, orderID)...); err != nil {
+		return nil, err
+	}
+
+	return uc.reservations.FindByOrderID(ctx, orderID)
+}
+
+// ReturnStock takes back units of a committed reservation that the
+// customer sent back. Resellable units go back to the warehouses they were
+// sold from; the others are written off, which the ledger records as a
+// return followed by a damage. Each return is taken back once however
+// often it is sent, so callers can safely retry, and all returns together
+// never take back more than was sold.
+func (uc *reservationUsecase) ReturnStock(ctx context.Context, orderID, returnID string, items []domain.ReturnedItem) (*domain.Reservation, error) {
+	if returnID == "" {
+		return nil, fmt.Errorf("%w: return ID is required", domain.ErrInvalidReturn)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: return must contain at least one item", domain.ErrInvalidReturn)
+	}
+
+	reservation, err := uc.reservations.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only what was sold and not sent back yet can come back; a retried
+	// return is answered with the reservation as it stands
+	if reservation.HasReturn(returnID) {
+		return reservation, nil
+	}
+	if err := reservation.CheckReturn(items); err != nil {
+		return nil, err
+	}
+
+	err = uc.reservations.AddReturn(ctx, orderID, domain.ReservationReturn{ID: returnID, Items: items})
+	if err != nil {
+		if errors.Is(err, domain.ErrReturnExists) {
+			return uc.reservations.FindByOrderID(ctx, orderID)
+		}
+		return nil, err
+	}
+
+	var resellable, writtenOff []domain.ReservationItem
+	for _, item := range items {
+		placed := soldFrom(reservation.Items, item)
+		if item.Resellable {
+			resellable = append(resellable, placed...)
+		} else {
+			writtenOff = append(writtenOff, placed...)
+		}
+	}
+
+	returned := uc.restock(ctx, resellable)
+	movements := itemMovements(returned, domain.MovementReturn, 1, orderID)
+	movements = append(movements, itemMovements(writtenOff, domain.MovementReturn, 1, orderID)...)
+	movements = append(movements, itemMovements(writtenOff, domain.MovementDamage, -1, orderID)...)
+	for i := range movements {
+		movements[i].Note = "return " + returnID
+	}
+	if err := recordMovements(ctx, uc.stock.ledger, movements...); err != nil {
+		return nil, err
+	}
+
+	return uc.reservations.FindByOrderID(ctx, orderID)
+}
+
+// ExpireReservations returns the stock of every active reservation whose
+// deadline has passed and reports how many were expired.
+func (uc *reservationUsecase) ExpireReservations(ctx context.Context) (int, error) {
+	expired, err := uc.reservations.ListExpired(ctx, time.Now(), expiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, reservation := range expired {
+		if err := uc.expire(ctx, reservation); err != nil {
+			if errors.Is(err, domain.ErrReservationClosed) {
+				continue
+			}
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+func (uc *reservationUsecase) expire(ctx context.Context, reservation *domain.Reservation) error {
+	err := uc.reservations.UpdateStatus(ctx, reservation.OrderID, domain.ReservationStatusActive, domain.ReservationStatusExpired)
+	if err != nil {
+		return err
+	}
+	returned := uc.restock(ctx, reservation.Items)
+	return recordMovements(ctx, uc.stock.ledger, itemMovements(returned, domain.MovementRelease, 1, reservation.OrderID)...)
+}
+
+// takeAll takes every item from stock, giving back what was taken when
+// one of them fails.
+func (uc *reservationUsecase) takeAll(ctx context.Context, items []domain.ReservationItem, shipTo *domain.GeoPoint) ([]domain.ReservationItem, error) {
+	var allocated []domain.ReservationItem
+	for _, item := range items {
+		parts, err := uc.take(ctx, item, shipTo)
+		if err != nil {
+			uc.restock(ctx, allocated)
+			return nil, err
+		}
+		allocated = append(allocated, parts...)
+	}
+	return allocated, nil
+}
+
+// take removes an item from stock and allocates it to warehouses. The
+// returned items record where each part of it was taken from.
+func (uc *reservationUsecase) take(ctx context.Context, item domain.ReservationItem, shipTo *domain.GeoPoint) ([]domain.ReservationItem, error) {
+	if _, err := uc.stock.total(ctx, item.ProductID, item.VariantSKU); err != nil {
+		return nil, err
+	}
+	if _, err := uc.stock.adjustTotal(ctx, item.ProductID, item.VariantSKU, -item.Quantity); err != nil {
+		return nil, err
+	}
+
+	parts, err := uc.allocate(ctx, item, shipTo)
+	if err != nil {
+		// Whatever was not given back to a warehouse goes back unassigned
+		remaining := item
+		for _, part := range parts {
+			remaining.Quantity -= part.Quantity
+		}
+		uc.restock(ctx, append(parts, remaining))
+		return nil, err
+	}
+
+	return parts, nil
+}
+
+// allocate draws an item, already taken from the total, from warehouses
+// in the order of the allocation strategy and covers what they lack from
+// unassigned stock. On failure it returns the parts taken so far.
+func (uc *reservationUsecase) allocate(ctx context.Context, item domain.ReservationItem, shipTo *domain.GeoPoint) ([]domain.ReservationItem, error) {
+	levels, _, err := uc.stock.itemLevels(ctx, item.ProductID, item.VariantSKU)
+	if err != nil || len(levels) == 0 {
+		// Not stocked at any warehouse: all of it is unassigned
+		return []domain.ReservationItem{item}, err
+	}
+	warehouses, err := uc.stock.warehouseIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []domain.ReservationItem
+	remaining := item.Quantity
+	for _, level := range rankLevels(levels, warehouses, uc.strategy, shipTo) {
+		if remaining == 0 {
+			break
+		}
+
+		quantity := min(remaining, level.Quantity)
+		err := uc.stock.levels.Adjust(ctx, level.WarehouseID, item.ProductID, item.VariantSKU, -quantity)
+		if errors.Is(err, domain.ErrInsufficientStock) {
+			// Drained by a concurrent reservation; try the next one
+			continue
+		}
+		if err != nil {
+			return parts, err
+		}
+
+		part := item
+		part.WarehouseID = level.WarehouseID
+		part.Quantity = quantity
+		parts = append(parts, part)
+		remaining -= quantity
+	}
+
+	if remaining == 0 {
+		return parts, nil
+	}
+
+	// The rest must come from unassigned stock, which is what is left of
+	// the total once every warehouse's share is accounted for
+	total, err := uc.stock.total(ctx, item.ProductID, item.VariantSKU)
+	if err != nil {
+		return parts, err
+	}
+	_, assigned, err := uc.stock.itemLevels(ctx, item.ProductID, item.VariantSKU)
+	if err != nil {
+		return parts, err
+	}
+	if total < assigned {
+		return parts, domain.ErrInsufficientStock
+	}
+
+	part := item
+	part.Quantity = remaining
+	return append(parts, part), nil
+}
+
+// restock returns items to stock and to the warehouses they were taken
+// from. Units of a warehouse that has since been deleted stay unassigned.
+// It is used for compensation, so it keeps going even if the caller's
+// context has been cancelled. It returns where the units actually went.
+func (uc *reservationUsecase) restock(ctx context.Context, items []domain.ReservationItem) []domain.ReservationItem {
+	ctx = context.WithoutCancel(ctx)
+
+	var returned []domain.ReservationItem
+	for _, item := range items {
+		if item.Quantity == 0 {
+			continue
+		}
+		if _, err := uc.stock.adjustTotal(ctx, item.ProductID, item.VariantSKU, item.Quantity); err != nil {
+			log.Printf("failed to return %d units of %s to stock: %v", item.Quantity, describeItem(item), err)
+			continue
+		}
+		if item.WarehouseID == "" {
+			returned = append(returned, item)
+			continue
+		}
+
+		warehouseID := item.WarehouseID
+		item.WarehouseID = ""
+		if _, err := uc.stock.warehouses.FindByID(ctx, warehouseID); err != nil {
+			if !errors.Is(err, domain.ErrWarehouseNotFound) {
+				log.Printf("failed to return %d units of %s to warehouse %s: %v", item.Quantity, describeItem(item), warehouseID, err)
+			}
+			returned = append(returned, item)
+			continue
+		}
+		if err := uc.stock.levels.Adjust(ctx, warehouseID, item.ProductID, item.VariantSKU, item.Quantity); err != nil {
+			log.Printf("failed to return %d units of %s to warehouse %s: %v", item.Quantity, describeItem(item), warehouseID, err)
+			returned = append(returned, item)
+			continue
+		}
+		item.WarehouseID = warehouseID
+		returned = append(returned, item)
+	}
+
+	return returned
+}
+
+// itemMovements turns reservation items into ledger entries of reason,
+// with sign giving the direction of the change.
+func itemMovements(items []domain.ReservationItem, reason domain.MovementReason, sign int, orderID string) []domain.StockMovement {
+	movements := make([]domain.StockMovement, len(items))
+	for i, item := range items {
+		movements[i] = domain.StockMovement{
+			ProductID:   item.ProductID,
+			VariantSKU:  item.VariantSKU,
+			WarehouseID: item.WarehouseID,
+			Delta:       sign * item.Quantity,
+			Reason:      reason,
+			OrderID:     orderID,
+		}
+	}
+	return movements
+}
+
+// soldFrom splits a returned quantity across the warehouses the item was
+// sold from, in the order the reservation lists them.
+func soldFrom(sold []domain.ReservationItem, item domain.ReturnedItem) []domain.ReservationItem {
+	var placed []domain.ReservationItem
+	remaining := item.Quantity
+	for _, line := range sold {
+		if remaining == 0 {
+			break
+		}
+		if line.ProductID != item.ProductID || line.VariantSKU != item.VariantSKU {
+			continue
+		}
+		line.Quantity = min(line.Quantity, remaining)
+		remaining -= line.Quantity
+		placed = append(placed, line)
+	}
+	return placed
+}
+
+func describeItem(item domain.ReservationItem) string {
+	if item.VariantSKU != "" {
+		return "variant " + item.VariantSKU
+	}
+	return "product " + item.ProductID
+}
+
+// mergeReservationItems validates items and folds repeated products or
+// variants into a single line so each is decremented once.
+func mergeReservationItems(items []domain.ReservationItem) ([]domain.ReservationItem, error) {
+	type key struct{ productID, sku string }
+	index := make(map[key]int, len(items))
+	merged := make([]domain.ReservationItem, 0, len(items))
+
+	for _, item := range items {
+		if item.ProductID == "" {
+			return nil, errors.New("product ID is required")
+		}
+		if item.Quantity <= 0 {
+			return nil, errors.New("reservation quantity must be positive")
+		}
+
+		k := key{item.ProductID, item.VariantSKU}
+		if i, ok := index[k]; ok {
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		index[k] = len(merged)
+		merged = append(merged, item)
+	}
+
+	return merged, nil
+}
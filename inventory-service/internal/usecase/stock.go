@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"inventory-service/internal/auth"
+	"inventory-service/internal/domain"
+	"inventory-service/internal/repository"
+)
+
+// stockStore keeps stock totals and per-warehouse levels in step. The
+// Stock of a product, or of a variant for products sold in variants, is
+// the total across all warehouses; the part of it no warehouse holds is
+// unassigned stock. Every change to either is recorded in the ledger.
+type stockStore struct {
+	products   repository.ProductRepository
+	variants   repository.VariantRepository
+	warehouses repository.WarehouseRepository
+	levels     repository.StockLevelRepository
+	ledger     repository.StockMovementRepository
+}
+
+// systemActor is the ledger actor of changes no user asked for, such as
+// reservations expiring.
+const systemActor = "system"
+
+// recordMovements appends movements to the ledger on behalf of the caller,
+// stopping at the first that cannot be stored. The stock has changed by
+// then: callers undo changes that a retry would apply again and return
+// the error either way, so a ledger that fell behind is never reported as
+// success. ReconcileStock reports any gap left.
+func recordMovements(ctx context.Context, ledger repository.StockMovementRepository, movements ...domain.StockMovement) error {
+	ctx = context.WithoutCancel(ctx)
+
+	actor := systemActor
+	if identity, ok := auth.FromContext(ctx); ok && identity.UserID != "" {
+		actor = identity.UserID
+	}
+
+	for _, movement := range movements {
+		if movement.Delta == 0 {
+			continue
+		}
+		movement.Actor = actor
+		if err := ledger.Append(ctx, &movement); err != nil {
+			return fmt.Errorf("recording %s of %d units of product %s: %w", movement.Reason, movement.Delta, movement.ProductID, err)
+		}
+	}
+	return nil
+}
+
+// total returns the stock an item draws from: the variant's when a SKU is
+// given, the product's otherwise. Products that have variants are only
+// stocked by SKU.
+func (s stockStore) total(ctx context.Context, productID, sku string) (int, error) {
+	if sku != "" {
+		variant, err := s.variants.FindBySKU(ctx, sku)
+		if err != nil {
+			return 0, err
+		}
+		if variant.ProductID != productID {
+			return 0, fmt.Errorf("%w: variant %s does not belong to product %s", domain.ErrInvalidVariant, sku, productID)
+		}
+		return variant.Stock, nil
+	}
+
+	product, err := s.products.FindByID(ctx, productID)
+	if err != nil {
+		return 0, err
+	}
+	variants, err := s.variants.ListByProduct(ctx, productID)
+	if err != nil {
+		return 0, err
+	}
+	if len(variants) > 0 {
+		return 0, fmt.Errorf("%w: product %s is stocked per variant, a SKU is required", domain.ErrInvalidVariant, productID)
+	}
+
+	return product.Stock, nil
+}
+
+// adjustTotal atomically adds delta to the total stock of an item and
+// returns the new total, failing with ErrInsufficientStock rather than
+// going below zero.
+func (s stockStore) adjustTotal(ctx context.Context, productID, sku string, delta int) (int, error) {
+	if sku != "" {
+		return s.variants.AdjustStock(ctx, sku, delta)
+	}
+	return s.products.AdjustStock(ctx, productID, delta)
+}
+
+// adjustAt moves the stock of an item at a warehouse, or its unassigned
+// stock, by delta together with its total, without touching the ledger.
+func (s stockStore) adjustAt(ctx context.Context, productID, sku, warehouseID string, delta int) error {
+	if warehouseID == "" {
+		_, err := s.adjustTotal(ctx, productID, sku, delta)
+		return err
+	}
+
+	if err := s.levels.Adjust(ctx, warehouseID, productID, sku, delta); err != nil {
+		return err
+	}
+	if _, err := s.adjustTotal(ctx, productID, sku, delta); err != nil {
+		if undoErr := s.levels.Adjust(ctx, warehouseID, productID, sku, -delta); undoErr != nil {
+			return fmt.Errorf("%w (restoring warehouse %s failed: %v)", err, warehouseID, undoErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// transferBack undoes a transfer between warehouse levels; the total
+// stock of the item was not changed by it.
+func (s stockStore) transferBack(ctx context.Context, transfer domain.StockTransfer) error {
+	if transfer.ToWarehouseID != "" {
+		if err := s.levels.Adjust(ctx, transfer.ToWarehouseID, transfer.ProductID, transfer.VariantSKU, -transfer.Quantity); err != nil {
+			return err
+		}
+	}
+	if transfer.FromWarehouseID != "" {
+		return s.levels.Adjust(ctx, transfer.FromWarehouseID, transfer.ProductID, transfer.VariantSKU, transfer.Quantity)
+	}
+	return nil
+}
+
+// itemLevels returns the warehouse levels of one item and the quantity
+// they hold together.
+func (s stockStore) itemLevels(ctx context.Context, productID, sku string) ([]*domain.StockLevel, int, error) {
+	levels, err := s.levels.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		matched  []*domain.StockLevel
+		assigned int
+	)
+	for _, level := range levels {
+		if level.VariantSKU == sku {
+			matched = append(matched, level)
+			assigned += level.Quantity
+		}
+	}
+
+	return matched, assigned, nil
+}
+
+// unassigned returns how much of an item's stock no warehouse holds.
+func (s stockStore) unassigned(ctx context.Context, productID, sku string) (int, error) {
+	total, err := s.total(ctx, productID, sku)
+	if err != nil {
+		return 0, err
+	}
+	_, assigned, err := s.itemLevels(ctx, productID, sku)
+	if err != nil {
+		return 0, err
+	}
+
+	return total - assigned, nil
+}
+
+// productStock breaks the stock of a product and its variants down by
+// warehouse.
+func (s stockStore) productStock(ctx context.Context, productID string) (*domain.ProductStock, error) {
+	product, err := s.products.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	variants, err := s.variants.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	stock := &domain.ProductStock{ProductID: productID, Total: product.Stock}
+	if len(variants) > 0 {
+		stock.Total = 0
+		for _, variant := range variants {
+			stock.Total += variant.Stock
+		}
+	}
+
+	stock.Levels, err = s.levels.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	warehouses, err := s.warehouseIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stock.Unassigned, stock.Available = stock.Total, stock.Total
+	for _, level := range stock.Levels {
+		stock.Unassigned -= level.Quantity
+		if warehouse, ok := warehouses[level.WarehouseID]; !ok || !warehouse.Active {
+			stock.Available -= level.Quantity
+		}
+	}
+
+	return stock, nil
+}
+
+func (s stockStore) warehouseIndex(ctx context.Context) (map[string]*domain.Warehouse, error) {
+	warehouses, err := s.warehouses.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]*domain.Warehouse, len(warehouses))
+	for _, warehouse := range warehouses {
+		index[warehouse.ID] = warehouse
+	}
+	return index, nil
+}
+
+// rankLevels orders the levels an item can be allocated from, best first,
+// according to strategy. Levels at inactive or unknown warehouses and
+// empty levels are dropped.
+func rankLevels(levels []*domain.StockLevel, warehouses map[string]*domain.Warehouse, strategy domain.AllocationStrategy, shipTo *domain.GeoPoint) []*domain.StockLevel {
+	candidates := make([]*domain.StockLevel, 0, len(levels))
+	for _, level := range levels {
+		if warehouse, ok := warehouses[level.WarehouseID]; ok && warehouse.Active && level.Quantity > 0 {
+			candidates = append(candidates, level)
+		}
+	}
+
+	if strategy == domain.AllocationNearest && shipTo == nil {
+		strategy = domain.AllocationPriority
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := warehouses[candidates[i].WarehouseID], warehouses[candidates[j].WarehouseID]
+		switch strategy {
+		case domain.AllocationNearest:
+			if da, db := a.Location.DistanceKm(*shipTo), b.Location.DistanceKm(*shipTo); da != db {
+				return da < db
+			}
+		case domain.AllocationMostStock:
+			if candidates[i].Quantity != candidates[j].Quantity {
+				return candidates[i].Quantity > candidates[j].Quantity
+			}
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+
+	return candidates
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"inventory-service/internal/domain"
+	"inventory-service/internal/repository"
+)
+
+type VariantUsecase interface {
+	CreateVariant(ctx context.Context, variant *domain.Variant) (*domain.Variant, error)
+	GetVariant(ctx context.Context, sku string) (*domain.Variant, error)
+	UpdateVariant(ctx context.Context, sku string, update domain.VariantUpdate) (*domain.Variant, error)
+	DeleteVariant(ctx context.Context, sku string) error
+	ListVariants(ctx context.Context, productID string) ([]*domain.Variant, error)
+}
+
+type variantUsecase struct {
+	variants    repository.VariantRepository
+	products    repository.ProductRepository
+	stockLevels repository.StockLevelRepository
+	movements   repository.StockMovementRepository
+}
+
+func NewVariantUsecase(variants repository.VariantRepository, products repository.ProductRepository, stockLevels repository.StockLevelRepository, movements repository.StockMovementRepository) VariantUsecase {
+	return &variantUsecase{
+		variants:    variants,
+		products:    products,
+		stockLevels: stockLevels,
+		movements:   movements,
+	}
+}
+
+func (uc *variantUsecase) CreateVariant(ctx context.Context, variant *domain.Variant) (*domain.Variant, error) {
+	variant.SKU = strings.TrimSpace(variant.SKU)
+	if variant.SKU == "" {
+		return nil, fmt.Errorf("%w: SKU is required", domain.ErrInvalidVariant)
+	}
+	if variant.ProductID == "" {
+		return nil, fmt.Errorf("%w: product ID is required", domain.ErrInvalidVariant)
+	}
+	if variant.Price < 0 {
+		return nil, fmt.Errorf("%w: price cannot be negative", domain.ErrInvalidVariant)
+	}
+	if variant.Stock < 0 {
+		return nil, fmt.Errorf("%w: stock cannot be negative", domain.ErrInvalidVariant)
+	}
+
+	if _, err := uc.products.FindByID(ctx, variant.ProductID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.variants.Create(ctx, variant); err != nil {
+		return nil, err
+	}
+	if err := recordMovements(ctx, uc.movements, domain.StockMovement{
+		ProductID:  variant.ProductID,
+		VariantSKU: variant.SKU,
+		Delta:      variant.Stock,
+		Reason:     domain.MovementRestock,
+		Note:       "initial stock",
+	}); err != nil {
+		return nil, fmt.Errorf("variant %s created: %w", variant.SKU, err)
+	}
+
+	return uc.variants.FindBySKU(ctx, variant.SKU)
+}
+
+func (uc *variantUsecase) GetVariant(ctx context.Context, sku string) (*domain.Variant, error) {
+	if sku == "" {
+		return nil, fmt.Errorf("%w: SKU is required", domain.ErrInvalidVariant)
+	}
+
+	return uc.variants.FindBySKU(ctx, sku)
+}
+
+func (uc *variantUsecase) UpdateVariant(ctx context.Context, sku string, update domain.VariantUpdate) (*domain.Variant, error) {
+	if sku == "" {
+		return nil, fmt.Errorf("%w: SKU is required", domain.ErrInvalidVariant)
+	}
+
+	existing, err := uc.variants.FindBySKU(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Attributes != nil {
+		existing.Attributes = update.Attributes
+	}
+	if update.Price != nil {
+		if *update.Price < 0 {
+			return nil, fmt.Errorf("%w: price cannot be negative", domain.ErrInvalidVariant)
+		}
+		existing.Price = *update.Price
+	}
+
+	if err := uc.variants.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	return uc.variants.FindBySKU(ctx, sku)
+}
+
+func (uc *variantUsecase) DeleteVariant(ctx context.Context, sku string) error {
+	if sku == "" {
+		return fmt.Errorf("%w: SKU is required", domain.ErrInvalidVariant)
+	}
+
+	if err := uc.variants.Delete(ctx, sku); err != nil {
+		return err
+	}
+
+	return uc.stockLevels.DeleteByVariant(ctx, sku)
+}
+
+func (uc *variantUsecase) ListVariants(ctx context.Context, productID string) ([]*domain.Variant, error) {
+	if productID == "" {
+		return nil, fmt.Errorf("%w: product ID is required", domain.ErrInvalidVariant)
+	}
+
+	if _, err := uc.products.FindByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	return uc.variants.ListByProduct(ctx, productID)
+}
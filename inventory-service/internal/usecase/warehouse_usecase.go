@@ -0,0 +1,473 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"inventory-service/internal/domain"
+	"inventory-service/internal/repository"
+)
+
+type WarehouseUsecase interface {
+	CreateWarehouse(ctx context.Context, warehouse *domain.Warehouse) (*domain.Warehouse, error)
+	GetWarehouse(ctx context.Context, id string) (*domain.Warehouse, error)
+	UpdateWarehouse(ctx context.Context, id string, update domain.WarehouseUpdate) (*domain.Warehouse, error)
+	DeleteWarehouse(ctx context.Context, id string) error
+	ListWarehouses(ctx context.Context) ([]*domain.Warehouse, error)
+	ListWarehouseStock(ctx context.Context, warehouseID string) ([]*domain.StockLevel, error)
+	SetStockLevel(ctx context.Context, level *domain.StockLevel, reason domain.MovementReason, note string) (*domain.ProductStock, error)
+	TransferStock(ctx context.Context, transfer domain.StockTransfer) (*domain.ProductStock, error)
+	AdjustStock(ctx context.Context, adjustment domain.StockAdjustment) (int, error)
+	GetProductStock(ctx context.Context, productID string) (*domain.ProductStock, error)
+	ListStockMovements(ctx context.Context, filter domain.MovementFilter, after *domain.PageCursor, limit int) ([]*domain.StockMovement, *domain.PageCursor, error)
+	ReconcileStock(ctx context.Context, productID string, apply bool) ([]domain.StockDiscrepancy, error)
+}
+
+type warehouseUsecase struct {
+	stock stockStore
+}
+
+func NewWarehouseUsecase(warehouses repository.WarehouseRepository, stockLevels repository.StockLevelRepository, movements repository.StockMovementRepository, products repository.ProductRepository, variants repository.VariantRepository) WarehouseUsecase {
+	return &warehouseUsecase{
+		stock: stockStore{
+			products:   products,
+			variants:   variants,
+			warehouses: warehouses,
+			levels:     stockLevels,
+			ledger:     movements,
+		},
+	}
+}
+
+func (uc *warehouseUsecase) CreateWarehouse(ctx context.Context, warehouse *domain.Warehouse) (*domain.Warehouse, error) {
+	warehouse.Name = strings.TrimSpace(warehouse.Name)
+	if warehouse.Name == "" {
+		return nil, fmt.Errorf("%w: warehouse name is required", domain.ErrInvalidWarehouse)
+	}
+	if !warehouse.Location.IsValid() {
+		return nil, fmt.Errorf("%w: location is out of range", domain.ErrInvalidWarehouse)
+	}
+
+	if err := uc.stock.warehouses.Create(ctx, warehouse); err != nil {
+		return nil, err
+	}
+
+	return uc.stock.warehouses.FindByID(ctx, warehouse.ID)
+}
+
+func (uc *warehouseUsecase) GetWarehouse(ctx context.Context, id string) (*domain.Warehouse, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: warehouse ID is required", domain.ErrInvalidWarehouse)
+	}
+
+	return uc.stock.warehouses.FindByID(ctx, id)
+}
+
+func (uc *warehouseUsecase) UpdateWarehouse(ctx context.Context, id string, update domain.WarehouseUpdate) (*domain.Warehouse, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: warehouse ID is required", domain.ErrInvalidWarehouse)
+	}
+
+	existing, err := uc.stock.warehouses.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: warehouse name cannot be empty", domain.ErrInvalidWarehouse)
+		}
+		existing.Name = name
+	}
+	if update.Location != nil {
+		if !update.Location.IsValid() {
+			return nil, fmt.Errorf("%w: location is out of range", domain.ErrInvalidWarehouse)
+		}
+		existing.Location = *update.Location
+	}
+	if update.Priority != nil {
+		existing.Priority = *update.Priority
+	}
+	if update.Active != nil {
+		existing.Active = *update.Active
+	}
+
+	if err := uc.stock.warehouses.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	return uc.stock.warehouses.FindByID(ctx, id)
+}
+
+// DeleteWarehouse removes an empty warehouse. Stock has to be transferred
+// out first; a warehouse that should stop shipping can be deactivated
+// instead.
+func (uc *warehouseUsecase) DeleteWarehouse(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: warehouse ID is required", domain.ErrInvalidWarehouse)
+	}
+
+	levels, err := uc.stock.levels.ListByWarehouse(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, level := range levels {
+		if level.Quantity > 0 {
+			return domain.ErrWarehouseInUse
+		}
+	}
+
+	if err := uc.stock.warehouses.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	return uc.stock.levels.DeleteByWarehouse(ctx, id)
+}
+
+func (uc *warehouseUsecase) ListWarehouses(ctx context.Context) ([]*domain.Warehouse, error) {
+	return uc.stock.warehouses.List(ctx)
+}
+
+func (uc *warehouseUsecase) ListWarehouseStock(ctx context.Context, warehouseID string) ([]*domain.StockLevel, error) {
+	if warehouseID == "" {
+		return nil, fmt.Errorf("%w: warehouse ID is required", domain.ErrInvalidWarehouse)
+	}
+
+	if _, err := uc.stock.warehouses.FindByID(ctx, warehouseID); err != nil {
+		return nil, err
+	}
+
+	return uc.stock.levels.ListByWarehouse(ctx, warehouseID)
+}
+
+// SetStockLevel records a stock count at a warehouse. The item's total
+// stock moves by the difference to the previous count, so units found or
+// lost in the count are added to or removed from the total. reason, a
+// count unless given, is what the ledger records for the difference.
+func (uc *warehouseUsecase) SetStockLevel(ctx context.Context, level *domain.StockLevel, reason domain.MovementReason, note string) (*domain.ProductStock, error) {
+	if level.WarehouseID == "" || level.ProductID == "" {
+		return nil, fmt.Errorf("%w: warehouse and product IDs are required", domain.ErrInvalidStockChange)
+	}
+	if level.Quantity < 0 {
+		return nil, fmt.Errorf("%w: quantity cannot be negative", domain.ErrInvalidStockChange)
+	}
+	if reason == "" {
+		reason = domain.MovementCount
+	}
+	if !reason.IsAdjustment() {
+		return nil, fmt.Errorf("%w: %q is not an adjustment reason", domain.ErrInvalidStockChange, reason)
+	}
+
+	if _, err := uc.stock.warehouses.FindByID(ctx, level.WarehouseID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.stock.total(ctx, level.ProductID, level.VariantSKU); err != nil {
+		return nil, err
+	}
+
+	levels, _, err := uc.stock.itemLevels(ctx, level.ProductID, level.VariantSKU)
+	if err != nil {
+		return nil, err
+	}
+	delta := level.Quantity
+	for _, existing := range levels {
+		if existing.WarehouseID == level.WarehouseID {
+			delta -= existing.Quantity
+		}
+	}
+
+	if delta != 0 {
+		if err := uc.stock.levels.Adjust(ctx, level.WarehouseID, level.ProductID, level.VariantSKU, delta); err != nil {
+			return nil, err
+		}
+		if _, err := uc.stock.adjustTotal(ctx, level.ProductID, level.VariantSKU, delta); err != nil {
+			// Units counted away were reserved in the meantime
+			if undoErr := uc.stock.levels.Adjust(ctx, level.WarehouseID, level.ProductID, level.VariantSKU, -delta); undoErr != nil {
+				return nil, fmt.Errorf("%w (restoring the warehouse count failed: %v)", err, undoErr)
+			}
+			return nil, err
+		}
+		if err := recordMovements(ctx, uc.stock.ledger, domain.StockMovement{
+			ProductID:   level.ProductID,
+			VariantSKU:  level.VariantSKU,
+			WarehouseID: level.WarehouseID,
+			Delta:       delta,
+			Reason:      reason,
+			Note:        note,
+		}); err != nil {
+			if undoErr := uc.stock.adjustAt(ctx, level.ProductID, level.VariantSKU, level.WarehouseID, -delta); undoErr != nil {
+				return nil, fmt.Errorf("%w (restoring the warehouse count failed: %v)", err, undoErr)
+			}
+			return nil, err
+		}
+	}
+
+	return uc.stock.productStock(ctx, level.ProductID)
+}
+
+// TransferStock moves units between warehouses, or between a warehouse
+// and unassigned stock. The item's total stock does not change.
+func (uc *warehouseUsecase) TransferStock(ctx context.Context, transfer domain.StockTransfer) (*domain.ProductStock, error) {
+	if transfer.ProductID == "" {
+		return nil, fmt.Errorf("%w: product ID is required", domain.ErrInvalidStockChange)
+	}
+	if transfer.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity must be positive", domain.ErrInvalidStockChange)
+	}
+	if transfer.FromWarehouseID == transfer.ToWarehouseID {
+		return nil, fmt.Errorf("%w: source and destination must differ", domain.ErrInvalidStockChange)
+	}
+
+	for _, id := range []string{transfer.FromWarehouseID, transfer.ToWarehouseID} {
+		if id == "" {
+			continue
+		}
+		if _, err := uc.stock.warehouses.FindByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if transfer.FromWarehouseID == "" {
+		unassigned, err := uc.stock.unassigned(ctx, transfer.ProductID, transfer.VariantSKU)
+		if err != nil {
+			return nil, err
+		}
+		if unassigned < transfer.Quantity {
+			return nil, domain.ErrInsufficientStock
+		}
+	} else {
+		if _, err := uc.stock.total(ctx, transfer.ProductID, transfer.VariantSKU); err != nil {
+			return nil, err
+		}
+		if err := uc.stock.levels.Adjust(ctx, transfer.FromWarehouseID, transfer.ProductID, transfer.VariantSKU, -transfer.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	if transfer.ToWarehouseID != "" {
+		if err := uc.stock.levels.Adjust(ctx, transfer.ToWarehouseID, transfer.ProductID, transfer.VariantSKU, transfer.Quantity); err != nil {
+			if transfer.FromWarehouseID != "" {
+				if undoErr := uc.stock.levels.Adjust(ctx, transfer.FromWarehouseID, transfer.ProductID, transfer.VariantSKU, transfer.Quantity); undoErr != nil {
+					return nil, fmt.Errorf("%w (returning units to %s failed: %v)", err, transfer.FromWarehouseID, undoErr)
+				}
+			}
+			return nil, err
+		}
+	}
+
+	movement := domain.StockMovement{
+		ProductID:  transfer.ProductID,
+		VariantSKU: transfer.VariantSKU,
+		Reason:     domain.MovementTransfer,
+	}
+	from, to := movement, movement
+	from.WarehouseID, from.Delta = transfer.FromWarehouseID, -transfer.Quantity
+	to.WarehouseID, to.Delta = transfer.ToWarehouseID, transfer.Quantity
+	if err := recordMovements(ctx, uc.stock.ledger, from, to); err != nil {
+		if undoErr := uc.stock.transferBack(ctx, transfer); undoErr != nil {
+			return nil, fmt.Errorf("%w (moving units back failed: %v)", err, undoErr)
+		}
+		return nil, err
+	}
+
+	return uc.stock.productStock(ctx, transfer.ProductID)
+}
+
+// AdjustStock adds a signed delta to the unassigned stock of an item, and
+// with it to its total, and returns the new total. The change is applied
+// atomically, so concurrent adjustments and reservations never lose each
+// other's updates; units held at warehouses are changed through
+// SetStockLevel instead.
+func (uc *warehouseUsecase) AdjustStock(ctx context.Context, adjustment domain.StockAdjustment) (int, error) {
+	if adjustment.ProductID == "" {
+		return 0, fmt.Errorf("%w: product ID is required", domain.ErrInvalidStockChange)
+	}
+	if adjustment.Delta == 0 {
+		return 0, fmt.Errorf("%w: delta cannot be zero", domain.ErrInvalidStockChange)
+	}
+	if adjustment.Reason == "" {
+		adjustment.Reason = domain.MovementManual
+	}
+	if !adjustment.Reason.IsAdjustment() {
+		return 0, fmt.Errorf("%w: %q is not an adjustment reason", domain.ErrInvalidStockChange, adjustment.Reason)
+	}
+
+	if adjustment.Delta < 0 {
+		unassigned, err := uc.stock.unassigned(ctx, adjustment.ProductID, adjustment.VariantSKU)
+		if err != nil {
+			return 0, err
+		}
+		if unassigned < -adjustment.Delta {
+			return 0, domain.ErrInsufficientStock
+		}
+	} else if _, err := uc.stock.total(ctx, adjustment.ProductID, adjustment.VariantSKU); err != nil {
+		return 0, err
+	}
+
+	stock, err := uc.stock.adjustTotal(ctx, adjustment.ProductID, adjustment.VariantSKU, adjustment.Delta)
+	if err != nil {
+		return 0, err
+	}
+	if err := recordMovements(ctx, uc.stock.ledger, domain.StockMovement{
+		ProductID:  adjustment.ProductID,
+		VariantSKU: adjustment.VariantSKU,
+		Delta:      adjustment.Delta,
+		Reason:     adjustment.Reason,
+		Note:       adjustment.Note,
+	}); err != nil {
+		if _, undoErr := uc.stock.adjustTotal(ctx, adjustment.ProductID, adjustment.VariantSKU, -adjustment.Delta); undoErr != nil {
+			return 0, fmt.Errorf("%w (restoring the stock failed: %v)", err, undoErr)
+		}
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+func (uc *warehouseUsecase) GetProductStock(ctx context.Context, productID string) (*domain.ProductStock, error) {
+	if productID == "" {
+		return nil, errors.New("product ID is required")
+	}
+
+	return uc.stock.productStock(ctx, productID)
+}
+
+// ListStockMovements returns one page of the stock ledger, newest first,
+// and the cursor of the next page, which is nil on the last one.
+func (uc *warehouseUsecase) ListStockMovements(ctx context.Context, filter domain.MovementFilter, after *domain.PageCursor, limit int) ([]*domain.StockMovement, *domain.PageCursor, error) {
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	movements, err := uc.stock.ledger.List(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *domain.PageCursor
+	if len(movements) > limit {
+		movements = movements[:limit]
+		next = domain.MovementCursor(movements[limit-1])
+	}
+
+	return movements, next, nil
+}
+
+// ReconcileStock compares the stored stock of a product and its variants,
+// per warehouse and unassigned, with the sums of the ledger. With apply
+// set, items the ledger has never seen get opening entries for their
+// stored stock, and stored stock that drifted from the ledger is set back
+// to it. The discrepancies found are returned either way.
+func (uc *warehouseUsecase) ReconcileStock(ctx context.Context, productID string, apply bool) ([]domain.StockDiscrepancy, error) {
+	if productID == "" {
+		return nil, errors.New("product ID is required")
+	}
+
+	product, err := uc.stock.products.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	variants, err := uc.stock.variants.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	levels, err := uc.stock.levels.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := uc.stock.ledger.Balances(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stored and ledger quantities per item, keyed by warehouse with ""
+	// for unassigned stock
+	totals := map[string]int{"": product.Stock}
+	if len(variants) > 0 {
+		totals = make(map[string]int, len(variants))
+		for _, variant := range variants {
+			totals[variant.SKU] = variant.Stock
+		}
+	}
+	recorded := make(map[string]map[string]int, len(totals))
+	ledger := make(map[string]map[string]int, len(totals))
+	for sku, total := range totals {
+		recorded[sku] = map[string]int{"": total}
+		ledger[sku] = map[string]int{}
+	}
+	for _, level := range levels {
+		if _, ok := recorded[level.VariantSKU]; ok {
+			recorded[level.VariantSKU][level.WarehouseID] += level.Quantity
+			recorded[level.VariantSKU][""] -= level.Quantity
+		}
+	}
+	for _, balance := range balances {
+		if _, ok := ledger[balance.VariantSKU]; ok {
+			ledger[balance.VariantSKU][balance.WarehouseID] += balance.Quantity
+		}
+	}
+
+	skus := make([]string, 0, len(totals))
+	for sku := range totals {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	var discrepancies []domain.StockDiscrepancy
+	for _, sku := range skus {
+		opening := len(ledger[sku]) == 0
+		for _, warehouseID := range stockPlaces(recorded[sku], ledger[sku]) {
+			stored, summed := recorded[sku][warehouseID], ledger[sku][warehouseID]
+			if stored == summed {
+				continue
+			}
+			discrepancies = append(discrepancies, domain.StockDiscrepancy{
+				ProductID:   productID,
+				VariantSKU:  sku,
+				WarehouseID: warehouseID,
+				Recorded:    stored,
+				Ledger:      summed,
+			})
+			if !apply {
+				continue
+			}
+
+			if opening {
+				recordMovements(ctx, uc.stock.ledger, domain.StockMovement{
+					ProductID:   productID,
+					VariantSKU:  sku,
+					WarehouseID: warehouseID,
+					Delta:       stored,
+					Reason:      domain.MovementOpening,
+				})
+				continue
+			}
+			if err := uc.stock.adjustAt(ctx, productID, sku, warehouseID, summed-stored); err != nil {
+				return discrepancies, err
+			}
+		}
+	}
+
+	return discrepancies, nil
+}
+
+// stockPlaces returns the warehouses found in either quantity map, in
+// order, with unassigned stock first.
+func stockPlaces(recorded, ledger map[string]int) []string {
+	seen := make(map[string]bool, len(recorded)+len(ledger))
+	places := []string{}
+	for _, quantities := range []map[string]int{recorded, ledger} {
+		for warehouseID := range quantities {
+			if !seen[warehouseID] {
+				seen[warehouseID] = true
+				places = append(places, warehouseID)
+			}
+		}
+	}
+	sort.Strings(places)
+	return places
+}
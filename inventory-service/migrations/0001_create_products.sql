@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS products (
+    id          TEXT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    price       DOUBLE PRECISION NOT NULL CHECK (price >= 0),
+    stock       INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    category_id TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS products_category_created_idx
+    ON products (category_id, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS products_created_idx
+    ON products (created_at DESC, id DESC);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS reservations (
+    order_id   TEXT PRIMARY KEY,
+    items      JSONB NOT NULL,
+    status     TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS reservations_active_expiry_idx
+    ON reservations (expires_at)
+    WHERE status = 'active';
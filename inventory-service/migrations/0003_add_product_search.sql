@@ -0,0 +1,7 @@
+-- Backs SearchProducts. The expression must stay in sync with
+-- productSearchVector in the repository.
+CREATE INDEX IF NOT EXISTS products_search_idx
+    ON products USING GIN ((setweight(to_tsvector('simple', name), 'A') || setweight(to_tsvector('simple', description), 'B')));
+
+CREATE INDEX IF NOT EXISTS products_price_idx
+    ON products (price);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id          TEXT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    parent_id   TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Sibling names are unique regardless of case
+CREATE UNIQUE INDEX IF NOT EXISTS categories_parent_name_idx
+    ON categories (parent_id, lower(name));
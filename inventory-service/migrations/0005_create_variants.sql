@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS variants (
+    sku        TEXT PRIMARY KEY,
+    product_id TEXT NOT NULL,
+    attributes JSONB NOT NULL DEFAULT '{}',
+    price      DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (price >= 0),
+    stock      INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS variants_product_idx
+    ON variants (product_id, sku);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS warehouses (
+    id         TEXT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    latitude   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    longitude  DOUBLE PRECISION NOT NULL DEFAULT 0,
+    priority   INTEGER NOT NULL DEFAULT 0,
+    active     BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- variant_sku is empty for products sold without variants
+CREATE TABLE IF NOT EXISTS stock_levels (
+    warehouse_id TEXT NOT NULL,
+    product_id   TEXT NOT NULL,
+    variant_sku  TEXT NOT NULL DEFAULT '',
+    quantity     INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (warehouse_id, product_id, variant_sku)
+);
+
+CREATE INDEX IF NOT EXISTS stock_levels_product_idx
+    ON stock_levels (product_id, warehouse_id, variant_sku);
@@ -0,0 +1,23 @@
+-- Append-only: rows are never updated or deleted
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id           TEXT PRIMARY KEY,
+    product_id   TEXT NOT NULL,
+    variant_sku  TEXT NOT NULL DEFAULT '',
+    warehouse_id TEXT NOT NULL DEFAULT '',
+    delta        INTEGER NOT NULL,
+    reason       TEXT NOT NULL,
+    actor        TEXT NOT NULL DEFAULT '',
+    order_id     TEXT NOT NULL DEFAULT '',
+    note         TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS stock_movements_created_idx
+    ON stock_movements (created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS stock_movements_product_idx
+    ON stock_movements (product_id, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS stock_movements_order_idx
+    ON stock_movements (order_id)
+    WHERE order_id <> '';
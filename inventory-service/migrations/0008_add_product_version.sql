@@ -0,0 +1,2 @@
+-- Incremented by every product update for optimistic concurrency control
+ALTER TABLE products ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
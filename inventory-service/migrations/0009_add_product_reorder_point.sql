@@ -0,0 +1,6 @@
+-- A positive reorder_point turns on low-stock alerts for the product
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_point INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_quantity INTEGER NOT NULL DEFAULT 0;
+
+-- Backs the scans of the low-stock checker
+CREATE INDEX IF NOT EXISTS products_reorder_idx ON products (id) WHERE reorder_point > 0;
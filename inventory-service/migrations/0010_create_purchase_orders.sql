@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS suppliers (
+    id         TEXT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    email      TEXT NOT NULL DEFAULT '',
+    phone      TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS suppliers_name_idx ON suppliers (name, id);
+
+-- Lines are only ever read and written with their order, so they are kept
+-- inline like reservation items
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id           TEXT PRIMARY KEY,
+    supplier_id  TEXT NOT NULL,
+    warehouse_id TEXT NOT NULL DEFAULT '',
+    lines        JSONB NOT NULL,
+    status       TEXT NOT NULL,
+    note         TEXT NOT NULL DEFAULT '',
+    version      BIGINT NOT NULL DEFAULT 1,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Back the cursor range scans of ListPurchaseOrders
+CREATE INDEX IF NOT EXISTS purchase_orders_created_idx
+    ON purchase_orders (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS purchase_orders_supplier_idx
+    ON purchase_orders (supplier_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS purchase_orders_status_idx
+    ON purchase_orders (status, created_at DESC, id DESC);
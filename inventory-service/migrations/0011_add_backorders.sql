@@ -0,0 +1,11 @@
+-- Products that accept orders without stock, and when stock is expected
+ALTER TABLE products ADD COLUMN IF NOT EXISTS backorder_mode TEXT NOT NULL DEFAULT 'none';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS available_at TIMESTAMPTZ;
+
+-- Where a backordered reservation ships to, for allocating its stock later
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS ship_to JSONB;
+
+-- Backs the oldest-first scans of backordered reservations
+CREATE INDEX IF NOT EXISTS reservations_backordered_idx
+    ON reservations (created_at, order_id)
+    WHERE status = 'backordered';
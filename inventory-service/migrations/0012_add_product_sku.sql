@@ -0,0 +1,4 @@
+-- An optional catalog SKU, the key bulk imports match products on
+ALTER TABLE products ADD COLUMN IF NOT EXISTS sku TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS products_sku_key ON products (sku) WHERE sku IS NOT NULL;
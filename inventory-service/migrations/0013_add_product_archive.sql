@@ -0,0 +1,6 @@
+-- Deleted products are archived rather than removed, so orders can still
+-- show them; they are purged once archived past the retention window
+ALTER TABLE products ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+-- Backs the scans of the purge job
+CREATE INDEX IF NOT EXISTS products_archived_idx ON products (archived_at, id) WHERE archived_at IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Customer returns taken back against a committed reservation, together
+-- with the items each took back, so each return is restocked only once
+-- and returns never add up to more than was sold
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS returns JSONB NOT NULL DEFAULT '[]';
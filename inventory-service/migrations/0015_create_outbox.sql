@@ -0,0 +1,10 @@
+-- Domain events written in the same transaction as the change they
+-- describe; the relay publishes them in seq order and then deletes them
+CREATE TABLE IF NOT EXISTS outbox (
+    seq          BIGSERIAL PRIMARY KEY,
+    id           TEXT NOT NULL UNIQUE,
+    type         TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    payload      JSONB NOT NULL,
+    occurred_at  TIMESTAMPTZ NOT NULL
+);
@@ -2,38 +2,97 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net"
 	"os"
+	"time"
 
+	"github.com/abaika-abay/ecommerce/protos/inventory"
 	"github.com/yourusername/ecommerce/protos/order"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"order-service/internal/auth"
 	"order-service/internal/repository"
 	"order-service/internal/service"
 	"order-service/internal/usecase"
 )
 
 func main() {
-	// Initialize MongoDB connection
-	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGO_URI")))
+	// Initialize repositories
+	repos, closeStorage, err := openRepositories(os.Getenv("STORAGE_DRIVER"))
 	if err != nil {
-		log.Fatalf("failed to connect to MongoDB: %v", err)
+		log.Fatalf("failed to open storage: %v", err)
 	}
-	defer client.Disconnect(context.Background())
+	defer closeStorage()
 
-	db := client.Database("ecommerce")
+	// Identities on calls between the gateway and the services are signed
+	// with a shared secret
+	signer, err := auth.NewSigner(os.Getenv("SERVICE_AUTH_SECRET"))
+	if err != nil {
+		log.Fatalf("failed to set up caller authentication: %v", err)
+	}
+
+	// Initialize inventory service connection
+	inventoryAddr := os.Getenv("INVENTORY_SERVICE_ADDR")
+	if inventoryAddr == "" {
+		inventoryAddr = "inventory-service:50051"
+	}
+	inventoryConn, err := grpc.Dial(inventoryAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(auth.ServiceClientInterceptor("order-service", signer)),
+	)
+	if err != nil {
+		log.Fatalf("failed to connect to inventory service: %v", err)
+	}
+	defer inventoryConn.Close()
 
-	// Initialize repository
-	orderRepo := repository.NewOrderRepository(db)
+	paymentProvider, err := newPaymentProvider(os.Getenv("PAYMENT_PROVIDER"))
+	if err != nil {
+		log.Fatalf("failed to set up payments: %v", err)
+	}
+
+	returnWindow, err := durationFromEnv("RETURN_WINDOW", 30*24*time.Hour)
+	if err != nil {
+		log.Fatalf("invalid return window: %v", err)
+	}
 
-	// Initialize usecase
-	orderUsecase := usecase.NewOrderUsecase(orderRepo)
+	// Initialize usecases
+	inventoryClient := inventory.NewInventoryServiceClient(inventoryConn)
+	orderUsecase := usecase.NewOrderUsecase(repos.Orders, repos.Payments, repos.Sagas, inventoryClient, paymentProvider)
+	returnUsecase := usecase.NewReturnUsecase(repos.Returns, repos.Orders, repos.Payments, inventoryClient, paymentProvider, returnWindow)
+
+	// Move backordered orders on once inventory has allocated their stock
+	backorderInterval, err := durationFromEnv("BACKORDER_POLL_INTERVAL", 30*time.Second)
+	if err != nil {
+		log.Fatalf("invalid backorder poll interval: %v", err)
+	}
+	go runBackorderPromotion(context.Background(), orderUsecase, backorderInterval)
+
+	// Carry on with sagas that wait for a retry or were cut short by a
+	// restart
+	sagaInterval, err := durationFromEnv("SAGA_RECOVERY_INTERVAL", 10*time.Second)
+	if err != nil {
+		log.Fatalf("invalid saga recovery interval: %v", err)
+	}
+	go runSagaRecovery(context.Background(), orderUsecase, sagaInterval)
+
+	// Publish the events the order repository writes to the outbox
+	broker, err := newEventBroker(os.Getenv("EVENT_BROKER"))
+	if err != nil {
+		log.Fatalf("failed to set up events: %v", err)
+	}
+	outboxInterval, err := durationFromEnv("OUTBOX_RELAY_INTERVAL", time.Second)
+	if err != nil {
+		log.Fatalf("invalid outbox relay interval: %v", err)
+	}
+	go runOutboxRelay(context.Background(), usecase.NewOutboxRelay(repos.Outbox, broker), outboxInterval)
 
 	// Initialize gRPC server
-	grpcServer := grpc.NewServer()
-	orderServer := service.NewOrderServer(orderUsecase)
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryServerInterceptor(signer)))
+	orderServer := service.NewOrderServer(orderUsecase, returnUsecase)
 	order.RegisterOrderServiceServer(grpcServer, orderServer)
 
 	// Start server
@@ -47,3 +106,125 @@ func main() {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
+
+// runBackorderPromotion promotes backordered orders every interval until
+// ctx is done.
+func runBackorderPromotion(ctx context.Context, orders usecase.OrderUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			promoted, err := orders.PromoteBackorders(ctx)
+			if err != nil {
+				log.Printf("failed to promote backordered orders: %v", err)
+			}
+			if promoted > 0 {
+				log.Printf("promoted %d backordered orders", promoted)
+			}
+		}
+	}
+}
+
+// runSagaRecovery resumes due sagas every interval until ctx is done.
+func runSagaRecovery(ctx context.Context, orders usecase.OrderUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			finished, err := orders.ResumeSagas(ctx)
+			if err != nil {
+				log.Printf("failed to resume sagas: %v", err)
+			}
+			if finished > 0 {
+				log.Printf("finished %d resumed sagas", finished)
+			}
+		}
+	}
+}
+
+// runOutboxRelay publishes pending events every interval until ctx is
+// done.
+func runOutboxRelay(ctx context.Context, relay *usecase.OutboxRelay, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := relay.Relay(ctx); err != nil {
+				log.Printf("failed to relay outbox events: %v", err)
+			}
+		}
+	}
+}
+
+// durationFromEnv reads a duration such as "30s" from the environment,
+// falling back to def when the variable is unset.
+func durationFromEnv(key string, def time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return def, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// newPaymentProvider builds the payment provider for the configured name.
+// Only the fake provider exists so far, and it is the default.
+func newPaymentProvider(name string) (usecase.PaymentProvider, error) {
+	switch name {
+	case "", "fake":
+		return usecase.NewFakePaymentProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", name)
+	}
+}
+
+// newEventBroker builds the event broker for the configured name. Only the
+// in-process broker exists so far; it is the default and logs every event.
+func newEventBroker(name string) (usecase.EventBroker, error) {
+	switch name {
+	case "", "inprocess":
+		broker := usecase.NewInProcessBroker()
+		broker.Subscribe(usecase.LogEvent)
+		return broker, nil
+	default:
+		return nil, fmt.Errorf("unknown event broker %q", name)
+	}
+}
+
+// openRepositories builds the repositories for the configured driver.
+// MongoDB is the default; "memory" keeps everything in process memory.
+func openRepositories(driver string) (repository.Repositories, func(), error) {
+	switch driver {
+	case "", "mongo":
+		client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(os.Getenv("MONGO_URI")))
+		if err != nil {
+			return repository.Repositories{}, nil, fmt.Errorf("connect to MongoDB: %w", err)
+		}
+		closeFn := func() { client.Disconnect(context.Background()) }
+		db := client.Database("ecommerce")
+		if err := repository.CheckMongoTransactions(context.Background(), db); err != nil {
+			closeFn()
+			return repository.Repositories{}, nil, err
+		}
+		if err := repository.EnsureMongoIndexes(context.Background(), db); err != nil {
+			closeFn()
+			return repository.Repositories{}, nil, fmt.Errorf("create MongoDB indexes: %w", err)
+		}
+		return repository.NewMongoRepositories(db), closeFn, nil
+	case "memory":
+		return repository.NewMemoryRepositories(), func() {}, nil
+	default:
+		return repository.Repositories{}, nil, fmt.Errorf("unknown storage driver %q", driver)
+	}
+}
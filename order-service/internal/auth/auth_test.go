@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// serviceMetadata returns the metadata ServiceClientInterceptor puts on an
+// outgoing call signed by signer.
+func serviceMetadata(t *testing.T, signer *Signer) metadata.MD {
+	t.Helper()
+
+	var md metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	interceptor := ServiceClientInterceptor("order-service", signer)
+	if err := interceptor(context.Background(), "/test", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("client interceptor: %v", err)
+	}
+	return md
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	signer, err := NewSigner("test-secret")
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	other, err := NewSigner("guessed")
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want codes.Code
+	}{
+		{
+			name: "signed",
+			md:   serviceMetadata(t, signer),
+			want: codes.OK,
+		},
+		{
+			name: "no metadata",
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "missing signature",
+			md: func() metadata.MD {
+				md := serviceMetadata(t, signer)
+				md.Delete(MetadataSignature)
+				return md
+			}(),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "signed with another secret",
+			md:   serviceMetadata(t, other),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "roles changed after signing",
+			md: func() metadata.MD {
+				md := serviceMetadata(t, signer)
+				md.Set(MetadataRoles, RoleAdmin)
+				return md
+			}(),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "user changed after signing",
+			md: func() metadata.MD {
+				md := serviceMetadata(t, signer)
+				md.Set(MetadataUserID, "someone-else")
+				return md
+			}(),
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "expiry extended after signing",
+			md: func() metadata.MD {
+				md := serviceMetadata(t, signer)
+				md.Set(MetadataExpires, "99999999999")
+				return md
+			}(),
+			want: codes.Unauthenticated,
+		},
+	}
+
+	interceptor := UnaryServerInterceptor(signer)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var identity Identity
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				identity, _ = FromContext(ctx)
+				return nil, nil
+			}
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, handler)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("interceptor returned %v, want %v", err, tt.want)
+			}
+			if tt.want == codes.OK && (identity.UserID != "order-service" || !identity.HasRole(RoleService)) {
+				t.Fatalf("handler got identity %+v", identity)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "errors"
+
+var (
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrOrderExists        = errors.New("order already exists")
+	ErrInvalidOrder       = errors.New("invalid order")
+	ErrProductUnavailable = errors.New("product is not available")
+	ErrInvalidStatus      = errors.New("invalid order status")
+	ErrInvalidTransition  = errors.New("order status transition not allowed")
+	ErrStatusConflict     = errors.New("order status was changed concurrently")
+	ErrVersionConflict    = errors.New("version conflict: order was modified concurrently")
+	ErrForbidden          = errors.New("not allowed to access another user's orders")
+	ErrPaymentDeclined    = errors.New("payment declined")
+	ErrPaymentFailed      = errors.New("payment provider failed")
+	ErrReturnNotFound     = errors.New("return not found")
+	ErrInvalidReturn      = errors.New("invalid return")
+	ErrNotReturnable      = errors.New("order cannot be returned")
+	ErrReturnTransition   = errors.New("return status transition not allowed")
+	ErrReturnConflict     = errors.New("return was changed concurrently")
+	ErrStaffOnly          = errors.New("only warehouse staff may do this")
+	ErrSagaNotFound       = errors.New("saga not found")
+	ErrSagaConflict       = errors.New("saga was changed concurrently")
+	ErrSagaPending        = errors.New("saga is still in progress")
+)
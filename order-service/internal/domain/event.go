@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType names a kind of domain event.
+type EventType string
+
+const (
+	EventOrderCreated       EventType = "OrderCreated"
+	EventOrderStatusChanged EventType = "OrderStatusChanged"
+)
+
+// Event is a change other systems may want to hear about. Repositories
+// write it to the outbox in the same write as the change itself and the
+// outbox relay publishes it from there, so every committed change is
+// published at least once; consumers tell repeats apart by ID.
+// AggregateID is the order the event is about and Payload its data as
+// JSON: the order as it was stored, or an OrderStatusChange.
+type Event struct {
+	ID          string          `json:"id"`
+	Type        EventType       `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+// OrderStatusChange is the payload of EventOrderStatusChanged. Version is
+// the order's version after the change.
+type OrderStatusChange struct {
+	OrderID string       `json:"order_id"`
+	Version int64        `json:"version"`
+	Change  StatusChange `json:"change"`
+}
@@ -5,25 +5,92 @@ import "time"
 type OrderStatus string
 
 const (
-	OrderStatusPending   OrderStatus = "pending"
-	OrderStatusPaid      OrderStatus = "paid"
-	OrderStatusShipped   OrderStatus = "shipped"
-	OrderStatusDelivered OrderStatus = "delivered"
-	OrderStatusCancelled OrderStatus = "cancelled"
+	// OrderStatusBackordered orders wait for stock; they move to pending
+	// once inventory has allocated it
+	OrderStatusBackordered OrderStatus = "backordered"
+	OrderStatusPending     OrderStatus = "pending"
+	OrderStatusPaid        OrderStatus = "paid"
+	OrderStatusShipped     OrderStatus = "shipped"
+	OrderStatusDelivered   OrderStatus = "delivered"
+	OrderStatusCancelled   OrderStatus = "cancelled"
 )
 
+// orderTransitions lists the statuses an order may move to from each status.
+// Orders can only be cancelled before they ship; delivered and cancelled
+// orders are final.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusBackordered: {OrderStatusPending, OrderStatusCancelled},
+	OrderStatusPending:     {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:        {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped:     {OrderStatusDelivered},
+	OrderStatusDelivered:   {},
+	OrderStatusCancelled:   {},
+}
+
+// IsValid reports whether s is a known order status.
+func (s OrderStatus) IsValid() bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether an order in status s may move to next.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderItem is one line of an order. AvailableAt is when inventory
+// expected the product in stock when the order was placed, if known.
 type OrderItem struct {
-	ProductID string  `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
+	ProductID   string     `json:"product_id"`
+	VariantSKU  string     `json:"variant_sku,omitempty"`
+	ProductName string     `json:"product_name"`
+	Quantity    int        `json:"quantity"`
+	Price       float64    `json:"price"`
+	AvailableAt *time.Time `json:"available_at,omitempty"`
 }
 
+// StatusChange is one entry of an order's append-only status history.
+type StatusChange struct {
+	From      OrderStatus `json:"from"`
+	To        OrderStatus `json:"to"`
+	ChangedBy string      `json:"changed_by"`
+	Reason    string      `json:"reason"`
+	ChangedAt time.Time   `json:"changed_at"`
+}
+
+// Order is a customer's purchase. Version starts at 1 and grows with every
+// change to the order, for optimistic concurrency control.
 type Order struct {
-	ID        string      `json:"id"`
-	UserID    string      `json:"user_id"`
-	Items     []OrderItem `json:"items"`
-	Total     float64     `json:"total"`
-	Status    OrderStatus `json:"status"`
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt time.Time   `json:"updated_at"`
+	ID            string         `json:"id"`
+	UserID        string         `json:"user_id"`
+	Items         []OrderItem    `json:"items"`
+	Total         float64        `json:"total"`
+	Status        OrderStatus    `json:"status"`
+	StatusHistory []StatusChange `json:"status_history"`
+	ShipTo        *GeoPoint      `json:"ship_to,omitempty"`
+	Version       int64          `json:"version"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// DeliveredAt returns when the order was delivered, and false when it has
+// not been.
+func (o *Order) DeliveredAt() (time.Time, bool) {
+	for i := len(o.StatusHistory) - 1; i >= 0; i-- {
+		if o.StatusHistory[i].To == OrderStatusDelivered {
+			return o.StatusHistory[i].ChangedAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// GeoPoint is a position in decimal degrees.
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
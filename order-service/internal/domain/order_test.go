@@ -0,0 +1,57 @@
+package domain
+
+import "testing"
+
+func TestOrderStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to OrderStatus
+		want     bool
+	}{
+		{OrderStatusBackordered, OrderStatusPending, true},
+		{OrderStatusBackordered, OrderStatusCancelled, true},
+		{OrderStatusBackordered, OrderStatusPaid, false},
+		{OrderStatusBackordered, OrderStatusShipped, false},
+		{OrderStatusPending, OrderStatusPaid, true},
+		{OrderStatusPending, OrderStatusCancelled, true},
+		{OrderStatusPending, OrderStatusBackordered, false},
+		{OrderStatusPending, OrderStatusShipped, false},
+		{OrderStatusPaid, OrderStatusShipped, true},
+		{OrderStatusPaid, OrderStatusCancelled, true},
+		{OrderStatusPaid, OrderStatusPending, false},
+		{OrderStatusShipped, OrderStatusDelivered, true},
+		{OrderStatusShipped, OrderStatusCancelled, false},
+		{OrderStatusShipped, OrderStatusPaid, false},
+		{OrderStatusDelivered, OrderStatusCancelled, false},
+		{OrderStatusDelivered, OrderStatusShipped, false},
+		{OrderStatusCancelled, OrderStatusPending, false},
+		{OrderStatusCancelled, OrderStatusCancelled, false},
+		{OrderStatus("lost"), OrderStatusCancelled, false},
+		{OrderStatusPending, OrderStatus("lost"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Fatalf("CanTransitionTo = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderStatusIsValid(t *testing.T) {
+	for _, status := range []OrderStatus{
+		OrderStatusBackordered,
+		OrderStatusPending,
+		OrderStatusPaid,
+		OrderStatusShipped,
+		OrderStatusDelivered,
+		OrderStatusCancelled,
+	} {
+		if !status.IsValid() {
+			t.Errorf("%s is not valid", status)
+		}
+	}
+	if OrderStatus("lost").IsValid() {
+		t.Errorf("unknown status is valid")
+	}
+}
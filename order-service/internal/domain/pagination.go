@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// PageCursor marks where a listing resumes: the sort key of the last order
+// already returned. Listings run newest first, so the next page holds
+// everything strictly before it.
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// OrderCursor returns the cursor positioned just after order.
+func OrderCursor(order *Order) *PageCursor {
+	return &PageCursor{CreatedAt: order.CreatedAt, ID: order.ID}
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// PaymentOperation is a call made to the payment provider.
+type PaymentOperation string
+
+const (
+	// PaymentAuthorize holds the order total on the customer's payment
+	// method.
+	PaymentAuthorize PaymentOperation = "authorize"
+	// PaymentCapture collects an authorized amount; only a successful
+	// capture marks an order paid.
+	PaymentCapture PaymentOperation = "capture"
+	// PaymentRefund returns captured money.
+	PaymentRefund PaymentOperation = "refund"
+	// PaymentVoid drops an authorization that was never captured.
+	PaymentVoid PaymentOperation = "void"
+)
+
+// PaymentAttempt records one call to the payment provider for an order,
+// whether it succeeded or not. Reference is the provider's identifier of
+// the payment the call acted on, empty when an authorization failed.
+// ReturnID names the return a refund was issued for.
+type PaymentAttempt struct {
+	ID        string           `json:"id"`
+	OrderID   string           `json:"order_id"`
+	Operation PaymentOperation `json:"operation"`
+	Amount    float64          `json:"amount"`
+	Reference string           `json:"reference,omitempty"`
+	ReturnID  string           `json:"return_id,omitempty"`
+	Succeeded bool             `json:"succeeded"`
+	Error     string           `json:"error,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// CapturedPayment sums up the successful attempts of an order, oldest
+// first: the reference of the payment last captured and how much of it
+// has not been refunded yet. The reference is empty when nothing was
+// captured.
+func CapturedPayment(attempts []*PaymentAttempt) (reference string, outstanding float64) {
+	for _, attempt := range attempts {
+		if !attempt.Succeeded {
+			continue
+		}
+		switch attempt.Operation {
+		case PaymentCapture:
+			reference = attempt.Reference
+			outstanding += attempt.Amount
+		case PaymentRefund:
+			outstanding -= attempt.Amount
+		}
+	}
+	return reference, outstanding
+}
@@ -0,0 +1,90 @@
+package domain
+
+import "time"
+
+// ReturnStatus is where a return stands in its workflow.
+type ReturnStatus string
+
+const (
+	// ReturnRequested returns wait for staff to approve or reject them.
+	ReturnRequested ReturnStatus = "requested"
+	// ReturnApproved returns wait for the items to arrive.
+	ReturnApproved ReturnStatus = "approved"
+	ReturnRejected ReturnStatus = "rejected"
+	// ReturnReceived returns have their items back in the warehouse and
+	// wait for the stock and refund to be settled.
+	ReturnReceived ReturnStatus = "received"
+	// ReturnRefunded returns are complete.
+	ReturnRefunded ReturnStatus = "refunded"
+)
+
+// returnTransitions lists the statuses a return may move to from each
+// status.
+var returnTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnRequested: {ReturnApproved, ReturnRejected},
+	ReturnApproved:  {ReturnReceived},
+	ReturnRejected:  {},
+	ReturnReceived:  {ReturnRefunded},
+	ReturnRefunded:  {},
+}
+
+// CanTransitionTo reports whether a return in status s may move to next.
+func (s ReturnStatus) CanTransitionTo(next ReturnStatus) bool {
+	for _, allowed := range returnTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ItemCondition is the state a returned item arrived in.
+type ItemCondition string
+
+const (
+	// ConditionResellable items go back into stock.
+	ConditionResellable ItemCondition = "resellable"
+	// ConditionDamaged items are written off.
+	ConditionDamaged ItemCondition = "damaged"
+)
+
+// IsValid reports whether c is a known condition.
+func (c ItemCondition) IsValid() bool {
+	return c == ConditionResellable || c == ConditionDamaged
+}
+
+// ReturnItem is a quantity of one order line sent back. Price is the unit
+// price the order was placed at; Condition is set once the item has been
+// received.
+type ReturnItem struct {
+	ProductID  string        `json:"product_id"`
+	VariantSKU string        `json:"variant_sku,omitempty"`
+	Quantity   int           `json:"quantity"`
+	Price      float64       `json:"price"`
+	Condition  ItemCondition `json:"condition,omitempty"`
+}
+
+// Return is a customer's request to send back items of a delivered order
+// for a refund. Note holds what staff said when deciding on it, and
+// RefundAmount what was refunded once it is complete.
+type Return struct {
+	ID           string       `json:"id"`
+	OrderID      string       `json:"order_id"`
+	UserID       string       `json:"user_id"`
+	Items        []ReturnItem `json:"items"`
+	Reason       string       `json:"reason"`
+	Status       ReturnStatus `json:"status"`
+	Note         string       `json:"note,omitempty"`
+	RefundAmount float64      `json:"refund_amount"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// Value is what the returned items were bought for.
+func (r *Return) Value() float64 {
+	var value float64
+	for _, item := range r.Items {
+		value += item.Price * float64(item.Quantity)
+	}
+	return value
+}
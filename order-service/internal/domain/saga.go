@@ -0,0 +1,100 @@
+package domain
+
+import "time"
+
+// SagaType names the workflow a saga runs.
+type SagaType string
+
+const (
+	// SagaPlaceOrder reserves stock, stores the order and, when a payment
+	// method was given, charges it.
+	SagaPlaceOrder SagaType = "place_order"
+)
+
+// SagaStatus is where a saga stands as a whole.
+type SagaStatus string
+
+const (
+	// SagaRunning sagas are working through their steps in order.
+	SagaRunning SagaStatus = "running"
+	// SagaCompensating sagas had a step fail for good and are undoing the
+	// steps completed before it, latest first.
+	SagaCompensating SagaStatus = "compensating"
+	SagaCompleted    SagaStatus = "completed"
+	// SagaCompensated sagas failed and have undone everything they did.
+	SagaCompensated SagaStatus = "compensated"
+)
+
+// IsFinished reports whether a saga in status s has nothing left to do.
+func (s SagaStatus) IsFinished() bool {
+	return s == SagaCompleted || s == SagaCompensated
+}
+
+// SagaStepStatus is where a single step of a saga stands.
+type SagaStepStatus string
+
+const (
+	SagaStepPending   SagaStepStatus = "pending"
+	SagaStepCompleted SagaStepStatus = "completed"
+	// SagaStepSkipped steps did not apply to the saga.
+	SagaStepSkipped SagaStepStatus = "skipped"
+	// SagaStepFailed steps made the saga compensate.
+	SagaStepFailed      SagaStepStatus = "failed"
+	SagaStepCompensated SagaStepStatus = "compensated"
+)
+
+// SagaStep records the progress of one step. Attempts counts the failed
+// runs of the step's current action or compensation and Error holds the
+// last failure.
+type SagaStep struct {
+	Name      string         `json:"name"`
+	Status    SagaStepStatus `json:"status"`
+	Attempts  int            `json:"attempts"`
+	Error     string         `json:"error,omitempty"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// Saga is the persisted state of a workflow that spans services. Steps run
+// in order; when one fails for good the completed ones are undone in
+// reverse. Order is the order the saga works on, as it stood when the saga
+// last changed it. A saga is picked up again at NextAttemptAt if it has
+// not finished by then, so one interrupted by a crash is resumed. Version
+// starts at 1 and grows with every change, so two runners cannot both
+// advance the same saga.
+type Saga struct {
+	ID            string     `json:"id"`
+	Type          SagaType   `json:"type"`
+	OrderID       string     `json:"order_id"`
+	UserID        string     `json:"user_id"`
+	Status        SagaStatus `json:"status"`
+	Steps         []SagaStep `json:"steps"`
+	Order         *Order     `json:"order"`
+	PaymentMethod string     `json:"-"`
+	// Error is the failure that made the saga compensate
+	Error         string    `json:"error,omitempty"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	Version       int64     `json:"version"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// NextStep returns the index of the step to run next: the first pending
+// step while the saga runs, or the latest completed step while it
+// compensates. It returns -1 when there is none.
+func (s *Saga) NextStep() int {
+	switch s.Status {
+	case SagaRunning:
+		for i, step := range s.Steps {
+			if step.Status == SagaStepPending {
+				return i
+			}
+		}
+	case SagaCompensating:
+		for i := len(s.Steps) - 1; i >= 0; i-- {
+			if s.Steps[i].Status == SagaStepCompleted {
+				return i
+			}
+		}
+	}
+	return -1
+}
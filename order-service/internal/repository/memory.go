@@ -0,0 +1,14 @@
+package repository
+
+// NewMemoryRepositories returns repositories that keep all data in process
+// memory, for tests and local development without a database.
+func NewMemoryRepositories() Repositories {
+	outbox := NewMemoryOutbox()
+	return Repositories{
+		Orders:   NewMemoryOrderRepository(outbox),
+		Payments: NewMemoryPaymentRepository(),
+		Returns:  NewMemoryReturnRepository(),
+		Sagas:    NewMemorySagaRepository(),
+		Outbox:   outbox,
+	}
+}
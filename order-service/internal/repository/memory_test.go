@@ -0,0 +1,14 @@
+package repository_test
+
+import (
+	"testing"
+
+	"order-service/internal/repository"
+	"order-service/internal/repository/repotest"
+)
+
+func TestMemoryRepositories(t *testing.T) {
+	repotest.TestRepositories(t, func(t *testing.T) repository.Repositories {
+		return repository.NewMemoryRepositories()
+	})
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NewMongoRepositories returns repositories backed by MongoDB.
+func NewMongoRepositories(db *mongo.Database) Repositories {
+	return Repositories{
+		Orders:   NewMongoOrderRepository(db),
+		Payments: NewMongoPaymentRepository(db),
+		Returns:  NewMongoReturnRepository(db),
+		Sagas:    NewMongoSagaRepository(db),
+		Outbox:   NewMongoOutboxRepository(db),
+	}
+}
+
+// CheckMongoTransactions fails unless db is served by a replica set or a
+// sharded cluster. The repositories write outbox events in transactions,
+// which a standalone server refuses.
+func CheckMongoTransactions(ctx context.Context, db *mongo.Database) error {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := db.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return fmt.Errorf("check MongoDB deployment: %w", err)
+	}
+	if hello.SetName == "" && hello.Msg != "isdbgrid" {
+		return errors.New("MongoDB runs standalone but transactions need a replica set; start mongod with --replSet and run rs.initiate() once, a single node will do")
+	}
+	return nil
+}
+
+// EnsureMongoIndexes creates the indexes the repositories rely on.
+// It is safe to call on every start.
+func EnsureMongoIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("orders").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		// Orders are addressed by their own id field rather than _id
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		// Backs the cursor range scans of ListByUser
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "id", Value: -1}}},
+		// Backs the oldest-first scans of ListByStatus
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}, {Key: "id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("payment_attempts").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		// Backs the oldest-first scans of ListByOrder
+		{Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "id", Value: 1}}},
+		// Lets only one of two concurrent returns of an order be stored
+		{Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "seq", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("returns").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		// Backs the oldest-first scans of ListByOrder
+		{Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "id", Value: 1}}},
+		// Lets only one of two concurrent returns of an order be stored
+		{Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "seq", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("sagas").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		// Backs FindByOrderID
+		{Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		// Backs the recovery scans of ListDue
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("outbox").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		// Backs the oldest-first scans of ListPending
+		{Keys: bson.D{{Key: "occurred_at", Value: 1}, {Key: "id", Value: 1}}},
+	})
+	return err
+}
@@ -0,0 +1,43 @@
+package repository_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"order-service/internal/repository"
+	"order-service/internal/repository/repotest"
+)
+
+// TestMongoRepositories runs the suite against the server in
+// TEST_MONGO_URI and is skipped without one. Every repository set gets a
+// database of its own that is dropped afterwards. The server has to run
+// as a replica set for the outbox transactions.
+func TestMongoRepositories(t *testing.T) {
+	uri := os.Getenv("TEST_MONGO_URI")
+	if uri == "" {
+		t.Skip("TEST_MONGO_URI is not set")
+	}
+
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { client.Disconnect(ctx) })
+	if err := repository.CheckMongoTransactions(ctx, client.Database("admin")); err != nil {
+		t.Fatal(err)
+	}
+
+	repotest.TestRepositories(t, func(t *testing.T) repository.Repositories {
+		db := client.Database("order_test_" + primitive.NewObjectID().Hex())
+		t.Cleanup(func() { db.Drop(ctx) })
+		if err := repository.EnsureMongoIndexes(ctx, db); err != nil {
+			t.Fatalf("indexes: %v", err)
+		}
+		return repository.NewMongoRepositories(db)
+	})
+}
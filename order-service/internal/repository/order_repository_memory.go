@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"order-service/internal/domain"
+)
+
+type memoryOrderRepository struct {
+	mu     sync.RWMutex
+	orders map[string]domain.Order
+	outbox *MemoryOutbox
+}
+
+// NewMemoryOrderRepository returns a thread-safe OrderRepository that
+// keeps everything in process memory, for tests and local development,
+// and writes its events to outbox.
+func NewMemoryOrderRepository(outbox *MemoryOutbox) OrderRepository {
+	return &memoryOrderRepository{
+		orders: map[string]domain.Order{},
+		outbox: outbox,
+	}
+}
+
+func (r *memoryOrderRepository) Create(ctx context.Context, order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.orders[order.ID]; ok {
+		return domain.ErrOrderExists
+	}
+
+	now := time.Now()
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	order.Version = 1
+	if err := r.outbox.add(domain.EventOrderCreated, order.ID, order); err != nil {
+		return err
+	}
+	r.orders[order.ID] = copyOrder(*order)
+
+	return nil
+}
+
+func (r *memoryOrderRepository) FindByID(ctx context.Context, id string) (*domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	order = copyOrder(order)
+	return &order, nil
+}
+
+func (r *memoryOrderRepository) UpdateStatus(ctx context.Context, id string, version int64, change domain.StatusChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, ok := r.orders[id]
+	if !ok {
+		return domain.ErrOrderNotFound
+	}
+	if order.Status != change.From || order.Version != version {
+		return domain.ErrStatusConflict
+	}
+
+	order = copyOrder(order)
+	order.Status = change.To
+	order.StatusHistory = append(order.StatusHistory, change)
+	order.UpdatedAt = change.ChangedAt
+	order.Version++
+	event := domain.OrderStatusChange{OrderID: id, Version: order.Version, Change: change}
+	if err := r.outbox.add(domain.EventOrderStatusChanged, id, event); err != nil {
+		return err
+	}
+	r.orders[id] = order
+
+	return nil
+}
+
+func (r *memoryOrderRepository) ListByUser(ctx context.Context, userID string, after *domain.PageCursor, limit int) ([]*domain.Order, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*domain.Order
+	for _, order := range r.orders {
+		if order.UserID != userID {
+			continue
+		}
+		order = copyOrder(order)
+		matched = append(matched, &order)
+	}
+	total := len(matched)
+
+	// Newest first, matching the MongoDB backend
+	sort.Slice(matched, func(i, j int) bool {
+		return newerOrder(matched[i], matched[j])
+	})
+
+	if after != nil {
+		cursor := &domain.Order{ID: after.ID, CreatedAt: after.CreatedAt}
+		start := sort.Search(len(matched), func(i int) bool {
+			return newerOrder(cursor, matched[i])
+		})
+		matched = matched[start:]
+	}
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	return matched, total, nil
+}
+
+func (r *memoryOrderRepository) ListByStatus(ctx context.Context, status domain.OrderStatus, limit int) ([]*domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*domain.Order
+	for _, order := range r.orders {
+		if order.Status != status {
+			continue
+		}
+		order = copyOrder(order)
+		matched = append(matched, &order)
+	}
+
+	// Oldest first, matching the MongoDB backend
+	sort.Slice(matched, func(i, j int) bool {
+		return newerOrder(matched[j], matched[i])
+	})
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	return matched, nil
+}
+
+// newerOrder orders by creation time, newest first, breaking ties on ID so
+// the order is stable.
+func newerOrder(a, b *domain.Order) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+// copyOrder detaches the slices of an order so callers cannot mutate
+// stored state.
+func copyOrder(order domain.Order) domain.Order {
+	order.Items = append([]domain.OrderItem(nil), order.Items...)
+	for i, item := range order.Items {
+		if item.AvailableAt != nil {
+			availableAt := *item.AvailableAt
+			order.Items[i].AvailableAt = &availableAt
+		}
+	}
+	order.StatusHistory = append([]domain.StatusChange(nil), order.StatusHistory...)
+	if order.ShipTo != nil {
+		shipTo := *order.ShipTo
+		order.ShipTo = &shipTo
+	}
+	return order
+}
@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"order-service/internal/domain"
+)
+
+type orderRepository struct {
+	collection *mongo.Collection
+}
+
+type orderItemDocument struct {
+	ProductID   string     `bson:"product_id"`
+	VariantSKU  string     `bson:"variant_sku,omitempty"`
+	ProductName string     `bson:"product_name"`
+	Quantity    int        `bson:"quantity"`
+	Price       float64    `bson:"price"`
+	AvailableAt *time.Time `bson:"available_at,omitempty"`
+}
+
+type statusChangeDocument struct {
+	From      string    `bson:"from"`
+	To        string    `bson:"to"`
+	ChangedBy string    `bson:"changed_by"`
+	Reason    string    `bson:"reason"`
+	ChangedAt time.Time `bson:"changed_at"`
+}
+
+type geoPointDocument struct {
+	Latitude  float64 `bson:"latitude"`
+	Longitude float64 `bson:"longitude"`
+}
+
+type orderDocument struct {
+	ID            string                 `bson:"id"`
+	UserID        string                 `bson:"user_id"`
+	Items         []orderItemDocument    `bson:"items"`
+	Total         float64                `bson:"total"`
+	Status        string                 `bson:"status"`
+	StatusHistory []statusChangeDocument `bson:"status_history"`
+	ShipTo        *geoPointDocument      `bson:"ship_to,omitempty"`
+	Version       int64                  `bson:"version"`
+	CreatedAt     time.Time              `bson:"created_at"`
+	UpdatedAt     time.Time              `bson:"updated_at"`
+}
+
+func NewMongoOrderRepository(db *mongo.Database) OrderRepository {
+	return &orderRepository{
+		collection: db.Collection("orders"),
+	}
+}
+
+func (r *orderRepository) Create(ctx context.Context, order *domain.Order) error {
+	now := time.Now()
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	order.Version = 1
+
+	err := inTransaction(ctx, r.collection.Database(), func(ctx mongo.SessionContext) error {
+		if _, err := r.collection.InsertOne(ctx, toOrderDocument(order)); err != nil {
+			return err
+		}
+		return writeEvent(ctx, r.collection.Database(), domain.EventOrderCreated, order.ID, order)
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrOrderExists
+	}
+	return err
+}
+
+// FindByID retrieves a single order by its ID from the database.
+// Corresponds to: rpc GetOrderByID(GetOrderRequest) returns (OrderResponse)
+func (r *orderRepository) FindByID(ctx context.Context, id string) (*domain.Order, error) {
+	var doc orderDocument
+	err := r.collection.FindOne(ctx, bson.M{"id": id}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+// UpdateStatus moves an order from change.From to change.To and appends the
+// change to its status history. The update only applies while the order is
+// still at version and in change.From, so concurrent changes cannot both
+// succeed.
+// Corresponds to: rpc UpdateOrderStatus(UpdateOrderStatusRequest) returns (OrderResponse)
+func (r *orderRepository) UpdateStatus(ctx context.Context, id string, version int64, change domain.StatusChange) error {
+	return inTransaction(ctx, r.collection.Database(), func(ctx mongo.SessionContext) error {
+		result, err := r.collection.UpdateOne(
+			ctx,
+			bson.M{"id": id, "status": string(change.From), "version": versionFilter(version)},
+			bson.M{
+				"$set": bson.M{
+					"status":     string(change.To),
+					"updated_at": change.ChangedAt,
+				},
+				"$inc": bson.M{"version": 1},
+				"$push": bson.M{
+					"status_history": toStatusChangeDocument(change),
+				},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			count, err := r.collection.CountDocuments(ctx, bson.M{"id": id})
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return domain.ErrOrderNotFound
+			}
+			return domain.ErrStatusConflict
+		}
+
+		event := domain.OrderStatusChange{OrderID: id, Version: version + 1, Change: change}
+		return writeEvent(ctx, r.collection.Database(), domain.EventOrderStatusChanged, id, event)
+	})
+}
+
+// ListByUser fetches a page of orders for a given user ID, newest first.
+// Corresponds to: rpc ListUserOrders(ListOrdersRequest) returns (ListOrdersResponse)
+func (r *orderRepository) ListByUser(ctx context.Context, userID string, after *domain.PageCursor, limit int) ([]*domain.Order, int, error) {
+	filter := bson.M{"user_id": userID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Resume strictly after the cursor in (created_at, id) order
+	if after != nil {
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": after.CreatedAt}},
+			bson.M{"created_at": after.CreatedAt, "id": bson.M{"$lt": after.ID}},
+		}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	orders := []*domain.Order{}
+	for cursor.Next(ctx) {
+		var doc orderDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, 0, err
+		}
+		orders = append(orders, doc.toDomain())
+	}
+	return orders, int(total), cursor.Err()
+}
+
+// ListByStatus fetches up to limit orders in status, oldest first.
+func (r *orderRepository) ListByStatus(ctx context.Context, status domain.OrderStatus, limit int) ([]*domain.Order, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"status": string(status)}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	orders := []*domain.Order{}
+	for cursor.Next(ctx) {
+		var doc orderDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		orders = append(orders, doc.toDomain())
+	}
+	return orders, cursor.Err()
+}
+
+func toOrderDocument(order *domain.Order) orderDocument {
+	items := make([]orderItemDocument, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = orderItemDocument{
+			ProductID:   item.ProductID,
+			VariantSKU:  item.VariantSKU,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			AvailableAt: item.AvailableAt,
+		}
+	}
+
+	history := make([]statusChangeDocument, len(order.StatusHistory))
+	for i, change := range order.StatusHistory {
+		history[i] = toStatusChangeDocument(change)
+	}
+
+	var shipTo *geoPointDocument
+	if order.ShipTo != nil {
+		shipTo = &geoPointDocument{Latitude: order.ShipTo.Latitude, Longitude: order.ShipTo.Longitude}
+	}
+
+	return orderDocument{
+		ID:            order.ID,
+		UserID:        order.UserID,
+		Items:         items,
+		Total:         order.Total,
+		Status:        string(order.Status),
+		StatusHistory: history,
+		ShipTo:        shipTo,
+		Version:       order.Version,
+		CreatedAt:     order.CreatedAt,
+		UpdatedAt:     order.UpdatedAt,
+	}
+}
+
+func (d *orderDocument) toDomain() *domain.Order {
+	items := make([]domain.OrderItem, len(d.Items))
+	for i, item := range d.Items {
+		items[i] = domain.OrderItem{
+			ProductID:   item.ProductID,
+			VariantSKU:  item.VariantSKU,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			AvailableAt: item.AvailableAt,
+		}
+	}
+
+	history := make([]domain.StatusChange, len(d.StatusHistory))
+	for i, change := range d.StatusHistory {
+		history[i] = domain.StatusChange{
+			From:      domain.OrderStatus(change.From),
+			To:        domain.OrderStatus(change.To),
+			ChangedBy: change.ChangedBy,
+			Reason:    change.Reason,
+			ChangedAt: change.ChangedAt,
+		}
+	}
+
+	var shipTo *domain.GeoPoint
+	if d.ShipTo != nil {
+		shipTo = &domain.GeoPoint{Latitude: d.ShipTo.Latitude, Longitude: d.ShipTo.Longitude}
+	}
+
+	return &domain.Order{
+		ID:            d.ID,
+		UserID:        d.UserID,
+		Items:         items,
+		Total:         d.Total,
+		Status:        domain.OrderStatus(d.Status),
+		StatusHistory: history,
+		ShipTo:        shipTo,
+		Version:       d.Version,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
+
+// versionFilter matches an order at version, treating orders stored before
+// versions were tracked as version 0.
+func versionFilter(version int64) interface{} {
+	if version == 0 {
+		return bson.M{"$exists": false}
+	}
+	return version
+}
+
+func toStatusChangeDocument(change domain.StatusChange) statusChangeDocument {
+	return statusChangeDocument{
+		From:      string(change.From),
+		To:        string(change.To),
+		ChangedBy: change.ChangedBy,
+		Reason:    change.Reason,
+		ChangedAt: change.ChangedAt,
+	}
+}
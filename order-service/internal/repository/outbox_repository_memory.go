@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"order-service/internal/domain"
+)
+
+// MemoryOutbox is an OutboxRepository that keeps events in process memory.
+// The memory order repository writes to it while holding its own lock, so
+// an event is there as soon as its change is.
+type MemoryOutbox struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+// NewMemoryOutbox returns an empty MemoryOutbox.
+func NewMemoryOutbox() *MemoryOutbox {
+	return &MemoryOutbox{}
+}
+
+func (o *MemoryOutbox) ListPending(ctx context.Context, limit int) ([]*domain.Event, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	events := []*domain.Event{}
+	for _, event := range o.events {
+		if len(events) == limit {
+			break
+		}
+		event := event
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+func (o *MemoryOutbox) Delete(ctx context.Context, ids []string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	deleted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		deleted[id] = true
+	}
+
+	kept := o.events[:0]
+	for _, event := range o.events {
+		if !deleted[event.ID] {
+			kept = append(kept, event)
+		}
+	}
+	o.events = kept
+
+	return nil
+}
+
+// add appends an event about aggregateID. A nil outbox drops it.
+func (o *MemoryOutbox) add(eventType domain.EventType, aggregateID string, payload interface{}) error {
+	if o == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.events = append(o.events, domain.Event{
+		ID:          primitive.NewObjectID().Hex(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     data,
+		OccurredAt:  time.Now(),
+	})
+	return nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"order-service/internal/domain"
+)
+
+type memoryPaymentRepository struct {
+	mu       sync.RWMutex
+	attempts map[string][]domain.PaymentAttempt
+}
+
+// NewMemoryPaymentRepository returns a thread-safe PaymentRepository that
+// keeps everything in process memory, for tests and local development.
+func NewMemoryPaymentRepository() PaymentRepository {
+	return &memoryPaymentRepository{
+		attempts: map[string][]domain.PaymentAttempt{},
+	}
+}
+
+func (r *memoryPaymentRepository) Record(ctx context.Context, attempt *domain.PaymentAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt.ID = primitive.NewObjectID().Hex()
+	attempt.CreatedAt = time.Now()
+	r.attempts[attempt.OrderID] = append(r.attempts[attempt.OrderID], *attempt)
+
+	return nil
+}
+
+func (r *memoryPaymentRepository) ListByOrder(ctx context.Context, orderID string) ([]*domain.PaymentAttempt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Attempts are appended as they are recorded, so they are already
+	// oldest first
+	stored := r.attempts[orderID]
+	attempts := make([]*domain.PaymentAttempt, len(stored))
+	for i := range stored {
+		attempt := stored[i]
+		attempts[i] = &attempt
+	}
+	return attempts, nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"order-service/internal/domain"
+)
+
+type paymentRepository struct {
+	collection *mongo.Collection
+}
+
+type paymentAttemptDocument struct {
+	ID        string    `bson:"id"`
+	OrderID   string    `bson:"order_id"`
+	Operation string    `bson:"operation"`
+	Amount    float64   `bson:"amount"`
+	Reference string    `bson:"reference,omitempty"`
+	ReturnID  string    `bson:"return_id,omitempty"`
+	Succeeded bool      `bson:"succeeded"`
+	Error     string    `bson:"error,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func NewMongoPaymentRepository(db *mongo.Database) PaymentRepository {
+	return &paymentRepository{
+		collection: db.Collection("payment_attempts"),
+	}
+}
+
+func (r *paymentRepository) Record(ctx context.Context, attempt *domain.PaymentAttempt) error {
+	attempt.ID = primitive.NewObjectID().Hex()
+	attempt.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, paymentAttemptDocument{
+		ID:        attempt.ID,
+		OrderID:   attempt.OrderID,
+		Operation: string(attempt.Operation),
+		Amount:    attempt.Amount,
+		Reference: attempt.Reference,
+		ReturnID:  attempt.ReturnID,
+		Succeeded: attempt.Succeeded,
+		Error:     attempt.Error,
+		CreatedAt: attempt.CreatedAt,
+	})
+	return err
+}
+
+// ListByOrder fetches every payment attempt of an order, oldest first.
+func (r *paymentRepository) ListByOrder(ctx context.Context, orderID string) ([]*domain.PaymentAttempt, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"order_id": orderID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	attempts := []*domain.PaymentAttempt{}
+	for cursor.Next(ctx) {
+		var doc paymentAttemptDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, doc.toDomain())
+	}
+	return attempts, cursor.Err()
+}
+
+func (d *paymentAttemptDocument) toDomain() *domain.PaymentAttempt {
+	return &domain.PaymentAttempt{
+		ID:        d.ID,
+		OrderID:   d.OrderID,
+		Operation: domain.PaymentOperation(d.Operation),
+		Amount:    d.Amount,
+		Reference: d.Reference,
+		ReturnID:  d.ReturnID,
+		Succeeded: d.Succeeded,
+		Error:     d.Error,
+		CreatedAt: d.CreatedAt,
+	}
+}
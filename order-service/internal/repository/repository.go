@@ -1,10 +1,85 @@
 package repository
 
-import "order-service/internal/domain"
+import (
+	"context"
+	"time"
 
+	"order-service/internal/domain"
+)
+
+// OrderRepository stores orders. Create and UpdateStatus write an
+// OrderCreated or OrderStatusChanged event to the outbox in the same write
+// as the order.
 type OrderRepository interface {
-	Create(order *domain.Order) error
-	FindByID(id string) (*domain.Order, error)
-	UpdateStatus(id string, status domain.OrderStatus) error
-	ListByUser(userID string, page, limit int) ([]*domain.Order, int, error)
+	// Create stores an order at version 1.
+	Create(ctx context.Context, order *domain.Order) error
+	FindByID(ctx context.Context, id string) (*domain.Order, error)
+	// UpdateStatus applies change and bumps the version of an order that
+	// is still at version and in status change.From, and fails with
+	// ErrStatusConflict otherwise.
+	UpdateStatus(ctx context.Context, id string, version int64, change domain.StatusChange) error
+	// ListByUser returns up to limit orders of a user newest first,
+	// starting after the cursor when one is given, together with the
+	// user's total order count.
+	ListByUser(ctx context.Context, userID string, after *domain.PageCursor, limit int) ([]*domain.Order, int, error)
+	// ListByStatus returns up to limit orders in status, oldest first.
+	ListByStatus(ctx context.Context, status domain.OrderStatus, limit int) ([]*domain.Order, error)
+}
+
+// PaymentRepository stores the calls made to the payment provider for
+// orders. Attempts are only ever appended.
+type PaymentRepository interface {
+	// Record stores an attempt, assigning its ID and creation time.
+	Record(ctx context.Context, attempt *domain.PaymentAttempt) error
+	// ListByOrder returns every attempt made for an order, oldest first.
+	ListByOrder(ctx context.Context, orderID string) ([]*domain.PaymentAttempt, error)
+}
+
+// ReturnRepository stores customer returns.
+type ReturnRepository interface {
+	// Create stores a new return, assigning its ID and creation time. seen
+	// is how many returns of the order the caller found before it; if
+	// another one was stored since, Create fails with ErrReturnConflict so
+	// the caller checks the items again.
+	Create(ctx context.Context, ret *domain.Return, seen int) error
+	FindByID(ctx context.Context, id string) (*domain.Return, error)
+	// ListByOrder returns every return of an order, oldest first.
+	ListByOrder(ctx context.Context, orderID string) ([]*domain.Return, error)
+	// Update stores the status, items, note and refund amount of a return
+	// that is still in status from, and fails with ErrReturnConflict
+	// otherwise.
+	Update(ctx context.Context, ret *domain.Return, from domain.ReturnStatus) error
+}
+
+// SagaRepository stores the state of sagas.
+type SagaRepository interface {
+	// Create stores a new saga at version 1, assigning its ID and creation
+	// time.
+	Create(ctx context.Context, saga *domain.Saga) error
+	// FindByOrderID returns the latest saga started for an order.
+	FindByOrderID(ctx context.Context, orderID string) (*domain.Saga, error)
+	// Update stores a saga that is still at saga.Version and bumps its
+	// version, and fails with ErrSagaConflict otherwise.
+	Update(ctx context.Context, saga *domain.Saga) error
+	// ListDue returns up to limit unfinished sagas whose next attempt is
+	// due at now, longest due first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Saga, error)
+}
+
+// OutboxRepository reads the domain events the other repositories write
+// together with the changes they describe, for the relay to publish.
+type OutboxRepository interface {
+	// ListPending returns up to limit unpublished events, oldest first.
+	ListPending(ctx context.Context, limit int) ([]*domain.Event, error)
+	// Delete removes published events; unknown IDs are ignored.
+	Delete(ctx context.Context, ids []string) error
+}
+
+// Repositories bundles the repositories of one storage backend.
+type Repositories struct {
+	Orders   OrderRepository
+	Payments PaymentRepository
+	Returns  ReturnRepository
+	Sagas    SagaRepository
+	Outbox   OutboxRepository
 }
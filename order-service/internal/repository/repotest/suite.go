@@ -0,0 +1,342 @@
+// Package repotest is the conformance suite every storage backend of
+// order-service must pass. A backend's test calls the Test functions with
+// a constructor returning a fresh, empty repository set.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+	"order-service/internal/repository"
+)
+
+// Factory returns repositories backed by empty storage.
+type Factory func(t *testing.T) repository.Repositories
+
+// TestRepositories runs the whole suite.
+func TestRepositories(t *testing.T, newRepos Factory) {
+	t.Run("Orders", func(t *testing.T) { TestOrderRepository(t, newRepos) })
+	t.Run("Returns", func(t *testing.T) { TestReturnRepository(t, newRepos) })
+}
+
+func TestOrderRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo := newRepos(t).Orders
+		order := &domain.Order{
+			ID:     "o1",
+			UserID: "u1",
+			Items:  []domain.OrderItem{{ProductID: "p1", ProductName: "Mug", Quantity: 2, Price: 9.5}},
+			Total:  19,
+			Status: domain.OrderStatusPending,
+			ShipTo: &domain.GeoPoint{Latitude: 43.2, Longitude: 76.9},
+		}
+		mustCreate(t, repo, order)
+
+		got, err := repo.FindByID(ctx, "o1")
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.UserID != "u1" || got.Total != 19 || got.Status != domain.OrderStatusPending || len(got.Items) != 1 || got.Items[0].Quantity != 2 {
+			t.Fatalf("FindByID returned %+v", got)
+		}
+		if got.ShipTo == nil || *got.ShipTo != *order.ShipTo {
+			t.Fatalf("ship to = %v, want %v", got.ShipTo, order.ShipTo)
+		}
+		if order.Version != 1 || got.Version != 1 {
+			t.Fatalf("Create set version %d, stored %d, want 1", order.Version, got.Version)
+		}
+		if got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+			t.Fatalf("timestamps not set: %+v", got)
+		}
+
+		if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, domain.ErrOrderNotFound) {
+			t.Fatalf("FindByID of a missing order returned %v, want ErrOrderNotFound", err)
+		}
+	})
+
+	t.Run("CreateDuplicate", func(t *testing.T) {
+		repo := newRepos(t).Orders
+		mustCreate(t, repo, &domain.Order{ID: "o1", UserID: "u1", Status: domain.OrderStatusPending})
+		if err := repo.Create(ctx, &domain.Order{ID: "o1", UserID: "u2", Status: domain.OrderStatusPending}); !errors.Is(err, domain.ErrOrderExists) {
+			t.Fatalf("Create of an existing ID returned %v, want ErrOrderExists", err)
+		}
+	})
+
+	t.Run("UpdateStatus", func(t *testing.T) {
+		repo := newRepos(t).Orders
+		mustCreate(t, repo, &domain.Order{ID: "o1", UserID: "u1", Status: domain.OrderStatusPending})
+
+		change := domain.StatusChange{
+			From:      domain.OrderStatusPending,
+			To:        domain.OrderStatusPaid,
+			ChangedBy: "u1",
+			Reason:    "paid",
+			ChangedAt: time.Now().Truncate(time.Millisecond),
+		}
+		if err := repo.UpdateStatus(ctx, "o1", 1, change); err != nil {
+			t.Fatalf("UpdateStatus: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, "o1")
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Status != domain.OrderStatusPaid || got.Version != 2 {
+			t.Fatalf("order is %s at version %d, want paid at version 2", got.Status, got.Version)
+		}
+		if len(got.StatusHistory) != 1 {
+			t.Fatalf("history has %d entries, want 1", len(got.StatusHistory))
+		}
+		if h := got.StatusHistory[0]; h.From != change.From || h.To != change.To || h.ChangedBy != "u1" || h.Reason != "paid" || !h.ChangedAt.Equal(change.ChangedAt) {
+			t.Fatalf("history entry = %+v, want %+v", h, change)
+		}
+	})
+
+	t.Run("UpdateStatusConflicts", func(t *testing.T) {
+		repo := newRepos(t).Orders
+		mustCreate(t, repo, &domain.Order{ID: "o1", UserID: "u1", Status: domain.OrderStatusPending})
+
+		paid := domain.StatusChange{From: domain.OrderStatusPending, To: domain.OrderStatusPaid, ChangedAt: time.Now()}
+		if err := repo.UpdateStatus(ctx, "o1", 1, paid); err != nil {
+			t.Fatalf("UpdateStatus: %v", err)
+		}
+
+		tests := []struct {
+			name    string
+			id      string
+			version int64
+			change  domain.StatusChange
+			want    error
+		}{
+			{
+				name:    "stale version",
+				id:      "o1",
+				version: 1,
+				change:  domain.StatusChange{From: domain.OrderStatusPaid, To: domain.OrderStatusShipped, ChangedAt: time.Now()},
+				want:    domain.ErrStatusConflict,
+			},
+			{
+				name:    "changed status",
+				id:      "o1",
+				version: 2,
+				change:  domain.StatusChange{From: domain.OrderStatusPending, To: domain.OrderStatusCancelled, ChangedAt: time.Now()},
+				want:    domain.ErrStatusConflict,
+			},
+			{
+				name:    "missing order",
+				id:      "missing",
+				version: 1,
+				change:  paid,
+				want:    domain.ErrOrderNotFound,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := repo.UpdateStatus(ctx, tt.id, tt.version, tt.change); !errors.Is(err, tt.want) {
+					t.Fatalf("UpdateStatus returned %v, want %v", err, tt.want)
+				}
+			})
+		}
+
+		got, err := repo.FindByID(ctx, "o1")
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Status != domain.OrderStatusPaid || got.Version != 2 || len(got.StatusHistory) != 1 {
+			t.Fatalf("refused updates changed the order: %s at version %d with %d changes", got.Status, got.Version, len(got.StatusHistory))
+		}
+	})
+
+	t.Run("ListByUser", func(t *testing.T) {
+		repo := newRepos(t).Orders
+		for _, id := range []string{"o1", "o2", "o3", "o4", "o5"} {
+			mustCreate(t, repo, &domain.Order{ID: id, UserID: "u1", Status: domain.OrderStatusPending})
+			// Distinct creation times at the millisecond precision MongoDB stores
+			time.Sleep(2 * time.Millisecond)
+		}
+		mustCreate(t, repo, &domain.Order{ID: "other", UserID: "u2", Status: domain.OrderStatusPending})
+
+		// Newest first, page by page
+		var (
+			listed []*domain.Order
+			after  *domain.PageCursor
+		)
+		for page := 0; ; page++ {
+			orders, total, err := repo.ListByUser(ctx, "u1", after, 2)
+			if err != nil {
+				t.Fatalf("ListByUser: %v", err)
+			}
+			if total != 5 {
+				t.Fatalf("total = %d, want 5", total)
+			}
+			listed = append(listed, orders...)
+			if len(orders) < 2 || page > 5 {
+				break
+			}
+			after = domain.OrderCursor(orders[len(orders)-1])
+		}
+		assertIDs(t, listed, "o5", "o4", "o3", "o2", "o1")
+
+		orders, total, err := repo.ListByUser(ctx, "nobody", nil, 10)
+		if err != nil || len(orders) != 0 || total != 0 {
+			t.Fatalf("ListByUser of a user without orders = %d orders, total %d, %v", len(orders), total, err)
+		}
+	})
+
+	t.Run("ListByStatus", func(t *testing.T) {
+		repo := newRepos(t).Orders
+		for _, id := range []string{"o1", "o2", "o3", "o4"} {
+			status := domain.OrderStatusBackordered
+			if id == "o2" {
+				status = domain.OrderStatusPending
+			}
+			mustCreate(t, repo, &domain.Order{ID: id, UserID: "u1", Status: status})
+			time.Sleep(2 * time.Millisecond)
+		}
+
+		// Oldest first, up to the limit
+		orders, err := repo.ListByStatus(ctx, domain.OrderStatusBackordered, 2)
+		if err != nil {
+			t.Fatalf("ListByStatus: %v", err)
+		}
+		assertIDs(t, orders, "o1", "o3")
+
+		// Orders leave the listing when their status changes
+		change := domain.StatusChange{From: domain.OrderStatusBackordered, To: domain.OrderStatusPending, ChangedAt: time.Now()}
+		if err := repo.UpdateStatus(ctx, "o1", 1, change); err != nil {
+			t.Fatalf("UpdateStatus: %v", err)
+		}
+		orders, err = repo.ListByStatus(ctx, domain.OrderStatusBackordered, 10)
+		if err != nil {
+			t.Fatalf("ListByStatus: %v", err)
+		}
+		assertIDs(t, orders, "o3", "o4")
+	})
+
+	t.Run("WritesEvents", func(t *testing.T) {
+		repos := newRepos(t)
+		mustCreate(t, repos.Orders, &domain.Order{ID: "o1", UserID: "u1", Status: domain.OrderStatusPending})
+		change := domain.StatusChange{From: domain.OrderStatusPending, To: domain.OrderStatusCancelled, ChangedAt: time.Now()}
+		if err := repos.Orders.UpdateStatus(ctx, "o1", 1, change); err != nil {
+			t.Fatalf("UpdateStatus: %v", err)
+		}
+		// A refused update writes no event
+		if err := repos.Orders.UpdateStatus(ctx, "o1", 1, change); !errors.Is(err, domain.ErrStatusConflict) {
+			t.Fatalf("UpdateStatus returned %v, want ErrStatusConflict", err)
+		}
+
+		events, err := repos.Outbox.ListPending(ctx, 10)
+		if err != nil {
+			t.Fatalf("ListPending: %v", err)
+		}
+		if len(events) != 2 || events[0].Type != domain.EventOrderCreated || events[1].Type != domain.EventOrderStatusChanged {
+			t.Fatalf("outbox holds %+v, want OrderCreated then OrderStatusChanged", events)
+		}
+		for _, event := range events {
+			if event.AggregateID != "o1" {
+				t.Fatalf("event %s is for %q, want o1", event.Type, event.AggregateID)
+			}
+		}
+	})
+}
+
+func TestReturnRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	newReturn := func() *domain.Return {
+		return &domain.Return{
+			OrderID: "o1",
+			UserID:  "u1",
+			Items:   []domain.ReturnItem{{ProductID: "p1", Quantity: 1, Price: 10}},
+			Reason:  "broken",
+			Status:  domain.ReturnRequested,
+		}
+	}
+
+	t.Run("CreateCountsEarlierReturns", func(t *testing.T) {
+		repo := newRepos(t).Returns
+
+		first := newReturn()
+		if err := repo.Create(ctx, first, 0); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if first.ID == "" || first.CreatedAt.IsZero() {
+			t.Fatalf("Create did not assign an ID and creation time: %+v", first)
+		}
+
+		// Another request saw no return either
+		if err := repo.Create(ctx, newReturn(), 0); !errors.Is(err, domain.ErrReturnConflict) {
+			t.Fatalf("Create after a concurrent return returned %v, want ErrReturnConflict", err)
+		}
+		if err := repo.Create(ctx, newReturn(), 1); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		// Returns of other orders are counted apart
+		other := newReturn()
+		other.OrderID = "o2"
+		if err := repo.Create(ctx, other, 0); err != nil {
+			t.Fatalf("Create for another order: %v", err)
+		}
+
+		returns, err := repo.ListByOrder(ctx, "o1")
+		if err != nil {
+			t.Fatalf("ListByOrder: %v", err)
+		}
+		if len(returns) != 2 || returns[0].ID != first.ID {
+			t.Fatalf("ListByOrder returned %d returns, want 2 starting with %s", len(returns), first.ID)
+		}
+	})
+
+	t.Run("UpdateFromStatus", func(t *testing.T) {
+		repo := newRepos(t).Returns
+		ret := newReturn()
+		if err := repo.Create(ctx, ret, 0); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		ret.Status = domain.ReturnApproved
+		ret.Note = "send it"
+		if err := repo.Update(ctx, ret, domain.ReturnRequested); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if err := repo.Update(ctx, ret, domain.ReturnRequested); !errors.Is(err, domain.ErrReturnConflict) {
+			t.Fatalf("Update from a stale status returned %v, want ErrReturnConflict", err)
+		}
+
+		got, err := repo.FindByID(ctx, ret.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Status != domain.ReturnApproved || got.Note != "send it" {
+			t.Fatalf("FindByID returned %+v", got)
+		}
+		if _, err := repo.FindByID(ctx, "missing"); !errors.Is(err, domain.ErrReturnNotFound) {
+			t.Fatalf("FindByID of a missing return returned %v, want ErrReturnNotFound", err)
+		}
+	})
+}
+
+func mustCreate(t *testing.T, repo repository.OrderRepository, order *domain.Order) {
+	t.Helper()
+	if err := repo.Create(context.Background(), order); err != nil {
+		t.Fatalf("Create(%s): %v", order.ID, err)
+	}
+}
+
+func assertIDs(t *testing.T, orders []*domain.Order, want ...string) {
+	t.Helper()
+	if len(orders) != len(want) {
+		t.Fatalf("got %d orders, want %v", len(orders), want)
+	}
+	for i, o := range orders {
+		if o.ID != want[i] {
+			t.Fatalf("order %d is %s, want %s", i, o.ID, want[i])
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"order-service/internal/domain"
+)
+
+type memoryReturnRepository struct {
+	mu      sync.RWMutex
+	returns map[string]domain.Return
+}
+
+// NewMemoryReturnRepository returns a thread-safe ReturnRepository that
+// keeps everything in process memory, for tests and local development.
+func NewMemoryReturnRepository() ReturnRepository {
+	return &memoryReturnRepository{
+		returns: map[string]domain.Return{},
+	}
+}
+
+func (r *memoryReturnRepository) Create(ctx context.Context, ret *domain.Return, seen int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, stored := range r.returns {
+		if stored.OrderID == ret.OrderID {
+			count++
+		}
+	}
+	if count != seen {
+		return domain.ErrReturnConflict
+	}
+
+	now := time.Now()
+	ret.ID = primitive.NewObjectID().Hex()
+	ret.CreatedAt = now
+	ret.UpdatedAt = now
+	r.returns[ret.ID] = copyReturn(*ret)
+
+	return nil
+}
+
+func (r *memoryReturnRepository) FindByID(ctx context.Context, id string) (*domain.Return, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ret, ok := r.returns[id]
+	if !ok {
+		return nil, domain.ErrReturnNotFound
+	}
+
+	ret = copyReturn(ret)
+	return &ret, nil
+}
+
+func (r *memoryReturnRepository) ListByOrder(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	returns := []*domain.Return{}
+	for _, ret := range r.returns {
+		if ret.OrderID != orderID {
+			continue
+		}
+		ret = copyReturn(ret)
+		returns = append(returns, &ret)
+	}
+
+	// Oldest first, matching the MongoDB backend
+	sort.Slice(returns, func(i, j int) bool {
+		if !returns[i].CreatedAt.Equal(returns[j].CreatedAt) {
+			return returns[i].CreatedAt.Before(returns[j].CreatedAt)
+		}
+		return returns[i].ID < returns[j].ID
+	})
+
+	return returns, nil
+}
+
+func (r *memoryReturnRepository) Update(ctx context.Context, ret *domain.Return, from domain.ReturnStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.returns[ret.ID]
+	if !ok {
+		return domain.ErrReturnNotFound
+	}
+	if stored.Status != from {
+		return domain.ErrReturnConflict
+	}
+
+	ret.UpdatedAt = time.Now()
+	stored.Status = ret.Status
+	stored.Items = ret.Items
+	stored.Note = ret.Note
+	stored.RefundAmount = ret.RefundAmount
+	stored.UpdatedAt = ret.UpdatedAt
+	r.returns[ret.ID] = copyReturn(stored)
+
+	return nil
+}
+
+// copyReturn detaches the items of a return so callers cannot mutate
+// stored state.
+func copyReturn(ret domain.Return) domain.Return {
+	ret.Items = append([]domain.ReturnItem(nil), ret.Items...)
+	return ret
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"order-service/internal/domain"
+)
+
+type returnRepository struct {
+	collection *mongo.Collection
+}
+
+type returnItemDocument struct {
+	ProductID  string  `bson:"product_id"`
+	VariantSKU string  `bson:"variant_sku,omitempty"`
+	Quantity   int     `bson:"quantity"`
+	Price      float64 `bson:"price"`
+	Condition  string  `bson:"condition,omitempty"`
+}
+
+type returnDocument struct {
+	ID           string               `bson:"id"`
+	OrderID      string               `bson:"order_id"`
+	Seq          int                  `bson:"seq"`
+	UserID       string               `bson:"user_id"`
+	Items        []returnItemDocument `bson:"items"`
+	Reason       string               `bson:"reason"`
+	Status       string               `bson:"status"`
+	Note         string               `bson:"note,omitempty"`
+	RefundAmount float64              `bson:"refund_amount"`
+	CreatedAt    time.Time            `bson:"created_at"`
+	UpdatedAt    time.Time            `bson:"updated_at"`
+}
+
+func NewMongoReturnRepository(db *mongo.Database) ReturnRepository {
+	return &returnRepository{
+		collection: db.Collection("returns"),
+	}
+}
+
+// Create numbers the returns of an order from 1; the unique index on
+// order_id and seq refuses a second return taking the same number.
+func (r *returnRepository) Create(ctx context.Context, ret *domain.Return, seen int) error {
+	now := time.Now()
+	ret.ID = primitive.NewObjectID().Hex()
+	ret.CreatedAt = now
+	ret.UpdatedAt = now
+
+	_, err := r.collection.InsertOne(ctx, returnDocument{
+		ID:           ret.ID,
+		OrderID:      ret.OrderID,
+		Seq:          seen + 1,
+		UserID:       ret.UserID,
+		Items:        returnItemDocuments(ret.Items),
+		Reason:       ret.Reason,
+		Status:       string(ret.Status),
+		Note:         ret.Note,
+		RefundAmount: ret.RefundAmount,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrReturnConflict
+	}
+	return err
+}
+
+func (r *returnRepository) FindByID(ctx context.Context, id string) (*domain.Return, error) {
+	var doc returnDocument
+	err := r.collection.FindOne(ctx, bson.M{"id": id}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrReturnNotFound
+		}
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+// ListByOrder fetches every return of an order, oldest first.
+func (r *returnRepository) ListByOrder(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"order_id": orderID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	returns := []*domain.Return{}
+	for cursor.Next(ctx) {
+		var doc returnDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		returns = append(returns, doc.toDomain())
+	}
+	return returns, cursor.Err()
+}
+
+// Update writes a return back, matching on its stored status so that
+// concurrent changes cannot both succeed.
+func (r *returnRepository) Update(ctx context.Context, ret *domain.Return, from domain.ReturnStatus) error {
+	now := time.Now()
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"id": ret.ID, "status": string(from)},
+		bson.M{"$set": bson.M{
+			"status":        string(ret.Status),
+			"items":         returnItemDocuments(ret.Items),
+			"note":          ret.Note,
+			"refund_amount": ret.RefundAmount,
+			"updated_at":    now,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		count, err := r.collection.CountDocuments(ctx, bson.M{"id": ret.ID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return domain.ErrReturnNotFound
+		}
+		return domain.ErrReturnConflict
+	}
+
+	ret.UpdatedAt = now
+	return nil
+}
+
+func returnItemDocuments(items []domain.ReturnItem) []returnItemDocument {
+	docs := make([]returnItemDocument, len(items))
+	for i, item := range items {
+		docs[i] = returnItemDocument{
+			ProductID:  item.ProductID,
+			VariantSKU: item.VariantSKU,
+			Quantity:   item.Quantity,
+			Price:      item.Price,
+			Condition:  string(item.Condition),
+		}
+	}
+	return docs
+}
+
+func (d *returnDocument) toDomain() *domain.Return {
+	items := make([]domain.ReturnItem, len(d.Items))
+	for i, item := range d.Items {
+		items[i] = domain.ReturnItem{
+			ProductID:  item.ProductID,
+			VariantSKU: item.VariantSKU,
+			Quantity:   item.Quantity,
+			Price:      item.Price,
+			Condition:  domain.ItemCondition(item.Condition),
+		}
+	}
+
+	return &domain.Return{
+		ID:           d.ID,
+		OrderID:      d.OrderID,
+		UserID:       d.UserID,
+		Items:        items,
+		Reason:       d.Reason,
+		Status:       domain.ReturnStatus(d.Status),
+		Note:         d.Note,
+		RefundAmount: d.RefundAmount,
+		CreatedAt:    d.CreatedAt,
+		UpdatedAt:    d.UpdatedAt,
+	}
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"order-service/internal/domain"
+)
+
+type memorySagaRepository struct {
+	mu    sync.RWMutex
+	sagas map[string]domain.Saga
+}
+
+// NewMemorySagaRepository returns a thread-safe SagaRepository that keeps
+// everything in process memory, for tests and local development.
+func NewMemorySagaRepository() SagaRepository {
+	return &memorySagaRepository{
+		sagas: map[string]domain.Saga{},
+	}
+}
+
+func (r *memorySagaRepository) Create(ctx context.Context, saga *domain.Saga) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	saga.ID = primitive.NewObjectID().Hex()
+	saga.Version = 1
+	saga.CreatedAt = now
+	saga.UpdatedAt = now
+	r.sagas[saga.ID] = copySaga(*saga)
+
+	return nil
+}
+
+func (r *memorySagaRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.Saga, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *domain.Saga
+	for _, saga := range r.sagas {
+		if saga.OrderID != orderID {
+			continue
+		}
+		if latest == nil || saga.CreatedAt.After(latest.CreatedAt) {
+			saga = copySaga(saga)
+			latest = &saga
+		}
+	}
+	if latest == nil {
+		return nil, domain.ErrSagaNotFound
+	}
+	return latest, nil
+}
+
+func (r *memorySagaRepository) Update(ctx context.Context, saga *domain.Saga) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.sagas[saga.ID]
+	if !ok {
+		return domain.ErrSagaNotFound
+	}
+	if stored.Version != saga.Version {
+		return domain.ErrSagaConflict
+	}
+
+	saga.Version++
+	saga.UpdatedAt = time.Now()
+	r.sagas[saga.ID] = copySaga(*saga)
+
+	return nil
+}
+
+func (r *memorySagaRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Saga, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sagas := []*domain.Saga{}
+	for _, saga := range r.sagas {
+		if saga.Status.IsFinished() || saga.NextAttemptAt.After(now) {
+			continue
+		}
+		saga = copySaga(saga)
+		sagas = append(sagas, &saga)
+	}
+
+	// Longest due first, matching the MongoDB backend
+	sort.Slice(sagas, func(i, j int) bool {
+		return sagas[i].NextAttemptAt.Before(sagas[j].NextAttemptAt)
+	})
+	if len(sagas) > limit {
+		sagas = sagas[:limit]
+	}
+
+	return sagas, nil
+}
+
+// copySaga detaches the steps and order of a saga so callers cannot mutate
+// stored state.
+func copySaga(saga domain.Saga) domain.Saga {
+	saga.Steps = append([]domain.SagaStep(nil), saga.Steps...)
+	if saga.Order != nil {
+		order := copyOrder(*saga.Order)
+		saga.Order = &order
+	}
+	return saga
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"order-service/internal/domain"
+)
+
+type sagaRepository struct {
+	collection *mongo.Collection
+}
+
+type sagaStepDocument struct {
+	Name      string    `bson:"name"`
+	Status    string    `bson:"status"`
+	Attempts  int       `bson:"attempts"`
+	Error     string    `bson:"error,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+type sagaDocument struct {
+	ID            string             `bson:"id"`
+	Type          string             `bson:"type"`
+	OrderID       string             `bson:"order_id"`
+	UserID        string             `bson:"user_id"`
+	Status        string             `bson:"status"`
+	Steps         []sagaStepDocument `bson:"steps"`
+	Order         *orderDocument     `bson:"order,omitempty"`
+	PaymentMethod string             `bson:"payment_method,omitempty"`
+	Error         string             `bson:"error,omitempty"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at"`
+	Version       int64              `bson:"version"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at"`
+}
+
+func NewMongoSagaRepository(db *mongo.Database) SagaRepository {
+	return &sagaRepository{
+		collection: db.Collection("sagas"),
+	}
+}
+
+func (r *sagaRepository) Create(ctx context.Context, saga *domain.Saga) error {
+	now := time.Now()
+	saga.ID = primitive.NewObjectID().Hex()
+	saga.Version = 1
+	saga.CreatedAt = now
+	saga.UpdatedAt = now
+
+	_, err := r.collection.InsertOne(ctx, toSagaDocument(saga))
+	return err
+}
+
+// FindByOrderID fetches the newest saga of an order.
+func (r *sagaRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.Saga, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var doc sagaDocument
+	err := r.collection.FindOne(ctx, bson.M{"order_id": orderID}, opts).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrSagaNotFound
+		}
+		return nil, err
+	}
+	return doc.toDomain(), nil
+}
+
+// Update replaces the state of a saga as long as nobody else changed it
+// since it was read.
+func (r *sagaRepository) Update(ctx context.Context, saga *domain.Saga) error {
+	updated := *saga
+	updated.Version++
+	updated.UpdatedAt = time.Now()
+
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"id": saga.ID, "version": saga.Version}, toSagaDocument(&updated))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		count, err := r.collection.CountDocuments(ctx, bson.M{"id": saga.ID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return domain.ErrSagaNotFound
+		}
+		return domain.ErrSagaConflict
+	}
+
+	saga.Version = updated.Version
+	saga.UpdatedAt = updated.UpdatedAt
+	return nil
+}
+
+// ListDue fetches unfinished sagas whose next attempt is due, longest due
+// first.
+func (r *sagaRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Saga, error) {
+	filter := bson.M{
+		"status":          bson.M{"$in": bson.A{string(domain.SagaRunning), string(domain.SagaCompensating)}},
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sagas := []*domain.Saga{}
+	for cursor.Next(ctx) {
+		var doc sagaDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		sagas = append(sagas, doc.toDomain())
+	}
+	return sagas, cursor.Err()
+}
+
+func toSagaDocument(saga *domain.Saga) sagaDocument {
+	steps := make([]sagaStepDocument, len(saga.Steps))
+	for i, step := range saga.Steps {
+		steps[i] = sagaStepDocument{
+			Name:      step.Name,
+			Status:    string(step.Status),
+			Attempts:  step.Attempts,
+			Error:     step.Error,
+			UpdatedAt: step.UpdatedAt,
+		}
+	}
+
+	var order *orderDocument
+	if saga.Order != nil {
+		doc := toOrderDocument(saga.Order)
+		order = &doc
+	}
+
+	return sagaDocument{
+		ID:            saga.ID,
+		Type:          string(saga.Type),
+		OrderID:       saga.OrderID,
+		UserID:        saga.UserID,
+		Status:        string(saga.Status),
+		Steps:         steps,
+		Order:         order,
+		PaymentMethod: saga.PaymentMethod,
+		Error:         saga.Error,
+		NextAttemptAt: saga.NextAttemptAt,
+		Version:       saga.Version,
+		CreatedAt:     saga.CreatedAt,
+		UpdatedAt:     saga.UpdatedAt,
+	}
+}
+
+func (d *sagaDocument) toDomain() *domain.Saga {
+	steps := make([]domain.SagaStep, len(d.Steps))
+	for i, step := range d.Steps {
+		steps[i] = domain.SagaStep{
+			Name:      step.Name,
+			Status:    domain.SagaStepStatus(step.Status),
+			Attempts:  step.Attempts,
+			Error:     step.Error,
+			UpdatedAt: step.UpdatedAt,
+		}
+	}
+
+	var order *domain.Order
+	if d.Order != nil {
+		order = d.Order.toDomain()
+	}
+
+	return &domain.Saga{
+		ID:            d.ID,
+		Type:          domain.SagaType(d.Type),
+		OrderID:       d.OrderID,
+		UserID:        d.UserID,
+		Status:        domain.SagaStatus(d.Status),
+		Steps:         steps,
+		Order:         order,
+		PaymentMethod: d.PaymentMethod,
+		Error:         d.Error,
+		NextAttemptAt: d.NextAttemptAt,
+		Version:       d.Version,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"order-service/internal/domain"
+)
+
+// toStatusError maps domain errors onto gRPC status codes. Errors that
+// already carry a status, such as those relayed from inventory-service,
+// pass through unchanged.
+func toStatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrOrderNotFound),
+		errors.Is(err, domain.ErrReturnNotFound),
+		errors.Is(err, domain.ErrSagaNotFound),
+		errors.Is(err, domain.ErrProductUnavailable):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidOrder),
+		errors.Is(err, domain.ErrInvalidStatus),
+		errors.Is(err, domain.ErrInvalidReturn):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrInvalidTransition),
+		errors.Is(err, domain.ErrPaymentDeclined),
+		errors.Is(err, domain.ErrNotReturnable),
+		errors.Is(err, domain.ErrReturnTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrPaymentFailed):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, domain.ErrOrderExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrForbidden),
+		errors.Is(err, domain.ErrStaffOnly):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrStatusConflict),
+		errors.Is(err, domain.ErrVersionConflict),
+		errors.Is(err, domain.ErrReturnConflict),
+		errors.Is(err, domain.ErrSagaConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, domain.ErrSagaPending):
+		// The work may still finish, so retrying the call is not safe
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
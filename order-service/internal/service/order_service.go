@@ -5,18 +5,24 @@ import (
 	"time"
 
 	"github.com/yourusername/ecommerce/protos/order"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"order-service/internal/auth"
 	"order-service/internal/domain"
 	"order-service/internal/usecase"
 )
 
 type OrderServer struct {
 	order.UnimplementedOrderServiceServer
-	orderUsecase usecase.OrderUsecase
+	orderUsecase  usecase.OrderUsecase
+	returnUsecase usecase.ReturnUsecase
 }
 
-func NewOrderServer(orderUsecase usecase.OrderUsecase) *OrderServer {
+func NewOrderServer(orderUsecase usecase.OrderUsecase, returnUsecase usecase.ReturnUsecase) *OrderServer {
 	return &OrderServer{
-		orderUsecase: orderUsecase,
+		orderUsecase:  orderUsecase,
+		returnUsecase: returnUsecase,
 	}
 }
 
@@ -26,21 +32,39 @@ func (s *OrderServer) CreateOrder(ctx context.Context, req *order.CreateOrderReq
 	orderItems := make([]domain.OrderItem, len(req.Items))
 	for i, item := range req.Items {
 		orderItems[i] = domain.OrderItem{
-			ProductID: item.ProductId,
-			Quantity:  int(item.Quantity),
-			Price:     item.Price,
+			ProductID:  item.ProductId,
+			VariantSKU: item.VariantSku,
+			Quantity:   int(item.Quantity),
+		}
+	}
+
+	// Customers always order for themselves; admins may order on behalf of
+	// another user
+	userID := req.UserId
+	if identity, ok := auth.FromContext(ctx); ok {
+		if userID == "" {
+			userID = identity.UserID
+		}
+		if userID != identity.UserID && !identity.IsAdmin() {
+			return nil, status.Error(codes.PermissionDenied, "cannot place orders for another user")
 		}
 	}
 
 	newOrder := &domain.Order{
 		ID:     generateID(), // Implement your ID generation
-		UserID: req.UserId,
+		UserID: userID,
 		Items:  orderItems,
 	}
+	if req.ShipTo != nil {
+		newOrder.ShipTo = &domain.GeoPoint{
+			Latitude:  req.ShipTo.Latitude,
+			Longitude: req.ShipTo.Longitude,
+		}
+	}
 
-	createdOrder, err := s.orderUsecase.CreateOrder(ctx, newOrder)
+	createdOrder, err := s.orderUsecase.CreateOrder(ctx, newOrder, req.PaymentMethod)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &order.OrderResponse{
@@ -53,7 +77,7 @@ func (s *OrderServer) CreateOrder(ctx context.Context, req *order.CreateOrderReq
 func (s *OrderServer) GetOrderByID(ctx context.Context, req *order.GetOrderRequest) (*order.OrderResponse, error) {
 	foundOrder, err := s.orderUsecase.GetOrder(ctx, req.Id)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &order.OrderResponse{
@@ -64,9 +88,9 @@ func (s *OrderServer) GetOrderByID(ctx context.Context, req *order.GetOrderReque
 // UpdateOrderStatus changes an order's status.
 // Corresponds to: rpc UpdateOrderStatus(UpdateOrderStatusRequest) returns (OrderResponse)
 func (s *OrderServer) UpdateOrderStatus(ctx context.Context, req *order.UpdateOrderStatusRequest) (*order.OrderResponse, error) {
-	updatedOrder, err := s.orderUsecase.UpdateOrderStatus(ctx, req.Id, domain.OrderStatus(req.Status))
+	updatedOrder, err := s.orderUsecase.UpdateOrderStatus(ctx, req.Id, domain.OrderStatus(req.Status), req.Reason, req.ExpectedVersion)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return &order.OrderResponse{
@@ -74,43 +98,177 @@ func (s *OrderServer) UpdateOrderStatus(ctx context.Context, req *order.UpdateOr
 	}, nil
 }
 
-// ListUserOrders returns all orders for a user with pagination.
+// ListUserOrders returns a user's orders a page at a time.
 // Corresponds to: rpc ListUserOrders(ListOrdersRequest) returns (ListOrdersResponse)
 func (s *OrderServer) ListUserOrders(ctx context.Context, req *order.ListOrdersRequest) (*order.ListOrdersResponse, error) {
-	orders, total, err := s.orderUsecase.ListUserOrders(ctx, req.UserId, int(req.Page), int(req.Limit))
+	after, err := decodePageToken(req.PageToken)
 	if err != nil {
 		return nil, err
 	}
 
+	limit := int(req.Limit)
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	orders, total, next, err := s.orderUsecase.ListUserOrders(ctx, req.UserId, after, limit)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
 	protoOrders := make([]*order.Order, len(orders))
 	for i, o := range orders {
 		protoOrders[i] = s.domainToProto(o)
 	}
 
 	return &order.ListOrdersResponse{
-		Orders: protoOrders,
-		Total:  int32(total),
+		Orders:        protoOrders,
+		Total:         int32(total),
+		Limit:         int32(limit),
+		NextPageToken: encodePageToken(next),
+	}, nil
+}
+
+// CancelOrder cancels an order, returning its stock and refunding it if
+// it was paid.
+// Corresponds to: rpc CancelOrder(CancelOrderRequest) returns (OrderResponse)
+func (s *OrderServer) CancelOrder(ctx context.Context, req *order.CancelOrderRequest) (*order.OrderResponse, error) {
+	cancelledOrder, err := s.orderUsecase.CancelOrder(ctx, req.Id, req.Reason)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &order.OrderResponse{
+		Order: s.domainToProto(cancelledOrder),
+	}, nil
+}
+
+// PayOrder captures payment for a pending order and marks it paid.
+// Corresponds to: rpc PayOrder(PayOrderRequest) returns (OrderResponse)
+func (s *OrderServer) PayOrder(ctx context.Context, req *order.PayOrderRequest) (*order.OrderResponse, error) {
+	paidOrder, err := s.orderUsecase.PayOrder(ctx, req.OrderId, req.PaymentMethod)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &order.OrderResponse{
+		Order: s.domainToProto(paidOrder),
 	}, nil
 }
 
+// ListOrderPayments returns the payment attempts made for an order.
+// Corresponds to: rpc ListOrderPayments(ListPaymentsRequest) returns (ListPaymentsResponse)
+func (s *OrderServer) ListOrderPayments(ctx context.Context, req *order.ListPaymentsRequest) (*order.ListPaymentsResponse, error) {
+	attempts, err := s.orderUsecase.ListPayments(ctx, req.OrderId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoAttempts := make([]*order.PaymentAttempt, len(attempts))
+	for i, attempt := range attempts {
+		protoAttempts[i] = &order.PaymentAttempt{
+			Id:        attempt.ID,
+			OrderId:   attempt.OrderID,
+			Operation: string(attempt.Operation),
+			Amount:    attempt.Amount,
+			Reference: attempt.Reference,
+			ReturnId:  attempt.ReturnID,
+			Succeeded: attempt.Succeeded,
+			Error:     attempt.Error,
+			CreatedAt: attempt.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	return &order.ListPaymentsResponse{Attempts: protoAttempts}, nil
+}
+
+// GetOrderSaga returns where the saga that placed an order stands.
+// Corresponds to: rpc GetOrderSaga(GetOrderSagaRequest) returns (SagaResponse)
+func (s *OrderServer) GetOrderSaga(ctx context.Context, req *order.GetOrderSagaRequest) (*order.SagaResponse, error) {
+	saga, err := s.orderUsecase.GetOrderSaga(ctx, req.OrderId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	steps := make([]*order.SagaStep, len(saga.Steps))
+	for i, step := range saga.Steps {
+		steps[i] = &order.SagaStep{
+			Name:     step.Name,
+			Status:   string(step.Status),
+			Attempts: int32(step.Attempts),
+			Error:    step.Error,
+		}
+		if !step.UpdatedAt.IsZero() {
+			steps[i].UpdatedAt = step.UpdatedAt.Format(time.RFC3339)
+		}
+	}
+
+	protoSaga := &order.Saga{
+		Id:        saga.ID,
+		Type:      string(saga.Type),
+		OrderId:   saga.OrderID,
+		Status:    string(saga.Status),
+		Steps:     steps,
+		Error:     saga.Error,
+		CreatedAt: saga.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: saga.UpdatedAt.Format(time.RFC3339),
+	}
+	if !saga.Status.IsFinished() {
+		protoSaga.NextAttemptAt = saga.NextAttemptAt.Format(time.RFC3339)
+	}
+	return &order.SagaResponse{Saga: protoSaga}, nil
+}
+
 // Helper to convert domain.Order to proto.Order
-func (s *OrderServer) domainToProto(order *domain.Order) *order.Order {
-	items := make([]*order.OrderItem, len(order.Items))
-	for i, item := range order.Items {
+func (s *OrderServer) domainToProto(o *domain.Order) *order.Order {
+	items := make([]*order.OrderItem, len(o.Items))
+	for i, item := range o.Items {
 		items[i] = &order.OrderItem{
-			ProductId: item.ProductID,
-			Quantity:  int32(item.Quantity),
-			Price:     item.Price,
+			ProductId:   item.ProductID,
+			VariantSku:  item.VariantSKU,
+			ProductName: item.ProductName,
+			Quantity:    int32(item.Quantity),
+			Price:       item.Price,
+		}
+		if item.AvailableAt != nil {
+			items[i].AvailableAt = item.AvailableAt.Format(time.RFC3339)
+		}
+	}
+
+	history := make([]*order.StatusChange, len(o.StatusHistory))
+	for i, change := range o.StatusHistory {
+		history[i] = &order.StatusChange{
+			From:      string(change.From),
+			To:        string(change.To),
+			ChangedBy: change.ChangedBy,
+			Reason:    change.Reason,
+			ChangedAt: change.ChangedAt.Format(time.RFC3339),
+		}
+	}
+
+	var shipTo *order.GeoPoint
+	if o.ShipTo != nil {
+		shipTo = &order.GeoPoint{
+			Latitude:  o.ShipTo.Latitude,
+			Longitude: o.ShipTo.Longitude,
 		}
 	}
 
 	return &order.Order{
-		Id:        order.ID,
-		UserId:    order.UserID,
-		Items:     items,
-		Total:     order.Total,
-		Status:    string(order.Status),
-		CreatedAt: order.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: order.UpdatedAt.Format(time.RFC3339),
+		Id:            o.ID,
+		UserId:        o.UserID,
+		Items:         items,
+		Total:         o.Total,
+		Status:        string(o.Status),
+		StatusHistory: history,
+		ShipTo:        shipTo,
+		Version:       o.Version,
+		CreatedAt:     o.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     o.UpdatedAt.Format(time.RFC3339),
 	}
 }
+
+// Helper function to generate IDs
+func generateID() string {
+	return primitive.NewObjectID().Hex()
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"order-service/internal/domain"
+)
+
+// pageToken is the wire form of a domain.PageCursor. Clients treat the
+// encoded token as opaque.
+// inventory-service has its own copy of this file, as the two are separate modules;
+// keep them in step.
+type pageToken struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"i"`
+}
+
+// encodePageToken returns "" for a nil cursor, which tells the client it
+// has reached the last page.
+func encodePageToken(cursor *domain.PageCursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	data, _ := json.Marshal(pageToken{CreatedAt: cursor.CreatedAt, ID: cursor.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken returns nil for an empty token, meaning the first page.
+// Tokens this service did not issue fail with an InvalidArgument status:
+// every token it issues names an item and its creation time.
+func decodePageToken(token string) (*domain.PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	invalid := status.Error(codes.InvalidArgument, "invalid page token")
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+	var decoded pageToken
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.ID == "" || decoded.CreatedAt.IsZero() {
+		return nil, invalid
+	}
+
+	return &domain.PageCursor{CreatedAt: decoded.CreatedAt, ID: decoded.ID}, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"order-service/internal/domain"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	cursor := &domain.PageCursor{CreatedAt: time.Date(2026, 3, 4, 5, 6, 7, 891011121, time.UTC), ID: "6601f0c2a1b2c3d4e5f60718"}
+
+	token := encodePageToken(cursor)
+	if token == "" {
+		t.Fatal("encodePageToken returned an empty token for a cursor")
+	}
+	got, err := decodePageToken(token)
+	if err != nil {
+		t.Fatalf("decodePageToken: %v", err)
+	}
+	if got.ID != cursor.ID || !got.CreatedAt.Equal(cursor.CreatedAt) {
+		t.Fatalf("decoded %+v, want %+v", got, cursor)
+	}
+
+	if token := encodePageToken(nil); token != "" {
+		t.Fatalf("encodePageToken(nil) = %q, want the empty token", token)
+	}
+	if got, err := decodePageToken(""); got != nil || err != nil {
+		t.Fatalf("decodePageToken(\"\") = %+v, %v, want the first page", got, err)
+	}
+}
+
+func TestDecodePageTokenRejectsBadTokens(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "not base64", token: "not a token!"},
+		{name: "not JSON", token: encode("page 2")},
+		{name: "empty object", token: encode(`{}`)},
+		{name: "no ID", token: encode(`{"t":"2026-03-04T05:06:07Z"}`)},
+		{name: "empty ID", token: encode(`{"t":"2026-03-04T05:06:07Z","i":""}`)},
+		{name: "no creation time", token: encode(`{"i":"x"}`)},
+		{name: "zero creation time", token: encode(`{"t":"0001-01-01T00:00:00Z","i":"x"}`)},
+		{name: "malformed creation time", token: encode(`{"t":"yesterday","i":"x"}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := decodePageToken(tt.token)
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Fatalf("decodePageToken returned %+v, %v, want %v", cursor, err, codes.InvalidArgument)
+			}
+		})
+	}
+}
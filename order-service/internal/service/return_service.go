@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/ecommerce/protos/order"
+	"order-service/internal/domain"
+)
+
+// RequestReturn opens a return for items of a delivered order.
+// Corresponds to: rpc RequestReturn(RequestReturnRequest) returns (ReturnResponse)
+func (s *OrderServer) RequestReturn(ctx context.Context, req *order.RequestReturnRequest) (*order.ReturnResponse, error) {
+	ret, err := s.returnUsecase.RequestReturn(ctx, req.OrderId, protoToReturnItems(req.Items), req.Reason)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &order.ReturnResponse{Return: returnToProto(ret)}, nil
+}
+
+// GetReturn returns a return by its ID.
+// Corresponds to: rpc GetReturn(GetReturnRequest) returns (ReturnResponse)
+func (s *OrderServer) GetReturn(ctx context.Context, req *order.GetReturnRequest) (*order.ReturnResponse, error) {
+	ret, err := s.returnUsecase.GetReturn(ctx, req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &order.ReturnResponse{Return: returnToProto(ret)}, nil
+}
+
+// ListOrderReturns returns the returns of an order.
+// Corresponds to: rpc ListOrderReturns(ListReturnsRequest) returns (ListReturnsResponse)
+func (s *OrderServer) ListOrderReturns(ctx context.Context, req *order.ListReturnsRequest) (*order.ListReturnsResponse, error) {
+	returns, err := s.returnUsecase.ListOrderReturns(ctx, req.OrderId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	protoReturns := make([]*order.Return, len(returns))
+	for i, ret := range returns {
+		protoReturns[i] = returnToProto(ret)
+	}
+	return &order.ListReturnsResponse{Returns: protoReturns}, nil
+}
+
+// ApproveReturn approves a requested return.
+// Corresponds to: rpc ApproveReturn(ReviewReturnRequest) returns (ReturnResponse)
+func (s *OrderServer) ApproveReturn(ctx context.Context, req *order.ReviewReturnRequest) (*order.ReturnResponse, error) {
+	ret, err := s.returnUsecase.ApproveReturn(ctx, req.Id, req.Note)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &order.ReturnResponse{Return: returnToProto(ret)}, nil
+}
+
+// RejectReturn rejects a requested return.
+// Corresponds to: rpc RejectReturn(ReviewReturnRequest) returns (ReturnResponse)
+func (s *OrderServer) RejectReturn(ctx context.Context, req *order.ReviewReturnRequest) (*order.ReturnResponse, error) {
+	ret, err := s.returnUsecase.RejectReturn(ctx, req.Id, req.Note)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &order.ReturnResponse{Return: returnToProto(ret)}, nil
+}
+
+// ReceiveReturn records the returned items and settles the return.
+// Corresponds to: rpc ReceiveReturn(ReceiveReturnRequest) returns (ReturnResponse)
+func (s *OrderServer) ReceiveReturn(ctx context.Context, req *order.ReceiveReturnRequest) (*order.ReturnResponse, error) {
+	ret, err := s.returnUsecase.ReceiveReturn(ctx, req.Id, protoToReturnItems(req.Items))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &order.ReturnResponse{Return: returnToProto(ret)}, nil
+}
+
+func protoToReturnItems(items []*order.ReturnItem) []domain.ReturnItem {
+	converted := make([]domain.ReturnItem, len(items))
+	for i, item := range items {
+		converted[i] = domain.ReturnItem{
+			ProductID:  item.ProductId,
+			VariantSKU: item.VariantSku,
+			Quantity:   int(item.Quantity),
+			Condition:  domain.ItemCondition(item.Condition),
+		}
+	}
+	return converted
+}
+
+func returnToProto(ret *domain.Return) *order.Return {
+	items := make([]*order.ReturnItem, len(ret.Items))
+	for i, item := range ret.Items {
+		items[i] = &order.ReturnItem{
+			ProductId:  item.ProductID,
+			VariantSku: item.VariantSKU,
+			Quantity:   int32(item.Quantity),
+			Price:      item.Price,
+			Condition:  string(item.Condition),
+		}
+	}
+
+	return &order.Return{
+		Id:           ret.ID,
+		OrderId:      ret.OrderID,
+		UserId:       ret.UserID,
+		Items:        items,
+		Reason:       ret.Reason,
+		Status:       string(ret.Status),
+		Note:         ret.Note,
+		RefundAmount: ret.RefundAmount,
+		CreatedAt:    ret.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    ret.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"order-service/internal/domain"
+	"order-service/internal/repository"
+)
+
+// PayOrder charges a pending order to a payment method: it authorizes the
+// order total, captures it and only then marks the order paid, which
+// commits its stock reservation. Every call to the payment provider is
+// recorded against the order. An authorization whose capture fails is
+// voided, and a capture whose order cannot be marked paid is refunded, so
+// a failed payment leaves no money held. Paying a paid order again is a
+// no-op.
+// Corresponds to: rpc PayOrder(PayOrderRequest) returns (OrderResponse)
+func (uc *orderUsecase) PayOrder(ctx context.Context, id string, method string) (*domain.Order, error) {
+	if method == "" {
+		return nil, fmt.Errorf("%w: payment method is required", domain.ErrInvalidOrder)
+	}
+
+	order, err := uc.findOwnedOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == domain.OrderStatusPaid {
+		return order, nil
+	}
+	if order.Status != domain.OrderStatusPending {
+		return nil, fmt.Errorf("%w: only pending orders can be paid, order is %s", domain.ErrInvalidTransition, order.Status)
+	}
+
+	return uc.pay(ctx, order, method)
+}
+
+// pay captures the total of a pending order and marks it paid.
+func (uc *orderUsecase) pay(ctx context.Context, order *domain.Order, method string) (*domain.Order, error) {
+	attempts, err := uc.payer.attempts.ListByOrder(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A payment captured by an earlier call that failed before marking the
+	// order paid is not taken twice
+	reference, outstanding := domain.CapturedPayment(attempts)
+	if outstanding <= 0 {
+		reference, err = uc.capture(ctx, order, method, attempts)
+		if err != nil {
+			return nil, err
+		}
+		outstanding = order.Total
+	}
+
+	paid, err := uc.transition(ctx, order, domain.OrderStatusPaid, "payment captured")
+	if err != nil {
+		// A concurrent call may have marked the order paid with this very
+		// payment, which must then be kept
+		if errors.Is(err, domain.ErrStatusConflict) {
+			if current, findErr := uc.repo.FindByID(ctx, order.ID); findErr == nil && current.Status == domain.OrderStatusPaid {
+				return current, nil
+			}
+		}
+		if refundErr := uc.payer.refund(ctx, order.ID, "", reference, outstanding); refundErr != nil {
+			log.Printf("failed to refund payment %s of order %s: %v", reference, order.ID, refundErr)
+		}
+		return nil, err
+	}
+	return paid, nil
+}
+
+// ListPayments returns every payment attempt made for an order, oldest
+// first.
+// Corresponds to: rpc ListOrderPayments(ListPaymentsRequest) returns (ListPaymentsResponse)
+func (uc *orderUsecase) ListPayments(ctx context.Context, id string) ([]*domain.PaymentAttempt, error) {
+	if _, err := uc.findOwnedOrder(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.payer.attempts.ListByOrder(ctx, id)
+}
+
+// capture authorizes and captures the total of an order and returns the
+// provider's reference of the payment. attempts are the order's earlier
+// payment attempts; each authorization gets its own idempotency key.
+func (uc *orderUsecase) capture(ctx context.Context, order *domain.Order, method string, attempts []*domain.PaymentAttempt) (string, error) {
+	authorizations := 0
+	for _, attempt := range attempts {
+		if attempt.Operation == domain.PaymentAuthorize {
+			authorizations++
+		}
+	}
+
+	reference, err := uc.payer.provider.Authorize(ctx, PaymentAuthorization{
+		OrderID:        order.ID,
+		Amount:         order.Total,
+		Method:         method,
+		IdempotencyKey: fmt.Sprintf("%s-%d", order.ID, authorizations+1),
+	})
+	uc.payer.record(ctx, &domain.PaymentAttempt{OrderID: order.ID, Operation: domain.PaymentAuthorize, Amount: order.Total, Reference: reference}, err)
+	if err != nil {
+		return "", paymentError(err)
+	}
+
+	err = uc.payer.provider.Capture(ctx, reference, order.Total)
+	uc.payer.record(ctx, &domain.PaymentAttempt{OrderID: order.ID, Operation: domain.PaymentCapture, Amount: order.Total, Reference: reference}, err)
+	if err != nil {
+		voidErr := uc.payer.provider.Void(ctx, reference)
+		uc.payer.record(ctx, &domain.PaymentAttempt{OrderID: order.ID, Operation: domain.PaymentVoid, Amount: order.Total, Reference: reference}, voidErr)
+		if voidErr != nil {
+			log.Printf("failed to void payment %s of order %s: %v", reference, order.ID, voidErr)
+		}
+		return "", paymentError(err)
+	}
+	return reference, nil
+}
+
+// payer calls the payment provider and records every call against the
+// order it was made for.
+type payer struct {
+	attempts repository.PaymentRepository
+	provider PaymentProvider
+}
+
+// refund gives back a captured amount; returnID names the return it is
+// for, if any. A failed refund is recorded too, so the attempt history
+// shows the money still outstanding.
+func (p payer) refund(ctx context.Context, orderID, returnID, reference string, amount float64) error {
+	err := p.provider.Refund(ctx, reference, amount)
+	p.record(ctx, &domain.PaymentAttempt{
+		OrderID:   orderID,
+		Operation: domain.PaymentRefund,
+		Amount:    amount,
+		Reference: reference,
+		ReturnID:  returnID,
+	}, err)
+	if err != nil {
+		return paymentError(err)
+	}
+	return nil
+}
+
+// record stores the outcome of a call to the payment provider. Failing to
+// store it must not undo the call, so errors are only logged.
+func (p payer) record(ctx context.Context, attempt *domain.PaymentAttempt, callErr error) {
+	attempt.Succeeded = callErr == nil
+	if callErr != nil {
+		attempt.Error = callErr.Error()
+	}
+	if err := p.attempts.Record(ctx, attempt); err != nil {
+		log.Printf("failed to record %s of order %s: %v", attempt.Operation, attempt.OrderID, err)
+	}
+}
+
+// paymentError maps a payment provider error onto the domain: declines
+// keep their meaning, everything else is a provider failure.
+func paymentError(err error) error {
+	if errors.Is(err, domain.ErrPaymentDeclined) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", domain.ErrPaymentFailed, err)
+}
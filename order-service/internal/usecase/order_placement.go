@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"order-service/internal/domain"
+)
+
+// placeOrderSteps defines the saga that places an order. Stock is held
+// before the order is stored so it can never be placed for more units
+// than inventory has; the order is charged last, and only when the
+// customer gave a payment method and its stock is there. Backordered
+// orders are paid with PayOrder once their stock arrives.
+func (uc *orderUsecase) placeOrderSteps() []sagaStep {
+	return []sagaStep{
+		{name: "reserve_stock", action: uc.reserveStock, compensate: uc.releaseStock},
+		{name: "create_order", action: uc.storeOrder, compensate: uc.withdrawOrder},
+		{name: "charge_payment", skip: skipCharge, action: uc.chargeOrder},
+	}
+}
+
+// GetOrderSaga returns the saga that placed an order.
+// Corresponds to: rpc GetOrderSaga(GetOrderSagaRequest) returns (SagaResponse)
+func (uc *orderUsecase) GetOrderSaga(ctx context.Context, orderID string) (*domain.Saga, error) {
+	saga, err := uc.sagas.sagas.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !mayAccess(ctx, saga.UserID) {
+		return nil, domain.ErrSagaNotFound
+	}
+	return saga, nil
+}
+
+// ResumeSagas carries on with sagas that wait for a retry or were
+// interrupted, and returns how many of them finished.
+func (uc *orderUsecase) ResumeSagas(ctx context.Context) (int, error) {
+	return uc.sagas.resume(ctx)
+}
+
+// reserveStock holds the stock of every item of the order. Items of
+// backorderable products that are out of stock put the whole order in
+// line for the next receipt instead. Inventory returns the existing hold
+// when the order already has one.
+func (uc *orderUsecase) reserveStock(ctx context.Context, saga *domain.Saga) error {
+	order := saga.Order
+	items := make([]*inventory.ReservationItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = &inventory.ReservationItem{
+			ProductId:  item.ProductID,
+			VariantSku: item.VariantSKU,
+			Quantity:   int32(item.Quantity),
+		}
+	}
+	req := &inventory.ReserveStockRequest{
+		OrderId:        order.ID,
+		Items:          items,
+		AllowBackorder: true,
+	}
+	if order.ShipTo != nil {
+		req.ShipTo = &inventory.GeoPoint{
+			Latitude:  order.ShipTo.Latitude,
+			Longitude: order.ShipTo.Longitude,
+		}
+	}
+	res, err := uc.inventory.ReserveStock(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	order.Status = domain.OrderStatusPending
+	reason := "order placed"
+	if res.Reservation.GetStatus() == reservationBackordered {
+		order.Status = domain.OrderStatusBackordered
+		reason = "order placed, awaiting stock"
+	}
+	order.StatusHistory = []domain.StatusChange{{
+		To:        order.Status,
+		ChangedBy: order.UserID,
+		Reason:    reason,
+		ChangedAt: time.Now(),
+	}}
+	return nil
+}
+
+// releaseStock gives back the stock held for the order. Inventory returns
+// it once, however often the reservation is released.
+func (uc *orderUsecase) releaseStock(ctx context.Context, saga *domain.Saga) error {
+	_, err := uc.inventory.ReleaseReservation(ctx, &inventory.ReservationRequest{OrderId: saga.OrderID})
+	return err
+}
+
+// storeOrder saves the order; one saved by an earlier run is kept.
+func (uc *orderUsecase) storeOrder(ctx context.Context, saga *domain.Saga) error {
+	if err := uc.repo.Create(ctx, saga.Order); err != nil && !errors.Is(err, domain.ErrOrderExists) {
+		return err
+	}
+	return nil
+}
+
+// withdrawOrder cancels the stored order, refunding it if it was paid.
+func (uc *orderUsecase) withdrawOrder(ctx context.Context, saga *domain.Saga) error {
+	order, err := uc.repo.FindByID(ctx, saga.OrderID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return nil
+		}
+		return err
+	}
+	if order.Status == domain.OrderStatusCancelled {
+		return nil
+	}
+
+	_, err = uc.cancel(ctx, order, "order could not be placed: "+saga.Error)
+	return err
+}
+
+// chargeOrder pays the order with the payment method it was placed with.
+func (uc *orderUsecase) chargeOrder(ctx context.Context, saga *domain.Saga) error {
+	order, err := uc.repo.FindByID(ctx, saga.OrderID)
+	if err != nil {
+		return err
+	}
+
+	switch order.Status {
+	case domain.OrderStatusPaid:
+		return nil
+	case domain.OrderStatusPending:
+		_, err = uc.pay(ctx, order, saga.PaymentMethod)
+		return err
+	default:
+		return fmt.Errorf("%w: %s orders cannot be paid", domain.ErrInvalidTransition, order.Status)
+	}
+}
+
+// skipCharge reports whether an order is placed without being paid.
+func skipCharge(saga *domain.Saga) bool {
+	return saga.PaymentMethod == "" || saga.Order.Status == domain.OrderStatusBackordered
+}
@@ -3,92 +3,427 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"time"
 
+	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"order-service/internal/auth"
 	"order-service/internal/domain"
 	"order-service/internal/repository"
 )
 
+// Reservation statuses reported by the inventory service
+const (
+	reservationActive      = "active"
+	reservationBackordered = "backordered"
+)
+
+// backorderBatchSize bounds how many backordered orders one
+// PromoteBackorders pass looks at.
+const backorderBatchSize = 100
+
 type OrderUsecase interface {
-	CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
+	CreateOrder(ctx context.Context, order *domain.Order, paymentMethod string) (*domain.Order, error)
 	GetOrder(ctx context.Context, id string) (*domain.Order, error)
-	UpdateOrderStatus(ctx context.Context, id string, status domain.OrderStatus) (*domain.Order, error)
-	ListUserOrders(ctx context.Context, userID string, page, limit int) ([]*domain.Order, int, error)
+	UpdateOrderStatus(ctx context.Context, id string, status domain.OrderStatus, reason string, expectedVersion int64) (*domain.Order, error)
+	ListUserOrders(ctx context.Context, userID string, after *domain.PageCursor, limit int) ([]*domain.Order, int, *domain.PageCursor, error)
+	PromoteBackorders(ctx context.Context) (int, error)
+	CancelOrder(ctx context.Context, id string, reason string) (*domain.Order, error)
+	PayOrder(ctx context.Context, id string, method string) (*domain.Order, error)
+	ListPayments(ctx context.Context, id string) ([]*domain.PaymentAttempt, error)
+	GetOrderSaga(ctx context.Context, orderID string) (*domain.Saga, error)
+	ResumeSagas(ctx context.Context) (int, error)
 }
 
 type orderUsecase struct {
-	repo repository.OrderRepository
+	repo      repository.OrderRepository
+	inventory inventory.InventoryServiceClient
+	payer     payer
+	sagas     *sagaOrchestrator
 }
 
-func NewOrderUsecase(repo repository.OrderRepository) OrderUsecase {
-	return &orderUsecase{repo: repo}
+func NewOrderUsecase(repo repository.OrderRepository, payments repository.PaymentRepository, sagas repository.SagaRepository, inventoryClient inventory.InventoryServiceClient, provider PaymentProvider) OrderUsecase {
+	uc := &orderUsecase{
+		repo:      repo,
+		inventory: inventoryClient,
+		payer:     payer{attempts: payments, provider: provider},
+	}
+	uc.sagas = &sagaOrchestrator{
+		sagas: sagas,
+		definitions: map[domain.SagaType][]sagaStep{
+			domain.SagaPlaceOrder: uc.placeOrderSteps(),
+		},
+	}
+	return uc
 }
 
-// CreateOrder processes order creation.
+// CreateOrder places an order through the place-order saga: it holds
+// the stock, stores the order and, when a payment method is given, charges
+// it. A step that fails for good undoes the ones before it and its error
+// is returned. When a step has to wait for a retry the saga carries on in
+// the background and ErrSagaPending is returned; GetOrderSaga tells how it
+// went.
 // Corresponds to: rpc CreateOrder(CreateOrderRequest) returns (OrderResponse)
-func (uc *orderUsecase) CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+func (uc *orderUsecase) CreateOrder(ctx context.Context, order *domain.Order, paymentMethod string) (*domain.Order, error) {
+	if order.UserID == "" {
+		return nil, fmt.Errorf("%w: user ID is required", domain.ErrInvalidOrder)
+	}
+	if len(order.Items) == 0 {
+		return nil, fmt.Errorf("%w: order must contain at least one item", domain.ErrInvalidOrder)
+	}
+
+	// Price every line from inventory; whatever the client sent is ignored
+	if err := uc.priceItems(ctx, order.Items); err != nil {
+		return nil, err
+	}
+
 	// Calculate total
 	var total float64
 	for _, item := range order.Items {
 		total += item.Price * float64(item.Quantity)
 	}
 	order.Total = total
-	order.Status = domain.OrderStatusPending
 
-	if err := uc.repo.Create(order); err != nil {
+	saga := &domain.Saga{
+		Type:          domain.SagaPlaceOrder,
+		OrderID:       order.ID,
+		UserID:        order.UserID,
+		Order:         order,
+		PaymentMethod: paymentMethod,
+	}
+	if err := uc.sagas.start(ctx, saga); err != nil {
+		if errors.Is(err, domain.ErrSagaPending) {
+			return nil, fmt.Errorf("order %s: %w", order.ID, err)
+		}
 		return nil, err
 	}
-	return order, nil
+	return uc.repo.FindByID(ctx, order.ID)
 }
 
 // GetOrder fetches an order by ID.
 // Corresponds to: rpc GetOrderByID(GetOrderRequest) returns (OrderResponse)
 func (uc *orderUsecase) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
-	order, err := uc.repo.GetOrderByID(id)
-	if err != nil {
-		return nil, err
-	}
-	return order, nil
+	return uc.findOwnedOrder(ctx, id)
 }
 
-// UpdateOrderStatus changes the status of an existing order.
+// UpdateOrderStatus moves an existing order along the status graph and
+// records the change in its history. Only warehouse staff ship and
+// deliver orders. A non-zero expectedVersion makes the update conditional
+// on the order still being at that version.
 // Corresponds to: rpc UpdateOrderStatus(UpdateOrderStatusRequest) returns (OrderResponse)
-func (uc *orderUsecase) UpdateOrderStatus(ctx context.Context, id string, status domain.OrderStatus) (*domain.Order, error) {
-	order, err := uc.repo.GetOrderByID(id)
+func (uc *orderUsecase) UpdateOrderStatus(ctx context.Context, id string, status domain.OrderStatus, reason string, expectedVersion int64) (*domain.Order, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%w: %q", domain.ErrInvalidStatus, status)
+	}
+
+	shipping := status == domain.OrderStatusShipped || status == domain.OrderStatusDelivered
+	if shipping && !isWarehouseStaff(ctx) {
+		return nil, domain.ErrStaffOnly
+	}
+
+	// Staff ship and deliver orders of every customer
+	var order *domain.Order
+	var err error
+	if shipping {
+		order, err = uc.repo.FindByID(ctx, id)
+	} else {
+		order, err = uc.findOwnedOrder(ctx, id)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if expectedVersion != 0 && expectedVersion != order.Version {
+		return nil, domain.ErrVersionConflict
+	}
 
-	// Optional: validate transition rules here
 	if order.Status == status {
 		return order, nil
 	}
+	if !order.Status.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: %s -> %s", domain.ErrInvalidTransition, order.Status, status)
+	}
+	switch status {
+	case domain.OrderStatusPaid:
+		// Money has to change hands first
+		return nil, fmt.Errorf("%w: orders are marked paid by capturing a payment", domain.ErrInvalidTransition)
+	case domain.OrderStatusCancelled:
+		return uc.cancel(ctx, order, reason)
+	}
+
+	return uc.transition(ctx, order, status, reason)
+}
+
+// CancelOrder cancels an order that has not shipped yet: the stock held
+// for it goes back to inventory and, if it was paid, the payment is
+// refunded. The reason is recorded in the status history. Cancelling a
+// cancelled order is a no-op, and a cancellation that failed part way can
+// be retried; stock is only ever returned once.
+// Corresponds to: rpc CancelOrder(CancelOrderRequest) returns (OrderResponse)
+func (uc *orderUsecase) CancelOrder(ctx context.Context, id string, reason string) (*domain.Order, error) {
+	if reason == "" {
+		return nil, fmt.Errorf("%w: cancellation reason is required", domain.ErrInvalidOrder)
+	}
+
+	order, err := uc.findOwnedOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == domain.OrderStatusCancelled {
+		return order, nil
+	}
+	if !order.Status.CanTransitionTo(domain.OrderStatusCancelled) {
+		return nil, fmt.Errorf("%w: %s orders cannot be cancelled", domain.ErrInvalidTransition, order.Status)
+	}
 
-	if err := uc.repo.UpdateOrderStatus(id, string(status)); err != nil {
+	return uc.cancel(ctx, order, reason)
+}
+
+// cancel cancels an order whose status allows it. Every step can be
+// repeated, so the order is only marked cancelled once its stock is back
+// and its payment refunded; until then a retry picks up where a failed
+// attempt stopped.
+func (uc *orderUsecase) cancel(ctx context.Context, order *domain.Order, reason string) (*domain.Order, error) {
+	// Inventory returns the stock of every item once, however often the
+	// reservation is released
+	if _, err := uc.inventory.ReleaseReservation(ctx, &inventory.ReservationRequest{OrderId: order.ID}); err != nil {
+		return nil, err
+	}
+
+	if order.Status == domain.OrderStatusPaid {
+		attempts, err := uc.payer.attempts.ListByOrder(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		if reference, outstanding := domain.CapturedPayment(attempts); outstanding > 0 {
+			if err := uc.payer.refund(ctx, order.ID, "", reference, outstanding); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return uc.transition(ctx, order, domain.OrderStatusCancelled, reason)
+}
+
+// ListUserOrders returns one page of a user's orders and the cursor of the
+// next page, which is nil on the last one.
+// Corresponds to: rpc ListUserOrders(ListOrdersRequest) returns (ListOrdersResponse)
+func (uc *orderUsecase) ListUserOrders(ctx context.Context, userID string, after *domain.PageCursor, limit int) ([]*domain.Order, int, *domain.PageCursor, error) {
+	if identity, ok := auth.FromContext(ctx); ok {
+		if userID == "" {
+			userID = identity.UserID
+		}
+		if userID != identity.UserID && !identity.IsAdmin() {
+			return nil, 0, nil, domain.ErrForbidden
+		}
+	}
+	if userID == "" {
+		return nil, 0, nil, fmt.Errorf("%w: user ID is required", domain.ErrInvalidOrder)
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	// Fetch one extra order to learn whether another page follows
+	orders, total, err := uc.repo.ListByUser(ctx, userID, after, limit+1)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var next *domain.PageCursor
+	if len(orders) > limit {
+		orders = orders[:limit]
+		next = domain.OrderCursor(orders[limit-1])
+	}
+
+	return orders, total, next, nil
+}
+
+// PromoteBackorders moves backordered orders whose stock inventory has
+// since allocated back to pending, oldest first, and returns how many it
+// moved. Orders still waiting are left alone.
+func (uc *orderUsecase) PromoteBackorders(ctx context.Context) (int, error) {
+	orders, err := uc.repo.ListByStatus(ctx, domain.OrderStatusBackordered, backorderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	promoted := 0
+	for _, order := range orders {
+		allocated, err := uc.stockAllocated(ctx, order.ID)
+		if err != nil {
+			log.Printf("failed to check reservation for backordered order %s: %v", order.ID, err)
+			continue
+		}
+		if !allocated {
+			continue
+		}
+
+		change := domain.StatusChange{
+			From:      order.Status,
+			To:        domain.OrderStatusPending,
+			ChangedBy: "system",
+			Reason:    "stock allocated",
+			ChangedAt: time.Now(),
+		}
+		if err := uc.repo.UpdateStatus(ctx, order.ID, order.Version, change); err != nil {
+			// A concurrent change wins; the order is looked at again on
+			// the next pass if it is still backordered
+			if errors.Is(err, domain.ErrStatusConflict) {
+				continue
+			}
+			return promoted, err
+		}
+		promoted++
+	}
+	return promoted, nil
+}
+
+// transition moves an order to status, which the caller has checked is
+// allowed, keeping the stock reservation in step, and records the change
+// in its history. Cancellations go through cancel, which releases the
+// reservation itself.
+func (uc *orderUsecase) transition(ctx context.Context, order *domain.Order, status domain.OrderStatus, reason string) (*domain.Order, error) {
+	switch status {
+	case domain.OrderStatusPending:
+		// Only a backordered order gets here; it moves on once inventory
+		// has allocated its stock
+		allocated, err := uc.stockAllocated(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !allocated {
+			return nil, fmt.Errorf("%w: stock for order %s has not arrived yet", domain.ErrInvalidTransition, order.ID)
+		}
+	case domain.OrderStatusPaid:
+		if _, err := uc.inventory.CommitReservation(ctx, &inventory.ReservationRequest{OrderId: order.ID}); err != nil {
+			return nil, err
+		}
+	}
+
+	change := domain.StatusChange{
+		From:      order.Status,
+		To:        status,
+		ChangedBy: actor(ctx),
+		Reason:    reason,
+		ChangedAt: time.Now(),
+	}
+	if err := uc.repo.UpdateStatus(ctx, order.ID, order.Version, change); err != nil {
 		return nil, err
 	}
 
 	order.Status = status
+	order.StatusHistory = append(order.StatusHistory, change)
+	order.UpdatedAt = change.ChangedAt
+	order.Version++
 	return order, nil
 }
 
-// ListUserOrders returns paginated orders of a specific user.
-// Corresponds to: rpc ListUserOrders(ListOrdersRequest) returns (ListOrdersResponse)
-func (uc *orderUsecase) ListUserOrders(ctx context.Context, userID string, page, limit int) ([]*domain.Order, int, error) {
-	orders, err := uc.repo.ListUserOrders(userID)
+// stockAllocated reports whether inventory holds stock for an order,
+// that is whether its reservation is no longer backordered.
+func (uc *orderUsecase) stockAllocated(ctx context.Context, orderID string) (bool, error) {
+	res, err := uc.inventory.GetReservation(ctx, &inventory.ReservationRequest{OrderId: orderID})
 	if err != nil {
-		return nil, 0, err
+		return false, err
 	}
+	return res.Reservation.GetStatus() == reservationActive, nil
+}
+
+// findOwnedOrder loads an order the caller is allowed to see. Other users'
+// orders are reported as not found so their IDs do not leak. Calls without
+// an identity come from inside the service and are not restricted.
+func (uc *orderUsecase) findOwnedOrder(ctx context.Context, id string) (*domain.Order, error) {
+	return findOwnedOrder(ctx, uc.repo, id)
+}
 
-	total := len(orders)
-	start := (page - 1) * limit
-	if start > total {
-		return []*domain.Order{}, total, nil
+func findOwnedOrder(ctx context.Context, orders repository.OrderRepository, id string) (*domain.Order, error) {
+	order, err := orders.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	end := start + limit
-	if end > total {
-		end = total
+	if !mayAccess(ctx, order.UserID) {
+		return nil, domain.ErrOrderNotFound
 	}
+	return order, nil
+}
 
-	return orders[start:end], total, nil
+// mayAccess reports whether the caller may see what belongs to userID:
+// their own things, or anyone's for admins. Calls without an identity
+// come from inside the service and are not restricted.
+func mayAccess(ctx context.Context, userID string) bool {
+	identity, ok := auth.FromContext(ctx)
+	return !ok || identity.IsAdmin() || identity.UserID == userID
+}
+
+// isWarehouseStaff reports whether the caller handles goods: ships and
+// delivers orders and takes returns back. Calls without an identity come
+// from inside the service and are not restricted.
+func isWarehouseStaff(ctx context.Context) bool {
+	identity, ok := auth.FromContext(ctx)
+	return !ok || identity.IsAdmin() || identity.HasRole(auth.RoleWarehouse)
+}
+
+// actor names who is making a change, for the status history.
+func actor(ctx context.Context) string {
+	if identity, ok := auth.FromContext(ctx); ok {
+		return identity.UserID
+	}
+	return "system"
+}
+
+// priceItems snapshots the authoritative name and price of every item's
+// product onto the item. Items naming a variant take the variant's price
+// when it overrides the product's.
+func (uc *orderUsecase) priceItems(ctx context.Context, items []domain.OrderItem) error {
+	for i := range items {
+		item := &items[i]
+		if item.ProductID == "" {
+			return fmt.Errorf("%w: item %d has no product ID", domain.ErrInvalidOrder, i)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("%w: item %d must have a positive quantity", domain.ErrInvalidOrder, i)
+		}
+
+		res, err := uc.inventory.GetProductByID(ctx, &inventory.GetProductRequest{Id: item.ProductID})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("%w: product %s does not exist", domain.ErrProductUnavailable, item.ProductID)
+			}
+			return err
+		}
+		if res.Product.ArchivedAt != "" {
+			return fmt.Errorf("%w: product %s is no longer sold", domain.ErrProductUnavailable, item.ProductID)
+		}
+
+		item.ProductName = res.Product.Name
+		item.Price = res.Product.Price
+		item.AvailableAt = nil
+		if res.Product.AvailableAt != "" {
+			if availableAt, err := time.Parse(time.RFC3339, res.Product.AvailableAt); err == nil {
+				item.AvailableAt = &availableAt
+			}
+		}
+
+		if item.VariantSKU == "" {
+			continue
+		}
+		variant, err := uc.inventory.GetVariant(ctx, &inventory.GetVariantRequest{Sku: item.VariantSKU})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("%w: variant %s does not exist", domain.ErrProductUnavailable, item.VariantSKU)
+			}
+			return err
+		}
+		if variant.Variant.ProductId != item.ProductID {
+			return fmt.Errorf("%w: variant %s does not belong to product %s", domain.ErrInvalidOrder, item.VariantSKU, item.ProductID)
+		}
+		if variant.Variant.Price > 0 {
+			item.Price = variant.Variant.Price
+		}
+	}
+	return nil
 }
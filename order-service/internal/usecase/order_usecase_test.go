@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"google.golang.org/grpc"
+	"order-service/internal/auth"
+	"order-service/internal/domain"
+	"order-service/internal/repository"
+)
+
+// stubInventory keeps the status of each order's reservation; products
+// cost 10.
+type stubInventory struct {
+	inventory.InventoryServiceClient
+	reservations map[string]string
+}
+
+func (s *stubInventory) GetProductByID(ctx context.Context, in *inventory.GetProductRequest, _ ...grpc.CallOption) (*inventory.ProductResponse, error) {
+	return &inventory.ProductResponse{Product: &inventory.Product{Id: in.Id, Name: in.Id, Price: 10}}, nil
+}
+
+func (s *stubInventory) ReserveStock(ctx context.Context, in *inventory.ReserveStockRequest, _ ...grpc.CallOption) (*inventory.ReservationResponse, error) {
+	s.reservations[in.OrderId] = "active"
+	return &inventory.ReservationResponse{Reservation: &inventory.Reservation{OrderId: in.OrderId, Status: "active"}}, nil
+}
+
+func (s *stubInventory) ReleaseReservation(ctx context.Context, in *inventory.ReservationRequest, _ ...grpc.CallOption) (*inventory.ReservationResponse, error) {
+	s.reservations[in.OrderId] = "released"
+	return &inventory.ReservationResponse{Reservation: &inventory.Reservation{OrderId: in.OrderId, Status: "released"}}, nil
+}
+
+func (s *stubInventory) GetReservation(ctx context.Context, in *inventory.ReservationRequest, _ ...grpc.CallOption) (*inventory.ReservationResponse, error) {
+	return &inventory.ReservationResponse{Reservation: &inventory.Reservation{OrderId: in.OrderId, Status: s.reservations[in.OrderId]}}, nil
+}
+
+func (s *stubInventory) CommitReservation(ctx context.Context, in *inventory.ReservationRequest, _ ...grpc.CallOption) (*inventory.ReservationResponse, error) {
+	s.reservations[in.OrderId] = "committed"
+	return &inventory.ReservationResponse{Reservation: &inventory.Reservation{OrderId: in.OrderId, Status: "committed"}}, nil
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        domain.OrderStatus
+		reservation string
+		to          domain.OrderStatus
+		wantErr     error
+	}{
+		{name: "backordered stock arrived", from: domain.OrderStatusBackordered, reservation: "active", to: domain.OrderStatusPending},
+		{name: "backordered stock not arrived", from: domain.OrderStatusBackordered, reservation: "backordered", to: domain.OrderStatusPending, wantErr: domain.ErrInvalidTransition},
+		{name: "cancel pending", from: domain.OrderStatusPending, reservation: "active", to: domain.OrderStatusCancelled},
+		{name: "mark paid without payment", from: domain.OrderStatusPending, reservation: "active", to: domain.OrderStatusPaid, wantErr: domain.ErrInvalidTransition},
+		{name: "ship paid", from: domain.OrderStatusPaid, reservation: "committed", to: domain.OrderStatusShipped},
+		{name: "deliver shipped", from: domain.OrderStatusShipped, reservation: "committed", to: domain.OrderStatusDelivered},
+		{name: "cancel shipped", from: domain.OrderStatusShipped, reservation: "committed", to: domain.OrderStatusCancelled, wantErr: domain.ErrInvalidTransition},
+		{name: "cancel delivered", from: domain.OrderStatusDelivered, reservation: "committed", to: domain.OrderStatusCancelled, wantErr: domain.ErrInvalidTransition},
+		{name: "reopen cancelled", from: domain.OrderStatusCancelled, reservation: "released", to: domain.OrderStatusPending, wantErr: domain.ErrInvalidTransition},
+		{name: "unknown status", from: domain.OrderStatusPending, reservation: "active", to: "lost", wantErr: domain.ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := auth.NewContext(context.Background(), auth.Identity{UserID: "a1", Roles: []string{auth.RoleAdmin}})
+			repos := repository.NewMemoryRepositories()
+			inv := &stubInventory{reservations: map[string]string{"o1": tt.reservation}}
+			uc := NewOrderUsecase(repos.Orders, repos.Payments, repos.Sagas, inv, NewFakePaymentProvider())
+
+			if err := repos.Orders.Create(ctx, &domain.Order{
+				ID:     "o1",
+				UserID: "u1",
+				Items:  []domain.OrderItem{{ProductID: "p1", Quantity: 1, Price: 10}},
+				Status: tt.from,
+			}); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+
+			_, err := uc.UpdateOrderStatus(ctx, "o1", tt.to, "test", 0)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("UpdateOrderStatus returned %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("UpdateOrderStatus: %v", err)
+			}
+
+			stored, err := repos.Orders.FindByID(ctx, "o1")
+			if err != nil {
+				t.Fatalf("FindByID: %v", err)
+			}
+			if tt.wantErr != nil {
+				if stored.Status != tt.from || len(stored.StatusHistory) != 0 {
+					t.Fatalf("refused change left order %s with history %+v", stored.Status, stored.StatusHistory)
+				}
+				return
+			}
+
+			if stored.Status != tt.to {
+				t.Fatalf("status = %s, want %s", stored.Status, tt.to)
+			}
+			if len(stored.StatusHistory) != 1 {
+				t.Fatalf("history has %d entries, want 1", len(stored.StatusHistory))
+			}
+			change := stored.StatusHistory[0]
+			if change.From != tt.from || change.To != tt.to || change.ChangedBy != "a1" || change.Reason != "test" || change.ChangedAt.IsZero() {
+				t.Fatalf("history entry = %+v", change)
+			}
+		})
+	}
+}
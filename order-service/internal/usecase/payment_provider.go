@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"order-service/internal/domain"
+)
+
+// PaymentAuthorization asks a payment provider to hold an amount on a
+// customer's payment method. Authorizing twice with the same
+// IdempotencyKey holds the money once.
+type PaymentAuthorization struct {
+	OrderID        string
+	Amount         float64
+	Method         string
+	IdempotencyKey string
+}
+
+// PaymentProvider moves money for orders. Authorize returns the
+// provider's reference of the payment, which the other calls act on.
+// A provider reports a refusal by the customer's bank or card issuer as
+// domain.ErrPaymentDeclined; any other error means the provider could not
+// be reached or failed.
+type PaymentProvider interface {
+	Authorize(ctx context.Context, auth PaymentAuthorization) (string, error)
+	Capture(ctx context.Context, reference string, amount float64) error
+	Refund(ctx context.Context, reference string, amount float64) error
+	Void(ctx context.Context, reference string) error
+}
+
+// Payment methods that make the fake provider misbehave. Every other
+// non-empty method is accepted.
+const (
+	FakeMethodDeclined        = "fake_declined"
+	FakeMethodCaptureDeclined = "fake_capture_declined"
+	FakeMethodUnavailable     = "fake_unavailable"
+)
+
+var errFakeUnavailable = errors.New("fake payment provider unavailable")
+
+type fakePayment struct {
+	method     string
+	authorized float64
+	captured   float64
+	refunded   float64
+	voided     bool
+}
+
+type fakePaymentProvider struct {
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+}
+
+// NewFakePaymentProvider returns a PaymentProvider that keeps payments in
+// process memory, for development and tests. It is deterministic: the
+// reference of a payment is derived from its idempotency key, and the
+// outcome of every call from the payment method it was authorized with.
+func NewFakePaymentProvider() PaymentProvider {
+	return &fakePaymentProvider{
+		payments: map[string]*fakePayment{},
+	}
+}
+
+func (p *fakePaymentProvider) Authorize(ctx context.Context, auth PaymentAuthorization) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch auth.Method {
+	case FakeMethodDeclined:
+		return "", fmt.Errorf("%w: card declined", domain.ErrPaymentDeclined)
+	case FakeMethodUnavailable:
+		return "", errFakeUnavailable
+	}
+	if auth.Amount <= 0 {
+		return "", fmt.Errorf("%w: amount must be positive", domain.ErrPaymentDeclined)
+	}
+
+	reference := "fake_" + auth.IdempotencyKey
+	if _, ok := p.payments[reference]; !ok {
+		p.payments[reference] = &fakePayment{method: auth.Method, authorized: auth.Amount}
+	}
+	return reference, nil
+}
+
+func (p *fakePaymentProvider) Capture(ctx context.Context, reference string, amount float64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.find(reference)
+	if err != nil {
+		return err
+	}
+	switch {
+	case payment.method == FakeMethodCaptureDeclined:
+		return fmt.Errorf("%w: insufficient funds", domain.ErrPaymentDeclined)
+	case payment.voided:
+		return fmt.Errorf("%w: payment %s was voided", domain.ErrPaymentDeclined, reference)
+	case payment.captured+amount > payment.authorized:
+		return fmt.Errorf("%w: capture exceeds authorized amount", domain.ErrPaymentDeclined)
+	}
+	payment.captured += amount
+	return nil
+}
+
+func (p *fakePaymentProvider) Refund(ctx context.Context, reference string, amount float64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.find(reference)
+	if err != nil {
+		return err
+	}
+	if payment.refunded+amount > payment.captured {
+		return fmt.Errorf("%w: refund exceeds captured amount", domain.ErrPaymentDeclined)
+	}
+	payment.refunded += amount
+	return nil
+}
+
+func (p *fakePaymentProvider) Void(ctx context.Context, reference string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.find(reference)
+	if err != nil {
+		return err
+	}
+	if payment.captured > 0 {
+		return fmt.Errorf("%w: payment %s was already captured", domain.ErrPaymentDeclined, reference)
+	}
+	payment.voided = true
+	return nil
+}
+
+func (p *fakePaymentProvider) find(reference string) (*fakePayment, error) {
+	payment, ok := p.payments[reference]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown payment %s", domain.ErrPaymentDeclined, reference)
+	}
+	return payment, nil
+}
@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abaika-abay/ecommerce/protos/inventory"
+	"order-service/internal/domain"
+	"order-service/internal/repository"
+)
+
+type ReturnUsecase interface {
+	RequestReturn(ctx context.Context, orderID string, items []domain.ReturnItem, reason string) (*domain.Return, error)
+	GetReturn(ctx context.Context, id string) (*domain.Return, error)
+	ListOrderReturns(ctx context.Context, orderID string) ([]*domain.Return, error)
+	ApproveReturn(ctx context.Context, id string, note string) (*domain.Return, error)
+	RejectReturn(ctx context.Context, id string, note string) (*domain.Return, error)
+	ReceiveReturn(ctx context.Context, id string, items []domain.ReturnItem) (*domain.Return, error)
+}
+
+type returnUsecase struct {
+	returns   repository.ReturnRepository
+	orders    repository.OrderRepository
+	inventory inventory.InventoryServiceClient
+	payer     payer
+	window    time.Duration
+}
+
+// NewReturnUsecase returns a ReturnUsecase that accepts returns for up to
+// window after an order was delivered.
+func NewReturnUsecase(returns repository.ReturnRepository, orders repository.OrderRepository, payments repository.PaymentRepository, inventoryClient inventory.InventoryServiceClient, provider PaymentProvider, window time.Duration) ReturnUsecase {
+	return &returnUsecase{
+		returns:   returns,
+		orders:    orders,
+		inventory: inventoryClient,
+		payer:     payer{attempts: payments, provider: provider},
+		window:    window,
+	}
+}
+
+// RequestReturn asks to send back items of a delivered order within the
+// return window. Each item names an order line and how many of its units
+// go back; units already in another return that was not rejected cannot
+// be returned again.
+// Corresponds to: rpc RequestReturn(RequestReturnRequest) returns (ReturnResponse)
+func (uc *returnUsecase) RequestReturn(ctx context.Context, orderID string, items []domain.ReturnItem, reason string) (*domain.Return, error) {
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", domain.ErrInvalidReturn)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: return must contain at least one item", domain.ErrInvalidReturn)
+	}
+
+	order, err := findOwnedOrder(ctx, uc.orders, orderID)
+	if err != nil {
+		return nil, err
+	}
+	deliveredAt, delivered := order.DeliveredAt()
+	if order.Status != domain.OrderStatusDelivered || !delivered {
+		return nil, fmt.Errorf("%w: order %s has not been delivered", domain.ErrNotReturnable, orderID)
+	}
+	if closesAt := deliveredAt.Add(uc.window); time.Now().After(closesAt) {
+		return nil, fmt.Errorf("%w: the return window closed on %s", domain.ErrNotReturnable, closesAt.Format(time.RFC3339))
+	}
+
+	// Another return of the order may be stored between listing the earlier
+	// ones and creating this one; Create then refuses it and the items are
+	// checked again against what is left.
+	for {
+		earlier, err := uc.returns.ListByOrder(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		ret, err := newReturn(order, earlier, items, reason)
+		if err != nil {
+			return nil, err
+		}
+		err = uc.returns.Create(ctx, ret, len(earlier))
+		if errors.Is(err, domain.ErrReturnConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+}
+
+// GetReturn fetches a return by ID.
+// Corresponds to: rpc GetReturn(GetReturnRequest) returns (ReturnResponse)
+func (uc *returnUsecase) GetReturn(ctx context.Context, id string) (*domain.Return, error) {
+	return uc.findVisibleReturn(ctx, id)
+}
+
+// ListOrderReturns returns every return of an order, oldest first.
+// Corresponds to: rpc ListOrderReturns(ListReturnsRequest) returns (ListReturnsResponse)
+func (uc *returnUsecase) ListOrderReturns(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	if !isWarehouseStaff(ctx) {
+		if _, err := findOwnedOrder(ctx, uc.orders, orderID); err != nil {
+			return nil, err
+		}
+	}
+	return uc.returns.ListByOrder(ctx, orderID)
+}
+
+// ApproveReturn lets the customer send the items of a requested return.
+// Corresponds to: rpc ApproveReturn(ReviewReturnRequest) returns (ReturnResponse)
+func (uc *returnUsecase) ApproveReturn(ctx context.Context, id string, note string) (*domain.Return, error) {
+	return uc.review(ctx, id, domain.ReturnApproved, note)
+}
+
+// RejectReturn turns down a requested return; the note says why.
+// Corresponds to: rpc RejectReturn(ReviewReturnRequest) returns (ReturnResponse)
+func (uc *returnUsecase) RejectReturn(ctx context.Context, id string, note string) (*domain.Return, error) {
+	if note == "" {
+		return nil, fmt.Errorf("%w: a rejection needs a note", domain.ErrInvalidReturn)
+	}
+	return uc.review(ctx, id, domain.ReturnRejected, note)
+}
+
+// ReceiveReturn records the condition each item of an approved return
+// arrived in, then settles it: resellable items go back into stock in
+// inventory, damaged ones are written off, and the price of every
+// returned item is refunded. A return that was received but not settled
+// is settled again, so the call can be retried until the return is
+// refunded; items need not be given again then.
+// Corresponds to: rpc ReceiveReturn(ReceiveReturnRequest) returns (ReturnResponse)
+func (uc *returnUsecase) ReceiveReturn(ctx context.Context, id string, items []domain.ReturnItem) (*domain.Return, error) {
+	if !isWarehouseStaff(ctx) {
+		return nil, domain.ErrStaffOnly
+	}
+
+	ret, err := uc.returns.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ret.Status {
+	case domain.ReturnRefunded:
+		return ret, nil
+	case domain.ReturnApproved:
+		if err := applyConditions(ret, items); err != nil {
+			return nil, err
+		}
+		ret.Status = domain.ReturnReceived
+		if err := uc.returns.Update(ctx, ret, domain.ReturnApproved); err != nil {
+			return nil, err
+		}
+	case domain.ReturnReceived:
+	default:
+		return nil, fmt.Errorf("%w: %s returns cannot be received", domain.ErrReturnTransition, ret.Status)
+	}
+
+	return uc.settle(ctx, ret)
+}
+
+// review moves a requested return to status on behalf of warehouse staff.
+func (uc *returnUsecase) review(ctx context.Context, id string, status domain.ReturnStatus, note string) (*domain.Return, error) {
+	if !isWarehouseStaff(ctx) {
+		return nil, domain.ErrStaffOnly
+	}
+
+	ret, err := uc.returns.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status == status {
+		return ret, nil
+	}
+	if !ret.Status.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: return %s is %s", domain.ErrReturnTransition, id, ret.Status)
+	}
+
+	from := ret.Status
+	ret.Status = status
+	ret.Note = note
+	if err := uc.returns.Update(ctx, ret, from); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// settle puts the items of a received return back into inventory and
+// refunds them, then marks the return refunded. Inventory takes each
+// return back once, and a refund already recorded for the return is not
+// issued again, so settling can be repeated after a failure.
+func (uc *returnUsecase) settle(ctx context.Context, ret *domain.Return) (*domain.Return, error) {
+	items := make([]*inventory.ReturnedItem, len(ret.Items))
+	for i, item := range ret.Items {
+		items[i] = &inventory.ReturnedItem{
+			ProductId:  item.ProductID,
+			VariantSku: item.VariantSKU,
+			Quantity:   int32(item.Quantity),
+			Resellable: item.Condition == domain.ConditionResellable,
+		}
+	}
+	if _, err := uc.inventory.ReturnStock(ctx, &inventory.ReturnStockRequest{
+		OrderId:  ret.OrderID,
+		ReturnId: ret.ID,
+		Items:    items,
+	}); err != nil {
+		return nil, err
+	}
+
+	attempts, err := uc.payer.attempts.ListByOrder(ctx, ret.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	refunded, done := refundFor(attempts, ret.ID)
+	if !done {
+		// Never refund more than is left of the payment
+		reference, outstanding := domain.CapturedPayment(attempts)
+		refunded = min(ret.Value(), outstanding)
+		if refunded > 0 {
+			if err := uc.payer.refund(ctx, ret.OrderID, ret.ID, reference, refunded); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ret.Status = domain.ReturnRefunded
+	ret.RefundAmount = refunded
+	if err := uc.returns.Update(ctx, ret, domain.ReturnReceived); err != nil {
+		if errors.Is(err, domain.ErrReturnConflict) {
+			// Settled concurrently
+			return uc.returns.FindByID(ctx, ret.ID)
+		}
+		return nil, err
+	}
+	return ret, nil
+}
+
+// findVisibleReturn loads a return the caller is allowed to see: their
+// own, or any for warehouse staff and admins. Others are reported as not
+// found so their IDs do not leak.
+func (uc *returnUsecase) findVisibleReturn(ctx context.Context, id string) (*domain.Return, error) {
+	ret, err := uc.returns.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isWarehouseStaff(ctx) && !mayAccess(ctx, ret.UserID) {
+		return nil, domain.ErrReturnNotFound
+	}
+	return ret, nil
+}
+
+// newReturn builds a requested return of items from order, pricing each
+// line as it was sold. Units in earlier returns that were not rejected
+// cannot be returned again.
+func newReturn(order *domain.Order, earlier []*domain.Return, items []domain.ReturnItem, reason string) (*domain.Return, error) {
+	// What is left to return of each line
+	type key struct{ productID, sku string }
+	returnable := map[key]int{}
+	prices := map[key]float64{}
+	for _, item := range order.Items {
+		k := key{item.ProductID, item.VariantSKU}
+		returnable[k] += item.Quantity
+		prices[k] = item.Price
+	}
+	for _, ret := range earlier {
+		if ret.Status == domain.ReturnRejected {
+			continue
+		}
+		for _, item := range ret.Items {
+			returnable[key{item.ProductID, item.VariantSKU}] -= item.Quantity
+		}
+	}
+
+	lines := make([]domain.ReturnItem, len(items))
+	for i, item := range items {
+		k := key{item.ProductID, item.VariantSKU}
+		if _, ok := prices[k]; !ok {
+			return nil, fmt.Errorf("%w: item %d is not part of order %s", domain.ErrInvalidReturn, i, order.ID)
+		}
+		if item.Quantity <= 0 || item.Quantity > returnable[k] {
+			return nil, fmt.Errorf("%w: item %d must return between 1 and %d units", domain.ErrInvalidReturn, i, max(returnable[k], 0))
+		}
+		returnable[k] -= item.Quantity
+		lines[i] = domain.ReturnItem{
+			ProductID:  item.ProductID,
+			VariantSKU: item.VariantSKU,
+			Quantity:   item.Quantity,
+			Price:      prices[k],
+		}
+	}
+
+	return &domain.Return{
+		OrderID: order.ID,
+		UserID:  order.UserID,
+		Items:   lines,
+		Reason:  reason,
+		Status:  domain.ReturnRequested,
+	}, nil
+}
+
+// applyConditions sets the condition of every item of ret from items,
+// which must name each of its lines once.
+func applyConditions(ret *domain.Return, items []domain.ReturnItem) error {
+	type key struct{ productID, sku string }
+	conditions := make(map[key]domain.ItemCondition, len(items))
+	for i, item := range items {
+		if !item.Condition.IsValid() {
+			return fmt.Errorf("%w: item %d has unknown condition %q", domain.ErrInvalidReturn, i, item.Condition)
+		}
+		conditions[key{item.ProductID, item.VariantSKU}] = item.Condition
+	}
+
+	for i := range ret.Items {
+		condition, ok := conditions[key{ret.Items[i].ProductID, ret.Items[i].VariantSKU}]
+		if !ok {
+			return fmt.Errorf("%w: no condition given for product %s", domain.ErrInvalidReturn, ret.Items[i].ProductID)
+		}
+		ret.Items[i].Condition = condition
+	}
+	return nil
+}
+
+// refundFor reports the amount refunded for a return, and whether a
+// refund for it has been issued at all.
+func refundFor(attempts []*domain.PaymentAttempt, returnID string) (float64, bool) {
+	for _, attempt := range attempts {
+		if attempt.Operation == domain.PaymentRefund && attempt.ReturnID == returnID && attempt.Succeeded {
+			return attempt.Amount, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+	"order-service/internal/repository"
+)
+
+// deliveredOrder stores an order of three units of p1 and one of p2,
+// delivered a minute ago.
+func deliveredOrder(t *testing.T, orders repository.OrderRepository) *domain.Order {
+	t.Helper()
+
+	order := &domain.Order{
+		ID:     "o1",
+		UserID: "u1",
+		Items: []domain.OrderItem{
+			{ProductID: "p1", Quantity: 3, Price: 10},
+			{ProductID: "p2", Quantity: 1, Price: 5},
+		},
+		Status: domain.OrderStatusDelivered,
+		StatusHistory: []domain.StatusChange{
+			{From: domain.OrderStatusShipped, To: domain.OrderStatusDelivered, ChangedBy: "w1", ChangedAt: time.Now().Add(-time.Minute)},
+		},
+	}
+	if err := orders.Create(context.Background(), order); err != nil {
+		t.Fatalf("Create order: %v", err)
+	}
+	return order
+}
+
+func TestRequestReturnRejectsOverReturn(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepositories()
+	order := deliveredOrder(t, repos.Orders)
+	uc := NewReturnUsecase(repos.Returns, repos.Orders, repos.Payments, nil, nil, time.Hour)
+
+	if _, err := uc.RequestReturn(ctx, order.ID, []domain.ReturnItem{{ProductID: "p1", Quantity: 4}}, "too many"); !errors.Is(err, domain.ErrInvalidReturn) {
+		t.Fatalf("returning more than was sold: err = %v, want ErrInvalidReturn", err)
+	}
+
+	first, err := uc.RequestReturn(ctx, order.ID, []domain.ReturnItem{{ProductID: "p1", Quantity: 2}}, "broken")
+	if err != nil {
+		t.Fatalf("RequestReturn: %v", err)
+	}
+	if got := first.Value(); got != 20 {
+		t.Fatalf("return value = %v, want 20", got)
+	}
+
+	if _, err := uc.RequestReturn(ctx, order.ID, []domain.ReturnItem{{ProductID: "p1", Quantity: 2}}, "broken"); !errors.Is(err, domain.ErrInvalidReturn) {
+		t.Fatalf("returning units already returned: err = %v, want ErrInvalidReturn", err)
+	}
+	if _, err := uc.RequestReturn(ctx, order.ID, []domain.ReturnItem{{ProductID: "p1", Quantity: 1}, {ProductID: "p1", Quantity: 1}}, "broken"); !errors.Is(err, domain.ErrInvalidReturn) {
+		t.Fatalf("one line named twice: err = %v, want ErrInvalidReturn", err)
+	}
+
+	// Units of a rejected return can be asked for again
+	if _, err := uc.RejectReturn(ctx, first.ID, "not broken"); err != nil {
+		t.Fatalf("RejectReturn: %v", err)
+	}
+	if _, err := uc.RequestReturn(ctx, order.ID, []domain.ReturnItem{{ProductID: "p1", Quantity: 3}}, "broken"); err != nil {
+		t.Fatalf("RequestReturn after rejection: %v", err)
+	}
+}
+
+func TestRequestReturnConcurrent(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepositories()
+	order := deliveredOrder(t, repos.Orders)
+	uc := NewReturnUsecase(repos.Returns, repos.Orders, repos.Payments, nil, nil, time.Hour)
+
+	const requests = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := uc.RequestReturn(ctx, order.ID, []domain.ReturnItem{{ProductID: "p1", Quantity: 1}}, "broken")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	accepted := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			accepted++
+		case !errors.Is(err, domain.ErrInvalidReturn):
+			t.Fatalf("RequestReturn: %v", err)
+		}
+	}
+	if accepted != 3 {
+		t.Fatalf("%d returns accepted, want 3", accepted)
+	}
+
+	returns, err := repos.Returns.ListByOrder(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("ListByOrder: %v", err)
+	}
+	returned := 0
+	for _, ret := range returns {
+		for _, item := range ret.Items {
+			returned += item.Quantity
+		}
+	}
+	if returned != 3 {
+		t.Fatalf("%d units returned, want 3", returned)
+	}
+}
@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"order-service/internal/domain"
+	"order-service/internal/repository"
+)
+
+const (
+	// sagaLease is how long a running saga is left alone by recovery;
+	// every step that completes extends it.
+	sagaLease = 30 * time.Second
+	// sagaMaxAttempts bounds how often a step's action is retried before
+	// the saga gives up and compensates. Compensations are retried until
+	// they succeed.
+	sagaMaxAttempts = 8
+	// sagaInlineWait is the longest retry delay slept through while the
+	// caller waits; longer ones are left to recovery.
+	sagaInlineWait = time.Second
+	// sagaRecoveryBatchSize bounds how many due sagas one ResumeSagas pass
+	// picks up.
+	sagaRecoveryBatchSize = 50
+)
+
+// sagaStep defines one step of a saga. action does the step's work and
+// compensate undoes it once it completed; both must be safe to repeat,
+// since a step interrupted by a crash runs again. compensate is nil for
+// steps with nothing to undo, and skip, when set, reports whether a step
+// does not apply to a saga at all.
+type sagaStep struct {
+	name       string
+	skip       func(saga *domain.Saga) bool
+	action     func(ctx context.Context, saga *domain.Saga) error
+	compensate func(ctx context.Context, saga *domain.Saga) error
+}
+
+// sagaOrchestrator runs sagas step by step, persisting their state after
+// every step so that they survive a crash.
+type sagaOrchestrator struct {
+	sagas       repository.SagaRepository
+	definitions map[domain.SagaType][]sagaStep
+}
+
+// start sets up the steps of a new saga for its type, stores it and runs
+// it. See run for what the returned error means.
+func (o *sagaOrchestrator) start(ctx context.Context, saga *domain.Saga) error {
+	steps := o.definitions[saga.Type]
+	saga.Status = domain.SagaRunning
+	saga.Steps = make([]domain.SagaStep, len(steps))
+	for i, step := range steps {
+		saga.Steps[i] = domain.SagaStep{Name: step.name, Status: domain.SagaStepPending}
+	}
+	saga.NextAttemptAt = time.Now().Add(sagaLease)
+
+	if err := o.sagas.Create(ctx, saga); err != nil {
+		return err
+	}
+	return o.run(ctx, saga)
+}
+
+// run drives a saga as far as it can go. It returns nil once the saga
+// completed, and the error of the step that failed for good once it
+// compensates, even if compensating has to be retried later. A saga
+// waiting to retry a step yields ErrSagaPending; recovery resumes it when
+// the retry is due.
+func (o *sagaOrchestrator) run(ctx context.Context, saga *domain.Saga) error {
+	steps, ok := o.definitions[saga.Type]
+	if !ok || len(steps) != len(saga.Steps) {
+		return fmt.Errorf("saga %s has unknown type %q", saga.ID, saga.Type)
+	}
+
+	// The saga outlives the call that started it
+	ctx = context.WithoutCancel(ctx)
+
+	var failure error
+	for !saga.Status.IsFinished() {
+		i := saga.NextStep()
+		if i < 0 {
+			if saga.Status == domain.SagaRunning {
+				saga.Status = domain.SagaCompleted
+			} else {
+				saga.Status = domain.SagaCompensated
+			}
+			if err := o.save(ctx, saga); err != nil {
+				return err
+			}
+			continue
+		}
+
+		step, state := steps[i], &saga.Steps[i]
+		if saga.Status == domain.SagaRunning {
+			if step.skip != nil && step.skip(saga) {
+				setStepStatus(state, domain.SagaStepSkipped)
+			} else if err := step.action(ctx, saga); err != nil {
+				state.Attempts++
+				state.Error = err.Error()
+				if retryable(err) && state.Attempts < sagaMaxAttempts {
+					retryNow, saveErr := o.backOff(ctx, saga, state.Attempts)
+					if saveErr != nil {
+						return saveErr
+					}
+					if !retryNow {
+						return fmt.Errorf("%w: step %s will be retried: %v", domain.ErrSagaPending, step.name, err)
+					}
+					continue
+				}
+				log.Printf("saga %s: step %s failed, compensating: %v", saga.ID, step.name, err)
+				failure = err
+				state.Status = domain.SagaStepFailed
+				state.UpdatedAt = time.Now()
+				saga.Status = domain.SagaCompensating
+				saga.Error = fmt.Sprintf("%s: %v", step.name, err)
+			} else {
+				setStepStatus(state, domain.SagaStepCompleted)
+			}
+		} else {
+			var err error
+			if step.compensate != nil {
+				err = step.compensate(ctx, saga)
+			}
+			if err != nil {
+				state.Attempts++
+				state.Error = err.Error()
+				log.Printf("saga %s: compensating step %s failed: %v", saga.ID, step.name, err)
+				retryNow, saveErr := o.backOff(ctx, saga, state.Attempts)
+				if failure != nil && !retryNow {
+					return failure
+				}
+				if saveErr != nil {
+					return saveErr
+				}
+				if !retryNow {
+					return fmt.Errorf("%w: undoing step %s will be retried: %v", domain.ErrSagaPending, step.name, err)
+				}
+				continue
+			}
+			setStepStatus(state, domain.SagaStepCompensated)
+		}
+
+		if err := o.save(ctx, saga); err != nil {
+			return err
+		}
+	}
+
+	if saga.Status == domain.SagaCompensated && failure == nil {
+		failure = errors.New(saga.Error)
+	}
+	return failure
+}
+
+// resume runs every saga that is due and returns how many of them
+// finished.
+func (o *sagaOrchestrator) resume(ctx context.Context) (int, error) {
+	sagas, err := o.sagas.ListDue(ctx, time.Now(), sagaRecoveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	finished := 0
+	for _, saga := range sagas {
+		// Take the saga over before running it so that a concurrent pass
+		// leaves it alone
+		if err := o.save(ctx, saga); err != nil {
+			if !errors.Is(err, domain.ErrSagaConflict) {
+				log.Printf("failed to resume saga %s: %v", saga.ID, err)
+			}
+			continue
+		}
+		if err := o.run(ctx, saga); err != nil && !saga.Status.IsFinished() {
+			log.Printf("saga %s of order %s is still %s: %v", saga.ID, saga.OrderID, saga.Status, err)
+			continue
+		}
+		finished++
+	}
+	return finished, nil
+}
+
+// save stores a saga and keeps it leased to the caller.
+func (o *sagaOrchestrator) save(ctx context.Context, saga *domain.Saga) error {
+	saga.NextAttemptAt = time.Now().Add(sagaLease)
+	return o.sagas.Update(ctx, saga)
+}
+
+// backOff schedules the next attempt of a failed step. Short delays are
+// waited out, and backOff reports true for the caller to retry at once;
+// longer ones are stored as the saga's next attempt for recovery to pick
+// up.
+func (o *sagaOrchestrator) backOff(ctx context.Context, saga *domain.Saga, attempts int) (bool, error) {
+	delay := sagaRetryDelay(attempts)
+	if delay > sagaInlineWait {
+		saga.NextAttemptAt = time.Now().Add(delay)
+		return false, o.sagas.Update(ctx, saga)
+	}
+
+	if err := o.save(ctx, saga); err != nil {
+		return false, err
+	}
+	time.Sleep(delay)
+	return true, nil
+}
+
+// sagaRetryDelay doubles the wait before each retry of a step, starting at
+// 200ms and topping out at a minute.
+func sagaRetryDelay(attempts int) time.Duration {
+	delay := 200 * time.Millisecond
+	for i := 1; i < attempts && delay < time.Minute; i++ {
+		delay *= 2
+	}
+	return min(delay, time.Minute)
+}
+
+func setStepStatus(state *domain.SagaStep, status domain.SagaStepStatus) {
+	state.Status = status
+	state.Attempts = 0
+	state.Error = ""
+	state.UpdatedAt = time.Now()
+}
+
+// retryable reports whether a step that failed with err may succeed when
+// run again: the payment provider or another service could not be
+// reached, or the order changed under the step. Anything else, such as
+// missing stock or a declined card, fails the saga.
+func retryable(err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrPaymentFailed),
+		errors.Is(err, domain.ErrStatusConflict),
+		errors.Is(err, context.DeadlineExceeded):
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"order-service/internal/domain"
+	"order-service/internal/repository"
+)
+
+const testSaga domain.SagaType = "test"
+
+// stepLog records the actions and compensations a saga runs.
+type stepLog struct {
+	calls []string
+}
+
+func (l *stepLog) step(name string, err func() error) sagaStep {
+	return sagaStep{
+		name: name,
+		action: func(ctx context.Context, saga *domain.Saga) error {
+			l.add(name)
+			if err != nil {
+				return err()
+			}
+			return nil
+		},
+		compensate: func(ctx context.Context, saga *domain.Saga) error {
+			l.add("undo " + name)
+			return nil
+		},
+	}
+}
+
+func (l *stepLog) add(call string) {
+	l.calls = append(l.calls, call)
+}
+
+func newTestOrchestrator(steps ...sagaStep) *sagaOrchestrator {
+	return &sagaOrchestrator{
+		sagas:       repository.NewMemorySagaRepository(),
+		definitions: map[domain.SagaType][]sagaStep{testSaga: steps},
+	}
+}
+
+func stepStatuses(saga *domain.Saga) []domain.SagaStepStatus {
+	statuses := make([]domain.SagaStepStatus, len(saga.Steps))
+	for i, step := range saga.Steps {
+		statuses[i] = step.Status
+	}
+	return statuses
+}
+
+func TestSagaCompensatesInReverseOrder(t *testing.T) {
+	ctx := context.Background()
+	declined := errors.New("declined")
+
+	var ran stepLog
+	second := ran.step("second", nil)
+	second.compensate = nil
+	o := newTestOrchestrator(
+		ran.step("first", nil),
+		second,
+		ran.step("third", nil),
+		ran.step("fourth", func() error { return declined }),
+	)
+
+	saga := &domain.Saga{Type: testSaga, OrderID: "o1"}
+	if err := o.start(ctx, saga); !errors.Is(err, declined) {
+		t.Fatalf("start returned %v, want the failed step's error", err)
+	}
+
+	want := []string{"first", "second", "third", "fourth", "undo third", "undo first"}
+	if !reflect.DeepEqual(ran.calls, want) {
+		t.Fatalf("calls = %v, want %v", ran.calls, want)
+	}
+
+	stored, err := o.sagas.FindByOrderID(ctx, "o1")
+	if err != nil {
+		t.Fatalf("FindByOrderID: %v", err)
+	}
+	if stored.Status != domain.SagaCompensated {
+		t.Fatalf("saga status = %s, want %s", stored.Status, domain.SagaCompensated)
+	}
+	wantStatuses := []domain.SagaStepStatus{
+		domain.SagaStepCompensated,
+		domain.SagaStepCompensated,
+		domain.SagaStepCompensated,
+		domain.SagaStepFailed,
+	}
+	if got := stepStatuses(stored); !reflect.DeepEqual(got, wantStatuses) {
+		t.Fatalf("step statuses = %v, want %v", got, wantStatuses)
+	}
+	if stored.Error != "fourth: declined" {
+		t.Fatalf("saga error = %q, want %q", stored.Error, "fourth: declined")
+	}
+}
+
+func TestSagaRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 200 * time.Millisecond},
+		{attempts: 2, want: 400 * time.Millisecond},
+		{attempts: 3, want: 800 * time.Millisecond},
+		{attempts: 4, want: 1600 * time.Millisecond},
+		{attempts: 9, want: 51200 * time.Millisecond},
+		{attempts: 10, want: time.Minute},
+		{attempts: 100, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := sagaRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("sagaRetryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSagaRetriesShortDelaysInline(t *testing.T) {
+	ctx := context.Background()
+
+	failures := 2
+	var ran stepLog
+	o := newTestOrchestrator(
+		ran.step("flaky", func() error {
+			if failures > 0 {
+				failures--
+				return status.Error(codes.Unavailable, "down")
+			}
+			return nil
+		}),
+	)
+
+	saga := &domain.Saga{Type: testSaga, OrderID: "o1"}
+	if err := o.start(ctx, saga); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if saga.Status != domain.SagaCompleted {
+		t.Fatalf("saga status = %s, want %s", saga.Status, domain.SagaCompleted)
+	}
+	if step := saga.Steps[0]; step.Status != domain.SagaStepCompleted || step.Attempts != 0 || step.Error != "" {
+		t.Fatalf("step = %+v, want completed with its failures cleared", step)
+	}
+	if len(ran.calls) != 3 {
+		t.Fatalf("step ran %d times, want 3", len(ran.calls))
+	}
+}
+
+func TestSagaRecovery(t *testing.T) {
+	ctx := context.Background()
+
+	failures := 4
+	var ran stepLog
+	o := newTestOrchestrator(
+		ran.step("first", nil),
+		ran.step("flaky", func() error {
+			if failures > 0 {
+				failures--
+				return status.Error(codes.Unavailable, "down")
+			}
+			return nil
+		}),
+	)
+
+	// The fourth delay is longer than the caller waits, so the saga is left
+	// for recovery
+	saga := &domain.Saga{Type: testSaga, OrderID: "o1"}
+	if err := o.start(ctx, saga); !errors.Is(err, domain.ErrSagaPending) {
+		t.Fatalf("start returned %v, want ErrSagaPending", err)
+	}
+
+	stored, err := o.sagas.FindByOrderID(ctx, "o1")
+	if err != nil {
+		t.Fatalf("FindByOrderID: %v", err)
+	}
+	if stored.Status != domain.SagaRunning || stored.Steps[1].Attempts != 4 {
+		t.Fatalf("saga = %+v, want running after 4 attempts", stored)
+	}
+	if wait := time.Until(stored.NextAttemptAt); wait <= sagaInlineWait {
+		t.Fatalf("next attempt in %v, want after %v", wait, sagaInlineWait)
+	}
+
+	// Not due yet
+	if finished, err := o.resume(ctx); err != nil || finished != 0 {
+		t.Fatalf("resume = %d, %v before the retry is due", finished, err)
+	}
+
+	stored.NextAttemptAt = time.Now().Add(-time.Second)
+	if err := o.sagas.Update(ctx, stored); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if finished, err := o.resume(ctx); err != nil || finished != 1 {
+		t.Fatalf("resume = %d, %v, want 1 finished", finished, err)
+	}
+
+	stored, err = o.sagas.FindByOrderID(ctx, "o1")
+	if err != nil {
+		t.Fatalf("FindByOrderID: %v", err)
+	}
+	if stored.Status != domain.SagaCompleted {
+		t.Fatalf("saga status = %s, want %s", stored.Status, domain.SagaCompleted)
+	}
+	// The completed first step is not run again
+	want := []string{"first", "flaky", "flaky", "flaky", "flaky", "flaky"}
+	if !reflect.DeepEqual(ran.calls, want) {
+		t.Fatalf("calls = %v, want %v", ran.calls, want)
+	}
+}
+
+func TestSagaRecoveryLeavesLeasedSagas(t *testing.T) {
+	ctx := context.Background()
+
+	var ran stepLog
+	o := newTestOrchestrator(ran.step("first", nil))
+
+	// A saga another process is running holds a lease until NextAttemptAt
+	saga := &domain.Saga{
+		Type:          testSaga,
+		OrderID:       "o1",
+		Status:        domain.SagaRunning,
+		Steps:         []domain.SagaStep{{Name: "first", Status: domain.SagaStepPending}},
+		NextAttemptAt: time.Now().Add(sagaLease),
+	}
+	if err := o.sagas.Create(ctx, saga); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if finished, err := o.resume(ctx); err != nil || finished != 0 {
+		t.Fatalf("resume = %d, %v while the saga is leased", finished, err)
+	}
+	if len(ran.calls) != 0 {
+		t.Fatalf("leased saga ran %v", ran.calls)
+	}
+
+	// Its process crashed and the lease ran out
+	saga.NextAttemptAt = time.Now().Add(-time.Second)
+	if err := o.sagas.Update(ctx, saga); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if finished, err := o.resume(ctx); err != nil || finished != 1 {
+		t.Fatalf("resume = %d, %v, want 1 finished", finished, err)
+	}
+	if !reflect.DeepEqual(ran.calls, []string{"first"}) {
+		t.Fatalf("calls = %v, want [first]", ran.calls)
+	}
+}
+
+func TestPlaceOrderCompensatesFailedCharge(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepositories()
+	inv := &stubInventory{reservations: map[string]string{}}
+	uc := NewOrderUsecase(repos.Orders, repos.Payments, repos.Sagas, inv, NewFakePaymentProvider())
+
+	order := &domain.Order{ID: "o1", UserID: "u1", Items: []domain.OrderItem{{ProductID: "p1", Quantity: 2}}}
+	if _, err := uc.CreateOrder(ctx, order, FakeMethodDeclined); !errors.Is(err, domain.ErrPaymentDeclined) {
+		t.Fatalf("CreateOrder returned %v, want ErrPaymentDeclined", err)
+	}
+
+	if got := inv.reservations["o1"]; got != "released" {
+		t.Fatalf("reservation is %q, want released", got)
+	}
+	stored, err := repos.Orders.FindByID(ctx, "o1")
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if stored.Status != domain.OrderStatusCancelled {
+		t.Fatalf("order status = %s, want %s", stored.Status, domain.OrderStatusCancelled)
+	}
+
+	saga, err := uc.GetOrderSaga(ctx, "o1")
+	if err != nil {
+		t.Fatalf("GetOrderSaga: %v", err)
+	}
+	if saga.Status != domain.SagaCompensated {
+		t.Fatalf("saga status = %s, want %s", saga.Status, domain.SagaCompensated)
+	}
+	want := []domain.SagaStepStatus{domain.SagaStepCompensated, domain.SagaStepCompensated, domain.SagaStepFailed}
+	if got := stepStatuses(saga); !reflect.DeepEqual(got, want) {
+		t.Fatalf("step statuses = %v, want %v", got, want)
+	}
+}